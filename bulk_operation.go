@@ -0,0 +1,433 @@
+package synergyshopify
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Types of bulk operations.
+const (
+	BulkOperationTypeQuery    = "QUERY"
+	BulkOperationTypeMutation = "MUTATION"
+)
+
+// Statuses of bulk operations.
+const (
+	BulkOperationStatusCreated   = "CREATED"
+	BulkOperationStatusRunning   = "RUNNING"
+	BulkOperationStatusCompleted = "COMPLETED"
+	BulkOperationStatusCanceling = "CANCELING"
+	BulkOperationStatusCanceled  = "CANCELED"
+	BulkOperationStatusFailed    = "FAILED"
+	BulkOperationStatusExpired   = "EXPIRED"
+)
+
+// wait between two polls of the current bulk operation
+var bulkOperationPollInterval = 2 * time.Second
+
+const bulkOperationFields = `id status type errorCode objectCount fileSize url partialDataUrl query createdAt completedAt`
+
+const (
+	bulkOperationRunQuery = `mutation bulkOperationRunQuery($query: String!) {
+  bulkOperationRunQuery(query: $query) {
+    bulkOperation { ` + bulkOperationFields + ` }
+    userErrors { field message code }
+  }
+}`
+
+	bulkOperationRunMutation = `mutation bulkOperationRunMutation($mutation: String!, $stagedUploadPath: String!) {
+  bulkOperationRunMutation(mutation: $mutation, stagedUploadPath: $stagedUploadPath) {
+    bulkOperation { ` + bulkOperationFields + ` }
+    userErrors { field message code }
+  }
+}`
+
+	bulkOperationCancel = `mutation bulkOperationCancel($id: ID!) {
+  bulkOperationCancel(id: $id) {
+    bulkOperation { ` + bulkOperationFields + ` }
+    userErrors { field message }
+  }
+}`
+
+	currentBulkOperation = `query currentBulkOperation($type: BulkOperationType!) {
+  currentBulkOperation(type: $type) { ` + bulkOperationFields + ` }
+}`
+)
+
+// BulkOperationService is an interface for running bulk operations of the
+// GraphQL Admin API, which export or import large amounts of data
+// asynchronously. A shop runs one bulk operation of each type at a time.
+// See: https://shopify.dev/docs/api/usage/bulk-operations/queries
+type BulkOperationService interface {
+	RunQuery(query string) (*BulkOperation, error)
+	RunMutation(mutation, stagedUploadPath string) (*BulkOperation, error)
+	Current(operationType string) (*BulkOperation, error)
+	Wait(operationType string) (*BulkOperation, error)
+	Cancel(id string) (*BulkOperation, error)
+}
+
+// BulkOperationServiceOp handles communication with the bulk operation
+// related fields of the GraphQL API.
+type BulkOperationServiceOp struct {
+	client *Client
+}
+
+// BulkOperation represents a Shopify bulk operation
+type BulkOperation struct {
+	ID        string `json:"id"`
+	Status    string `json:"status"`
+	Type      string `json:"type"`
+	ErrorCode string `json:"errorCode,omitempty"`
+	// ObjectCount and FileSize are unsigned 64 bit integers, as strings
+	ObjectCount    string     `json:"objectCount,omitempty"`
+	FileSize       string     `json:"fileSize,omitempty"`
+	URL            string     `json:"url,omitempty"`
+	PartialDataURL string     `json:"partialDataUrl,omitempty"`
+	Query          string     `json:"query,omitempty"`
+	CreatedAt      *time.Time `json:"createdAt,omitempty"`
+	CompletedAt    *time.Time `json:"completedAt,omitempty"`
+}
+
+// Done reports whether the operation stopped running, whether it completed
+// or not.
+func (op *BulkOperation) Done() bool {
+	switch op.Status {
+	case BulkOperationStatusCompleted, BulkOperationStatusCanceled, BulkOperationStatusFailed, BulkOperationStatusExpired:
+		return true
+	}
+	return false
+}
+
+// BulkOperationError occurs when a bulk operation stopped without completing,
+// or its results were requested before it completed.
+type BulkOperationError struct {
+	Operation BulkOperation
+}
+
+func (e BulkOperationError) Error() string {
+	msg := fmt.Sprintf("bulk operation %s is %s", e.Operation.ID, strings.ToLower(e.Operation.Status))
+	if e.Operation.ErrorCode != "" {
+		msg += ": " + e.Operation.ErrorCode
+	}
+	return msg
+}
+
+// bulkOperationPayload is the payload of the bulk operation mutations.
+type bulkOperationPayload struct {
+	BulkOperation *BulkOperation `json:"bulkOperation"`
+}
+
+// RunQuery starts a bulk query, e.g.
+//
+//	op, err := client.BulkOperation.RunQuery(`{
+//		products {
+//			edges { node { id title variants { edges { node { id sku } } } } }
+//		}
+//	}`)
+func (s *BulkOperationServiceOp) RunQuery(query string) (*BulkOperation, error) {
+	resp := struct {
+		Payload bulkOperationPayload `json:"bulkOperationRunQuery"`
+	}{}
+	err := s.client.GraphQL.Query(bulkOperationRunQuery, map[string]interface{}{"query": query}, &resp)
+	return resp.Payload.BulkOperation, err
+}
+
+// RunMutation starts a bulk mutation, run once for every line of the JSONL
+// file of variables uploaded to stagedUploadPath with the stagedUploadsCreate
+// mutation.
+func (s *BulkOperationServiceOp) RunMutation(mutation, stagedUploadPath string) (*BulkOperation, error) {
+	resp := struct {
+		Payload bulkOperationPayload `json:"bulkOperationRunMutation"`
+	}{}
+	variables := map[string]interface{}{"mutation": mutation, "stagedUploadPath": stagedUploadPath}
+	err := s.client.GraphQL.Query(bulkOperationRunMutation, variables, &resp)
+	return resp.Payload.BulkOperation, err
+}
+
+// Current returns the last bulk operation of the given type started by the
+// app, nil if none.
+func (s *BulkOperationServiceOp) Current(operationType string) (*BulkOperation, error) {
+	resp := struct {
+		CurrentBulkOperation *BulkOperation `json:"currentBulkOperation"`
+	}{}
+	err := s.client.GraphQL.Query(currentBulkOperation, map[string]interface{}{"type": operationType}, &resp)
+	return resp.CurrentBulkOperation, err
+}
+
+// Wait polls the current bulk operation of the given type until it is done. An
+// operation that failed, was canceled or expired is returned with a
+// BulkOperationError.
+func (s *BulkOperationServiceOp) Wait(operationType string) (*BulkOperation, error) {
+	for {
+		op, err := s.Current(operationType)
+		if err != nil {
+			return nil, err
+		}
+		if op == nil {
+			return nil, fmt.Errorf("no current bulk operation of type %s", operationType)
+		}
+
+		if op.Done() {
+			if op.Status != BulkOperationStatusCompleted {
+				return op, BulkOperationError{Operation: *op}
+			}
+			return op, nil
+		}
+
+		s.client.log.Debugf("bulk operation %s is %s, %s objects so far", op.ID, strings.ToLower(op.Status), op.ObjectCount)
+		if err := sleepContext(s.client.context(), bulkOperationPollInterval); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Cancel requests the cancellation of a running bulk operation.
+func (s *BulkOperationServiceOp) Cancel(id string) (*BulkOperation, error) {
+	resp := struct {
+		Payload bulkOperationPayload `json:"bulkOperationCancel"`
+	}{}
+	err := s.client.GraphQL.Query(bulkOperationCancel, map[string]interface{}{"id": id}, &resp)
+	return resp.Payload.BulkOperation, err
+}
+
+// RunBulkQuery starts a bulk query, waits for it to complete and streams its
+// results, see StreamBulkOperation:
+//
+//	err := RunBulkQuery(client, query, func(product Product) error {
+//		...
+//	})
+//
+// It fails when the current bulk query isn't the one it started, e.g. when
+// another one was started meanwhile, since a shop only has one of them.
+func RunBulkQuery[T any](c *Client, query string, fn func(T) error) error {
+	started, err := c.BulkOperation.RunQuery(query)
+	if err != nil {
+		return err
+	}
+	if started == nil {
+		return fmt.Errorf("no bulk operation started by the bulk query")
+	}
+
+	op, err := c.BulkOperation.Wait(BulkOperationTypeQuery)
+	if err != nil {
+		return err
+	}
+	if op.ID != started.ID {
+		return fmt.Errorf("bulk query %s was replaced by bulk query %s", started.ID, op.ID)
+	}
+	return StreamBulkOperation(c, op, fn)
+}
+
+// StreamBulkOperation downloads the JSONL result file of a completed bulk
+// operation with the HTTP client of c, without the credentials of the shop,
+// and calls fn with every top level object as soon as it is read. The download
+// isn't bounded by the timeout of the client but by the context of c only.
+//
+// The objects of nested connections, written on their own lines with a
+// __parentId, are put back into their parent as a list named after their
+// type, e.g. "variants" for ProductVariant objects and "metafields" for
+// Metafield objects, which requires them to select their id. The objects are
+// then given the shape of the REST resources before being decoded into T: keys
+// are converted to snake case and global ids to numeric ids, the global id
+// being kept as admin_graphql_api_id. Values are left as is, e.g. the enums
+// in upper case.
+//
+// Returning an error from fn stops the download and that error is returned,
+// except ErrStopStream which stops it silently.
+func StreamBulkOperation[T any](c *Client, op *BulkOperation, fn func(T) error) error {
+	if op.Status != BulkOperationStatusCompleted {
+		return BulkOperationError{Operation: *op}
+	}
+	if op.URL == "" {
+		// no objects
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(c.context(), http.MethodGet, op.URL, nil)
+	if err != nil {
+		return err
+	}
+
+	// the file isn't downloaded with Do since it's no request to the admin
+	// API, and without the timeout of the client, which would cut the
+	// download of large files, the context bounding it instead
+	httpClient := *c.Client
+	httpClient.Timeout = 0
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return ResponseError{
+			Status:  resp.StatusCode,
+			Message: fmt.Sprintf("downloading the result of bulk operation %s: %s", op.ID, resp.Status),
+		}
+	}
+
+	decoder := &bulkDecoder[T]{fn: fn}
+	err = decoder.decodeResponse(resp.Body)
+	if decoder.err != nil {
+		err = decoder.err
+	}
+	if errors.Is(err, ErrStopStream) {
+		return nil
+	}
+	return err
+}
+
+// bulkDecoder reassembles the objects of a JSONL result file.
+type bulkDecoder[T any] struct {
+	fn func(T) error
+
+	// error returned by fn, passed on to the caller unwrapped
+	err error
+
+	// the top level object being read and its descendants by id
+	root    map[string]interface{}
+	objects map[string]map[string]interface{}
+}
+
+func (d *bulkDecoder[T]) decodeResponse(r io.Reader) error {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+
+	for line := 1; ; line++ {
+		var object map[string]interface{}
+		err := dec.Decode(&object)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		parentID, ok := object["__parentId"].(string)
+		if !ok {
+			if err := d.emit(); err != nil {
+				return err
+			}
+			d.root = object
+			d.objects = map[string]map[string]interface{}{}
+			if id, ok := object["id"].(string); ok {
+				d.objects[id] = object
+			}
+			continue
+		}
+
+		parent, ok := d.objects[parentID]
+		if !ok {
+			return ResponseDecodingError{
+				Message: fmt.Sprintf("line %d: parent %s not found before its children", line, parentID),
+			}
+		}
+		delete(object, "__parentId")
+
+		id, _ := object["id"].(string)
+		key := bulkChildKey(id)
+		children, _ := parent[key].([]interface{})
+		parent[key] = append(children, object)
+		if id != "" {
+			d.objects[id] = object
+		}
+	}
+
+	return d.emit()
+}
+
+// emit passes the top level object read so far to fn.
+func (d *bulkDecoder[T]) emit() error {
+	if d.root == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(restShape(d.root))
+	if err != nil {
+		return err
+	}
+	d.root, d.objects = nil, nil
+
+	var item T
+	if err := json.Unmarshal(data, &item); err != nil {
+		return ResponseDecodingError{Body: data, Message: err.Error()}
+	}
+	if err := d.fn(item); err != nil {
+		d.err = err
+		return err
+	}
+	return nil
+}
+
+// bulkChildKeys are the lists of the children of types not simply named after
+// their type.
+var bulkChildKeys = map[string]string{
+	"ProductVariant": "variants",
+	"ProductImage":   "images",
+	"MediaImage":     "images",
+}
+
+// bulkChildKey returns the name of the list of children of the type of the
+// given global id, e.g. "line_items" for gid://shopify/LineItem/1.
+func bulkChildKey(id string) string {
+	typ, _, _ := strings.Cut(strings.TrimPrefix(id, "gid://shopify/"), "/")
+	if typ == "" || !strings.HasPrefix(id, "gid://shopify/") {
+		return "children"
+	}
+	if key, ok := bulkChildKeys[typ]; ok {
+		return key
+	}
+	return snakeCase(typ) + "s"
+}
+
+// globalID matches the global ids of Shopify and captures their numeric id.
+var globalID = regexp.MustCompile(`^gid://shopify/\w+/(\d+)`)
+
+// restShape converts a GraphQL value to the shape of the REST resources.
+func restShape(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		object := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			if id, ok := value.(string); ok && key == "id" {
+				if m := globalID.FindStringSubmatch(id); m != nil {
+					object["id"] = json.Number(m[1])
+					object["admin_graphql_api_id"] = id
+					continue
+				}
+			}
+			object[snakeCase(key)] = restShape(value)
+		}
+		return object
+	case []interface{}:
+		list := make([]interface{}, len(v))
+		for i, value := range v {
+			list[i] = restShape(value)
+		}
+		return list
+	}
+	return v
+}
+
+// snakeCase converts a camel case name to snake case, e.g. bodyHtml to
+// body_html.
+func snakeCase(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,296 @@
+package synergyshopify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+const bulkResultURL = "https://storage.googleapis.com/shopify-tiers-assets-prod-us-east1/bulk-operation-outputs/result.jsonl"
+
+// bulkOperationResponder answers the bulk operation queries and mutations,
+// the current operation going through the given statuses one poll at a time.
+func bulkOperationResponder(t *testing.T, statuses ...string) httpmock.Responder {
+	polls := 0
+	return func(req *http.Request) (*http.Response, error) {
+		body, _ := io.ReadAll(req.Body)
+		request := GraphQLRequest{}
+		if err := json.Unmarshal(body, &request); err != nil {
+			t.Errorf("invalid GraphQL request: %v", err)
+		}
+
+		op := map[string]interface{}{"id": "gid://shopify/BulkOperation/1", "type": BulkOperationTypeQuery, "status": BulkOperationStatusCreated}
+		data := map[string]interface{}{}
+		switch {
+		case strings.HasPrefix(request.Query, "mutation bulkOperationRunQuery"):
+			data["bulkOperationRunQuery"] = map[string]interface{}{"bulkOperation": op, "userErrors": []interface{}{}}
+		case strings.HasPrefix(request.Query, "query currentBulkOperation"):
+			op["status"] = statuses[polls]
+			if polls < len(statuses)-1 {
+				polls++
+			}
+			if op["status"] == BulkOperationStatusCompleted {
+				op["objectCount"] = "7"
+				op["url"] = bulkResultURL
+			}
+			data["currentBulkOperation"] = op
+		default:
+			t.Errorf("unexpected query %s", request.Query)
+		}
+
+		return httpmock.NewJsonResponse(200, map[string]interface{}{"data": data})
+	}
+}
+
+func TestBulkOperationRunQuery(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			request := GraphQLRequest{}
+			json.NewDecoder(req.Body).Decode(&request)
+			if query := request.Variables.(map[string]interface{})["query"]; query != "{ products { edges { node { id } } } }" {
+				t.Errorf("RunQuery sent query %v", query)
+			}
+
+			return httpmock.NewStringResponse(200, `{"data": {"bulkOperationRunQuery": {
+				"bulkOperation": {"id": "gid://shopify/BulkOperation/1", "status": "CREATED", "type": "QUERY"},
+				"userErrors": []
+			}}}`), nil
+		})
+
+	op, err := client.BulkOperation.RunQuery("{ products { edges { node { id } } } }")
+	if err != nil {
+		t.Fatalf("BulkOperation.RunQuery returned error: %v", err)
+	}
+
+	expected := &BulkOperation{ID: "gid://shopify/BulkOperation/1", Status: BulkOperationStatusCreated, Type: BulkOperationTypeQuery}
+	if !reflect.DeepEqual(op, expected) {
+		t.Errorf("BulkOperation.RunQuery returned %+v, expected %+v", op, expected)
+	}
+}
+
+func TestBulkOperationRunQueryUserErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"data": {"bulkOperationRunQuery": {
+			"bulkOperation": null,
+			"userErrors": [{"field": ["query"], "message": "A bulk query operation for this app and shop is already in progress", "code": "OPERATION_IN_PROGRESS"}]
+		}}}`))
+
+	op, err := client.BulkOperation.RunQuery("{ products { edges { node { id } } } }")
+	var userErrors UserErrorsError
+	if op != nil || !errors.As(err, &userErrors) || userErrors.UserErrors[0].Code != "OPERATION_IN_PROGRESS" {
+		t.Errorf("BulkOperation.RunQuery returned %+v, %v", op, err)
+	}
+}
+
+func TestBulkOperationWait(t *testing.T) {
+	setup()
+	defer teardown()
+	defer func(interval time.Duration) { bulkOperationPollInterval = interval }(bulkOperationPollInterval)
+	bulkOperationPollInterval = time.Millisecond
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		bulkOperationResponder(t, BulkOperationStatusRunning, BulkOperationStatusRunning, BulkOperationStatusCompleted))
+
+	op, err := client.BulkOperation.Wait(BulkOperationTypeQuery)
+	if err != nil {
+		t.Fatalf("BulkOperation.Wait returned error: %v", err)
+	}
+	if op.Status != BulkOperationStatusCompleted || op.URL != bulkResultURL {
+		t.Errorf("BulkOperation.Wait returned %+v", op)
+	}
+	if calls := httpmock.GetTotalCallCount(); calls != 3 {
+		t.Errorf("expected 3 polls, got %d", calls)
+	}
+}
+
+func TestBulkOperationWaitFailed(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"data": {"currentBulkOperation": {
+			"id": "gid://shopify/BulkOperation/1", "status": "FAILED", "type": "QUERY", "errorCode": "INTERNAL_SERVER_ERROR"
+		}}}`))
+
+	_, err := client.BulkOperation.Wait(BulkOperationTypeQuery)
+	var opErr BulkOperationError
+	if !errors.As(err, &opErr) || err.Error() != "bulk operation gid://shopify/BulkOperation/1 is failed: INTERNAL_SERVER_ERROR" {
+		t.Errorf("BulkOperation.Wait returned %v, expected a BulkOperationError", err)
+	}
+}
+
+func TestRunBulkQuery(t *testing.T) {
+	setup()
+	defer teardown()
+	defer func(interval time.Duration) { bulkOperationPollInterval = interval }(bulkOperationPollInterval)
+	bulkOperationPollInterval = time.Millisecond
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		bulkOperationResponder(t, BulkOperationStatusRunning, BulkOperationStatusCompleted))
+	httpmock.RegisterResponder("GET", bulkResultURL,
+		func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get("X-Shopify-Access-Token") != "" {
+				t.Error("the access token was sent along to the result file")
+			}
+			return httpmock.NewBytesResponse(200, loadFixture("bulk_operation_products.jsonl")), nil
+		})
+
+	var products []Product
+	err := RunBulkQuery(client, "{ products { edges { node { id } } } }", func(p Product) error {
+		products = append(products, p)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("RunBulkQuery returned error: %v", err)
+	}
+	if len(products) != 2 {
+		t.Fatalf("expected 2 products, got %+v", products)
+	}
+
+	createdAt := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+	shirt := products[0]
+	if shirt.ID != 1 || shirt.AdminGraphqlAPIID != "gid://shopify/Product/1" || shirt.BodyHTML != "<p>Cotton</p>" ||
+		shirt.ProductType != "Tops" || !shirt.CreatedAt.Equal(createdAt) {
+		t.Errorf("unexpected product %+v", shirt)
+	}
+	if len(shirt.Variants) != 2 || shirt.Variants[0].ID != 11 || shirt.Variants[1].Sku != "SHIRT-L" || shirt.Variants[1].InventoryQuantity != 3 {
+		t.Errorf("unexpected variants %+v", shirt.Variants)
+	}
+	if metafields := shirt.Variants[1].Metafields; len(metafields) != 1 || metafields[0].Value != "L" {
+		t.Errorf("expected the metafield of the variant, got %+v", metafields)
+	}
+	if len(shirt.Images) != 1 || shirt.Images[0].ID != 21 {
+		t.Errorf("unexpected images %+v", shirt.Images)
+	}
+	if hat := products[1]; hat.Title != "Hat" || len(hat.Variants) != 1 || hat.Variants[0].Sku != "HAT" {
+		t.Errorf("unexpected product %+v", hat)
+	}
+}
+
+func TestRunBulkQueryReplaced(t *testing.T) {
+	setup()
+	defer teardown()
+
+	responder := bulkOperationResponder(t, BulkOperationStatusCompleted)
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+			req.Body = io.NopCloser(bytes.NewReader(body))
+			if !bytes.Contains(body, []byte("currentBulkOperation")) {
+				return responder(req)
+			}
+			// another bulk query was started meanwhile
+			return httpmock.NewJsonResponse(200, map[string]interface{}{"data": map[string]interface{}{
+				"currentBulkOperation": map[string]interface{}{
+					"id": "gid://shopify/BulkOperation/2", "type": BulkOperationTypeQuery, "status": BulkOperationStatusCompleted, "url": bulkResultURL,
+				},
+			}})
+		})
+	httpmock.RegisterResponder("GET", bulkResultURL, httpmock.NewBytesResponder(200, loadFixture("bulk_operation_products.jsonl")))
+
+	called := false
+	err := RunBulkQuery(client, "{ products { edges { node { id } } } }", func(p Product) error {
+		called = true
+		return nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "gid://shopify/BulkOperation/2") || called {
+		t.Errorf("RunBulkQuery returned %v, expected an error about the other bulk query", err)
+	}
+	if count := httpmock.GetCallCountInfo()["GET "+bulkResultURL]; count != 0 {
+		t.Errorf("expected the results of the other bulk query not to be downloaded, got %d requests", count)
+	}
+}
+
+func TestStreamBulkOperation(t *testing.T) {
+	setup()
+	defer teardown()
+	op := &BulkOperation{ID: "gid://shopify/BulkOperation/1", Status: BulkOperationStatusCompleted, URL: bulkResultURL}
+
+	httpmock.RegisterResponder("GET", bulkResultURL, httpmock.NewBytesResponder(200, loadFixture("bulk_operation_products.jsonl")))
+	count := 0
+	err := StreamBulkOperation(client, op, func(p map[string]interface{}) error {
+		count++
+		return ErrStopStream
+	})
+	if err != nil || count != 1 {
+		t.Errorf("StreamBulkOperation returned %v after %d objects, expected to stop after the first one", err, count)
+	}
+
+	httpmock.RegisterResponder("GET", bulkResultURL, httpmock.NewStringResponder(200,
+		`{"id":"gid://shopify/Product/1"}`+"\n"+`{"id":"gid://shopify/ProductVariant/11","__parentId":"gid://shopify/Product/2"}`+"\n"))
+	err = StreamBulkOperation(client, op, func(p Product) error { return nil })
+	if err == nil || !strings.Contains(err.Error(), "line 2: parent gid://shopify/Product/2 not found") {
+		t.Errorf("StreamBulkOperation returned %v, expected a missing parent error", err)
+	}
+
+	running := &BulkOperation{ID: "gid://shopify/BulkOperation/1", Status: BulkOperationStatusRunning}
+	if err := StreamBulkOperation(client, running, func(p Product) error { return nil }); !errors.As(err, &BulkOperationError{}) {
+		t.Errorf("StreamBulkOperation returned %v for a running operation", err)
+	}
+}
+
+func TestStreamBulkOperationSlowDownload(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("X-Shopify-Access-Token") != "" {
+			t.Error("the access token was sent along to the result file")
+		}
+		for i := 1; i <= 10; i++ {
+			fmt.Fprintf(w, `{"id":"gid://shopify/Product/%d"}`+"\n", i)
+			w.(http.Flusher).Flush()
+			time.Sleep(20 * time.Millisecond)
+		}
+	}))
+	defer server.Close()
+
+	// the download takes longer than the timeout of the client
+	c := NewClient(app, "fooshop", "abcd", WithHTTPClient(&http.Client{Timeout: 50 * time.Millisecond}))
+	op := &BulkOperation{ID: "gid://shopify/BulkOperation/1", Status: BulkOperationStatusCompleted, URL: server.URL}
+
+	count := 0
+	err := StreamBulkOperation(c, op, func(p Product) error {
+		count++
+		return nil
+	})
+	if err != nil || count != 10 {
+		t.Errorf("StreamBulkOperation returned %v after %d objects, expected 10", err, count)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err = StreamBulkOperation(c.WithContext(ctx), op, func(p Product) error { return nil })
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("StreamBulkOperation returned %v, expected the context to stop the download", err)
+	}
+}
+
+func TestBulkChildKey(t *testing.T) {
+	cases := map[string]string{
+		"gid://shopify/ProductVariant/1":     "variants",
+		"gid://shopify/LineItem/1":           "line_items",
+		"gid://shopify/Metafield/1":          "metafields",
+		"gid://shopify/ProductImage/1?v=123": "images",
+		"":                                   "children",
+	}
+	for id, expected := range cases {
+		if actual := bulkChildKey(id); actual != expected {
+			t.Errorf("bulkChildKey(%q) returned %q, expected %q", id, actual, expected)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package synergyshopify
+
+import (
+	"container/list"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// number of responses kept by the cache created when none is given to WithCache
+const defaultCacheSize = 1000
+
+// Cache stores the responses of the response cache, see WithCache.
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	// Get returns the entry stored under key, if any.
+	Get(key string) (*CacheEntry, bool)
+
+	// Set stores entry under key.
+	Set(key string, entry *CacheEntry)
+
+	// DeletePrefix removes every entry whose key starts with prefix.
+	DeletePrefix(prefix string)
+}
+
+// CacheEntry is a response stored in a Cache.
+type CacheEntry struct {
+	Body         []byte
+	Header       http.Header
+	ETag         string
+	LastModified string
+	Expires      time.Time
+}
+
+// fresh reports whether the entry can be served without asking Shopify.
+func (e *CacheEntry) fresh() bool {
+	return time.Now().Before(e.Expires)
+}
+
+// DefaultCacheTTLs returns the time to live of the resources that rarely
+// change and are cached when no TTLs are given to WithCache.
+func DefaultCacheTTLs() map[string]time.Duration {
+	return map[string]time.Duration{
+		"shop":           10 * time.Minute,
+		"locations":      10 * time.Minute,
+		"shipping_zones": 10 * time.Minute,
+		"themes":         10 * time.Minute,
+	}
+}
+
+// responseCache caches the GET responses of the resources it has a TTL for.
+// Stale responses are revalidated with conditional requests using their ETag
+// or Last-Modified headers, and writes to a resource invalidate its responses.
+type responseCache struct {
+	storage Cache
+	ttls    map[string]time.Duration
+}
+
+// cacheKey returns the key of the response to req, which ignores the order of
+// the query parameters.
+func cacheKey(req *http.Request) string {
+	return req.URL.Host + req.URL.Path + "?" + req.URL.Query().Encode()
+}
+
+// resourcePath splits the path of a request into the api prefix and the
+// resource it targets, e.g. "/admin/api/2024-01/products/1.json" into
+// "/admin/api/2024-01/" and "products".
+func resourcePath(urlPath string) (string, string) {
+	prefix := "/"
+	rest := strings.TrimPrefix(urlPath, "/")
+
+	if strings.HasPrefix(rest, "admin/") {
+		prefix += "admin/"
+		rest = strings.TrimPrefix(rest, "admin/")
+
+		if strings.HasPrefix(rest, "api/") {
+			parts := strings.SplitN(rest, "/", 3)
+			if len(parts) == 3 {
+				prefix += parts[0] + "/" + parts[1] + "/"
+				rest = parts[2]
+			}
+		}
+	}
+
+	resource := strings.SplitN(rest, "/", 2)[0]
+	return prefix, strings.TrimSuffix(resource, ".json")
+}
+
+// ttl returns the time to live of the responses to req, and whether they are
+// cached at all. Requests sent with "Cache-Control: no-cache" bypass the cache.
+func (rc *responseCache) ttl(req *http.Request) (time.Duration, bool) {
+	if req.Method != http.MethodGet || req.Header.Get("Cache-Control") == "no-cache" {
+		return 0, false
+	}
+
+	_, resource := resourcePath(req.URL.Path)
+	ttl, ok := rc.ttls[resource]
+	return ttl, ok
+}
+
+// lookup returns the cached response to req, if any, and prepares req to be
+// revalidated when it is stale.
+func (rc *responseCache) lookup(req *http.Request) *CacheEntry {
+	if _, ok := rc.ttl(req); !ok {
+		return nil
+	}
+
+	entry, ok := rc.storage.Get(cacheKey(req))
+	if !ok {
+		return nil
+	}
+
+	if !entry.fresh() {
+		if entry.ETag != "" {
+			req.Header.Set("If-None-Match", entry.ETag)
+		}
+		if entry.LastModified != "" {
+			req.Header.Set("If-Modified-Since", entry.LastModified)
+		}
+	}
+
+	return entry
+}
+
+// store caches the successful response to req.
+func (rc *responseCache) store(req *http.Request, header http.Header, body []byte) {
+	ttl, ok := rc.ttl(req)
+	if !ok {
+		return
+	}
+
+	rc.storage.Set(cacheKey(req), &CacheEntry{
+		Body:         body,
+		Header:       header,
+		ETag:         header.Get("ETag"),
+		LastModified: header.Get("Last-Modified"),
+		Expires:      time.Now().Add(ttl),
+	})
+}
+
+// refresh extends the life of an entry revalidated by a 304 response.
+func (rc *responseCache) refresh(req *http.Request, entry *CacheEntry) {
+	ttl, _ := rc.ttl(req)
+
+	refreshed := *entry
+	refreshed.Expires = time.Now().Add(ttl)
+	rc.storage.Set(cacheKey(req), &refreshed)
+}
+
+// invalidate removes the cached responses of the resource written by req.
+func (rc *responseCache) invalidate(req *http.Request) {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return
+	}
+
+	prefix, resource := resourcePath(req.URL.Path)
+	root := req.URL.Host + prefix + resource
+	rc.storage.DeletePrefix(root + ".json")
+	rc.storage.DeletePrefix(root + "/")
+}
+
+// LRUCache is an in-memory Cache keeping the most recently used responses.
+type LRUCache struct {
+	size int
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type lruItem struct {
+	key   string
+	entry *CacheEntry
+}
+
+// NewLRUCache returns an in-memory cache holding up to size responses.
+func NewLRUCache(size int) *LRUCache {
+	if size <= 0 {
+		size = defaultCacheSize
+	}
+
+	return &LRUCache{
+		size:    size,
+		order:   list.New(),
+		entries: map[string]*list.Element{},
+	}
+}
+
+// Get returns the entry stored under key, if any.
+func (c *LRUCache) Get(key string) (*CacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	c.order.MoveToFront(elem)
+	return elem.Value.(*lruItem).entry, true
+}
+
+// Set stores entry under key, evicting the least recently used entry when the
+// cache is full.
+func (c *LRUCache) Set(key string, entry *CacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		elem.Value.(*lruItem).entry = entry
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&lruItem{key: key, entry: entry})
+
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruItem).key)
+	}
+}
+
+// DeletePrefix removes every entry whose key starts with prefix.
+func (c *LRUCache) DeletePrefix(prefix string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, elem := range c.entries {
+		if strings.HasPrefix(key, prefix) {
+			c.order.Remove(elem)
+			delete(c.entries, key)
+		}
+	}
+}
+
+// Len returns the number of entries in the cache.
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
@@ -0,0 +1,184 @@
+package synergyshopify
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestResourcePath(t *testing.T) {
+	cases := []struct {
+		path     string
+		prefix   string
+		resource string
+	}{
+		{"/admin/api/2024-01/shop.json", "/admin/api/2024-01/", "shop"},
+		{"/admin/api/2024-01/products/1/images.json", "/admin/api/2024-01/", "products"},
+		{"/admin/locations.json", "/admin/", "locations"},
+		{"/admin/oauth/access_scopes.json", "/admin/", "oauth"},
+		{"/apps/proxy.json", "/", "apps"},
+	}
+
+	for _, c := range cases {
+		prefix, resource := resourcePath(c.path)
+		if prefix != c.prefix || resource != c.resource {
+			t.Errorf("resourcePath(%q) returned %q, %q, expected %q, %q", c.path, prefix, resource, c.prefix, c.resource)
+		}
+	}
+}
+
+func TestCacheFreshResponse(t *testing.T) {
+	setup()
+	defer teardown()
+	WithCache(nil, nil)(client)
+
+	url := fmt.Sprintf("https://fooshop.myshopify.com/%s/shop.json", client.pathPrefix)
+	httpmock.RegisterResponder("GET", url, httpmock.NewStringResponder(200, `{"shop":{"id":1}}`))
+
+	for i := 0; i < 3; i++ {
+		shop, err := client.Shop.Get(nil)
+		if err != nil {
+			t.Fatalf("Shop.Get returned error: %v", err)
+		}
+		if shop.ID != 1 {
+			t.Errorf("Shop.Get returned id %d, expected 1", shop.ID)
+		}
+	}
+
+	if count := httpmock.GetCallCountInfo()["GET "+url]; count != 1 {
+		t.Errorf("expected 1 request, got %d", count)
+	}
+}
+
+func TestCacheRevalidate(t *testing.T) {
+	setup()
+	defer teardown()
+	WithCache(nil, map[string]time.Duration{"shop": 0})(client)
+
+	url := fmt.Sprintf("https://fooshop.myshopify.com/%s/shop.json", client.pathPrefix)
+	calls := 0
+	httpmock.RegisterResponder("GET", url, func(req *http.Request) (*http.Response, error) {
+		calls++
+		if calls == 1 {
+			resp := httpmock.NewStringResponse(200, `{"shop":{"id":1}}`)
+			resp.Header.Set("ETag", `"v1"`)
+			resp.Header.Set("Last-Modified", "Mon, 01 Jan 2024 00:00:00 GMT")
+			return resp, nil
+		}
+
+		if got := req.Header.Get("If-None-Match"); got != `"v1"` {
+			t.Errorf("expected If-None-Match %q, got %q", `"v1"`, got)
+		}
+		if got := req.Header.Get("If-Modified-Since"); got != "Mon, 01 Jan 2024 00:00:00 GMT" {
+			t.Errorf("expected If-Modified-Since to be sent, got %q", got)
+		}
+		return httpmock.NewStringResponse(304, ""), nil
+	})
+
+	req, err := client.NewRequest("GET", fmt.Sprintf("%s/shop.json", client.pathPrefix), nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	shop := new(ShopResource)
+	resp, err := client.Do(req, shop)
+	if err != nil {
+		t.Fatalf("Client.Do returned error: %v", err)
+	}
+	if resp.Cached {
+		t.Error("expected the first response not to be cached")
+	}
+
+	req, _ = client.NewRequest("GET", fmt.Sprintf("%s/shop.json", client.pathPrefix), nil, nil)
+	shop = new(ShopResource)
+	resp, err = client.Do(req, shop)
+	if err != nil {
+		t.Fatalf("Client.Do returned error: %v", err)
+	}
+	if !resp.Cached || resp.StatusCode != http.StatusNotModified {
+		t.Errorf("expected a cached 304 response, got %+v", resp)
+	}
+	if shop.Shop == nil || shop.Shop.ID != 1 {
+		t.Errorf("expected the cached shop, got %+v", shop.Shop)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 requests, got %d", calls)
+	}
+}
+
+func TestCacheInvalidate(t *testing.T) {
+	setup()
+	defer teardown()
+	WithCache(nil, map[string]time.Duration{"locations": time.Hour})(client)
+
+	listURL := fmt.Sprintf("https://fooshop.myshopify.com/%s/locations.json", client.pathPrefix)
+	itemURL := fmt.Sprintf("https://fooshop.myshopify.com/%s/locations/1.json", client.pathPrefix)
+	httpmock.RegisterResponder("GET", listURL, httpmock.NewStringResponder(200, `{"locations":[{"id":1}]}`))
+	httpmock.RegisterResponder("PUT", itemURL, httpmock.NewStringResponder(200, `{"location":{"id":1}}`))
+
+	var resource interface{}
+	for i := 0; i < 2; i++ {
+		if err := client.Get("locations.json", &resource, nil, true); err != nil {
+			t.Fatalf("Client.Get returned error: %v", err)
+		}
+	}
+	if err := client.Put("locations/1.json", map[string]interface{}{"location": nil}, &resource); err != nil {
+		t.Fatalf("Client.Put returned error: %v", err)
+	}
+	if err := client.Get("locations.json", &resource, nil, true); err != nil {
+		t.Fatalf("Client.Get returned error: %v", err)
+	}
+
+	if count := httpmock.GetCallCountInfo()["GET "+listURL]; count != 2 {
+		t.Errorf("expected 2 list requests, got %d", count)
+	}
+}
+
+func TestCacheUncachedResource(t *testing.T) {
+	setup()
+	defer teardown()
+	WithCache(nil, nil)(client)
+
+	url := fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1.json", client.pathPrefix)
+	httpmock.RegisterResponder("GET", url, httpmock.NewStringResponder(200, `{"product":{"id":1}}`))
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.Product.Get(1, nil); err != nil {
+			t.Fatalf("Product.Get returned error: %v", err)
+		}
+	}
+
+	if count := httpmock.GetCallCountInfo()["GET "+url]; count != 2 {
+		t.Errorf("expected 2 requests, got %d", count)
+	}
+}
+
+func TestLRUCache(t *testing.T) {
+	cache := NewLRUCache(2)
+	cache.Set("a", &CacheEntry{ETag: "a"})
+	cache.Set("b", &CacheEntry{ETag: "b"})
+
+	// a becomes the most recently used entry so b is evicted
+	if _, ok := cache.Get("a"); !ok {
+		t.Fatal("expected a to be cached")
+	}
+	cache.Set("c", &CacheEntry{ETag: "c"})
+
+	if _, ok := cache.Get("b"); ok {
+		t.Error("expected b to be evicted")
+	}
+	if cache.Len() != 2 {
+		t.Errorf("expected 2 entries, got %d", cache.Len())
+	}
+
+	cache.DeletePrefix("a")
+	if _, ok := cache.Get("a"); ok {
+		t.Error("expected a to be deleted")
+	}
+	if _, ok := cache.Get("c"); !ok {
+		t.Error("expected c to be kept")
+	}
+}
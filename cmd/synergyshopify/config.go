@@ -0,0 +1,123 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Environment variables holding the credentials, taking precedence over the
+// config file.
+const (
+	envShop       = "SHOPIFY_SHOP"
+	envToken      = "SHOPIFY_ACCESS_TOKEN"
+	envAPIVersion = "SHOPIFY_API_VERSION"
+	envProfile    = "SHOPIFY_PROFILE"
+	envConfig     = "SYNERGYSHOPIFY_CONFIG"
+)
+
+// config is the config file, by default synergyshopify/config.json in the
+// user config directory, e.g.
+//
+//	{
+//		"default": "acme",
+//		"profiles": {
+//			"acme": {"shop": "acme", "token": "shpat_...", "api_version": "2024-01"}
+//		}
+//	}
+type config struct {
+	Default  string             `json:"default"`
+	Profiles map[string]profile `json:"profiles"`
+}
+
+// profile holds the credentials of a shop.
+type profile struct {
+	Shop       string `json:"shop"`
+	Token      string `json:"token"`
+	APIVersion string `json:"api_version"`
+}
+
+// configPath returns the path of the config file.
+func configPath(getenv func(string) string) (string, error) {
+	if path := getenv(envConfig); path != "" {
+		return path, nil
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "synergyshopify", "config.json"), nil
+}
+
+// loadConfig reads the config file, a missing file being an empty config.
+func loadConfig(path string) (config, error) {
+	var cfg config
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, err
+	}
+
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// credentials resolves the credentials of the shop from the flags, then the
+// environment, then the profile of the config file. A profile selected with
+// the -profile flag takes precedence over the environment.
+func credentials(flags profile, profileName string, getenv func(string) string) (profile, error) {
+	creds := flags
+	fill := func(p profile) {
+		if creds.Shop == "" {
+			creds.Shop = p.Shop
+		}
+		if creds.Token == "" {
+			creds.Token = p.Token
+		}
+		if creds.APIVersion == "" {
+			creds.APIVersion = p.APIVersion
+		}
+	}
+
+	env := profile{Shop: getenv(envShop), Token: getenv(envToken), APIVersion: getenv(envAPIVersion)}
+	if profileName == "" {
+		fill(env)
+		profileName = getenv(envProfile)
+	}
+
+	if creds.Shop == "" || creds.Token == "" {
+		path, err := configPath(getenv)
+		if err != nil {
+			return creds, err
+		}
+		cfg, err := loadConfig(path)
+		if err != nil {
+			return creds, err
+		}
+
+		if profileName == "" {
+			profileName = cfg.Default
+		}
+		if profileName != "" {
+			p, ok := cfg.Profiles[profileName]
+			if !ok {
+				return creds, fmt.Errorf("no profile %q in %s", profileName, path)
+			}
+			fill(p)
+		}
+	}
+	fill(env)
+
+	if creds.Shop == "" || creds.Token == "" {
+		return creds, fmt.Errorf("missing credentials, set -shop and -token, %s and %s, or a profile", envShop, envToken)
+	}
+	return creds, nil
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// filterFields returns the settable fields of an options struct by the name
+// of their url parameter, including the ones of embedded structs.
+func filterFields(v reflect.Value) map[string]reflect.Value {
+	fields := map[string]reflect.Value{}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			for name, f := range filterFields(v.Field(i)) {
+				if _, ok := fields[name]; !ok {
+					fields[name] = f
+				}
+			}
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("url"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		// fields of the outer struct shadow the embedded ones
+		fields[name] = v.Field(i)
+	}
+
+	return fields
+}
+
+// listOptions returns a copy of the options struct of a resource with the
+// given parameters set, e.g. {"vendor": "Acme", "created_at_min": "2024-01-01"}.
+func listOptions(options interface{}, params map[string]string) (interface{}, error) {
+	v := reflect.New(reflect.TypeOf(options)).Elem()
+	fields := filterFields(v)
+
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		field, ok := fields[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown filter %q, expected one of %s", name, strings.Join(filterNames(options), ", "))
+		}
+		if err := setField(field, params[name]); err != nil {
+			return nil, fmt.Errorf("filter %s: %w", name, err)
+		}
+	}
+
+	return v.Interface(), nil
+}
+
+// filterNames returns the parameters of an options struct.
+func filterNames(options interface{}) []string {
+	var names []string
+	for name := range filterFields(reflect.New(reflect.TypeOf(options)).Elem()) {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// setField parses a filter value into a field of an options struct.
+func setField(field reflect.Value, value string) error {
+	if field.Kind() == reflect.Pointer {
+		ptr := reflect.New(field.Type().Elem())
+		if err := setField(ptr.Elem(), value); err != nil {
+			return err
+		}
+		field.Set(ptr)
+		return nil
+	}
+
+	if field.Type() == timeType {
+		t, err := parseTime(value)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Slice:
+		values := strings.Split(value, ",")
+		slice := reflect.MakeSlice(field.Type(), len(values), len(values))
+		for i, v := range values {
+			if err := setField(slice.Index(i), strings.TrimSpace(v)); err != nil {
+				return err
+			}
+		}
+		field.Set(slice)
+	case reflect.Struct:
+		// e.g. OnlyDate, which embeds a time.Time
+		if field.NumField() > 0 && field.Type().Field(0).Anonymous && field.Field(0).Type() == timeType {
+			return setField(field.Field(0), value)
+		}
+		return fmt.Errorf("unsupported type %s", field.Type())
+	default:
+		return fmt.Errorf("unsupported type %s", field.Type())
+	}
+
+	return nil
+}
+
+// parseTime accepts RFC 3339 times and dates.
+func parseTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q, expected a date or an RFC 3339 time", value)
+	}
+	return t, nil
+}
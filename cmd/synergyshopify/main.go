@@ -0,0 +1,441 @@
+// Command synergyshopify administers a Shopify store from the command line,
+// e.g.
+//
+//	synergyshopify products list -filter vendor=Acme -all -output table
+//	synergyshopify orders get 450789469
+//	synergyshopify products update 632910392 -data '{"title": "New title"}'
+//	synergyshopify metafields list -parent products/632910392
+//
+// Run synergyshopify help for the details.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	shopify "github.com/binodsynergytechs/synergyshopify"
+)
+
+const usage = `Usage: synergyshopify <resource> <action> [flags] [id]
+
+Actions:
+  list     list resources, one page unless -all is set
+  count    count resources
+  get      get the resource with the given id
+  create   create a resource from the JSON of -data or -file
+  update   update the resource with the given id from the JSON of -data or -file
+  delete   delete the resource with the given id
+
+Run "synergyshopify resources" for the resources and their actions, and
+"synergyshopify <resource> <action> -h" for the flags.
+
+Credentials are read from the -shop and -token flags, the SHOPIFY_SHOP and
+SHOPIFY_ACCESS_TOKEN environment variables, or a profile of the config file
+(SYNERGYSHOPIFY_CONFIG, by default synergyshopify/config.json in the user
+config directory):
+
+  {
+    "default": "acme",
+    "profiles": {
+      "acme": {"shop": "acme", "token": "shpat_...", "api_version": "2024-01"}
+    }
+  }
+`
+
+var actions = []string{"list", "count", "get", "create", "update", "delete"}
+
+func main() {
+	c := &cli{stdin: os.Stdin, stdout: os.Stdout, stderr: os.Stderr, getenv: os.Getenv}
+	if err := c.run(os.Args[1:]); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, "synergyshopify:", err)
+		}
+		os.Exit(1)
+	}
+}
+
+// cli runs the commands with the given streams and environment.
+type cli struct {
+	stdin          io.Reader
+	stdout, stderr io.Writer
+	getenv         func(string) string
+
+	// options are applied to the client after the ones of the flags
+	options []shopify.Option
+}
+
+// command holds the flags of a command.
+type command struct {
+	name     string
+	resource resource
+	action   string
+	id       string
+
+	creds       profile
+	profileName string
+	output      string
+	parent      string
+	data        string
+	file        string
+	fields      string
+	columns     string
+	pageInfo    string
+	limit       int
+	all         bool
+	filters     filterFlag
+}
+
+// filterFlag collects the repeated -filter key=value flags.
+type filterFlag map[string]string
+
+func (f filterFlag) String() string {
+	return ""
+}
+
+func (f filterFlag) Set(value string) error {
+	k, v, ok := strings.Cut(value, "=")
+	if !ok || k == "" {
+		return fmt.Errorf("expected key=value, got %q", value)
+	}
+	f[k] = v
+	return nil
+}
+
+func (c *cli) run(args []string) error {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "-help" || args[0] == "--help" {
+		fmt.Fprint(c.stdout, usage)
+		return nil
+	}
+
+	if args[0] == "resources" {
+		for _, name := range resourceNames() {
+			r := resources[name]
+			var supported []string
+			for _, action := range actions {
+				if r.supports(action) {
+					supported = append(supported, action)
+				}
+			}
+			line := fmt.Sprintf("%-20s %s", name, strings.Join(supported, ", "))
+			if r.parent != "" {
+				line += fmt.Sprintf(" (-parent: %s)", r.parent)
+			}
+			fmt.Fprintln(c.stdout, line)
+		}
+		return nil
+	}
+
+	if len(args) < 2 {
+		return fmt.Errorf("missing action, expected one of %s", strings.Join(actions, ", "))
+	}
+
+	cmd, err := c.parse(args[0], args[1], args[2:])
+	if err != nil {
+		return err
+	}
+
+	creds, err := credentials(cmd.creds, cmd.profileName, c.getenv)
+	if err != nil {
+		return err
+	}
+	client := c.client(creds)
+
+	switch cmd.action {
+	case "list":
+		return c.list(client, cmd)
+	case "count":
+		return c.count(client, cmd)
+	case "get":
+		return c.get(client, cmd)
+	case "create", "update":
+		return c.save(client, cmd)
+	case "delete":
+		return c.delete(client, cmd)
+	}
+	return nil
+}
+
+// parse parses the flags of a command, which may come before or after the id.
+func (c *cli) parse(name, action string, args []string) (*command, error) {
+	r, ok := resources[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown resource %q, run synergyshopify resources for the list", name)
+	}
+	if !r.supports(action) {
+		return nil, fmt.Errorf("%s don't support %s", name, action)
+	}
+
+	cmd := &command{name: name, resource: r, action: action, filters: filterFlag{}}
+	fs := flag.NewFlagSet(name+" "+action, flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+
+	fs.StringVar(&cmd.creds.Shop, "shop", "", "shop name, e.g. acme for acme.myshopify.com")
+	fs.StringVar(&cmd.creds.Token, "token", "", "access token")
+	fs.StringVar(&cmd.creds.APIVersion, "api-version", "", "api version, e.g. 2024-01")
+	fs.StringVar(&cmd.profileName, "profile", "", "profile of the config file")
+	fs.StringVar(&cmd.output, "output", outputJSON, "output format: json, table or csv")
+	if r.parent != "" {
+		fs.StringVar(&cmd.parent, "parent", "", r.parent)
+	}
+
+	switch action {
+	case "list", "count":
+		fs.Var(cmd.filters, "filter", "filter as key=value, repeatable, e.g. -filter created_at_min=2024-01-01")
+	case "create", "update":
+		fs.StringVar(&cmd.data, "data", "", "JSON of the resource")
+		fs.StringVar(&cmd.file, "file", "", "file holding the JSON of the resource, - for stdin")
+	}
+	if action == "list" || action == "get" {
+		fs.StringVar(&cmd.fields, "fields", "", "comma separated fields to retrieve")
+		fs.StringVar(&cmd.columns, "columns", "", "comma separated columns of table and csv outputs")
+	}
+	if action == "list" {
+		fs.IntVar(&cmd.limit, "limit", 0, "number of resources per page")
+		fs.BoolVar(&cmd.all, "all", false, "list the resources of every page")
+		fs.StringVar(&cmd.pageInfo, "page-info", "", "page to list, as printed after a page")
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() > 0 {
+		cmd.id = fs.Arg(0)
+		if err := fs.Parse(fs.Args()[1:]); err != nil {
+			return nil, err
+		}
+		if fs.NArg() > 0 {
+			return nil, fmt.Errorf("unexpected arguments %s", strings.Join(fs.Args(), " "))
+		}
+	}
+
+	switch action {
+	case "get", "update", "delete":
+		if cmd.id == "" {
+			return nil, fmt.Errorf("missing id of the %s to %s", r.singular, action)
+		}
+	default:
+		if cmd.id != "" {
+			return nil, fmt.Errorf("unexpected id %s", cmd.id)
+		}
+	}
+
+	return cmd, nil
+}
+
+func (c *cli) client(creds profile) *shopify.Client {
+	opts := []shopify.Option{shopify.WithRetryPolicy(shopify.DefaultRetryPolicy(3))}
+	if creds.APIVersion != "" {
+		opts = append(opts, shopify.WithVersion(creds.APIVersion))
+	}
+	opts = append(opts, c.options...)
+
+	return shopify.NewClient(shopify.App{}, creds.Shop, creds.Token, opts...)
+}
+
+func (c *cli) list(client *shopify.Client, cmd *command) error {
+	listPath, err := cmd.resource.path(cmd.resource.list, cmd.parent, "")
+	if err != nil {
+		return err
+	}
+
+	var options interface{}
+	if cmd.pageInfo != "" {
+		// Shopify rejects the filters along with a page_info
+		options = shopify.ListOptions{PageInfo: cmd.pageInfo, Limit: cmd.limit, Fields: cmd.fields}
+	} else {
+		params := map[string]string{}
+		for k, v := range cmd.filters {
+			params[k] = v
+		}
+		if cmd.limit > 0 {
+			params["limit"] = fmt.Sprint(cmd.limit)
+		}
+		if cmd.fields != "" {
+			params["fields"] = cmd.fields
+		}
+		if options, err = listOptions(cmd.resource.options, params); err != nil {
+			return err
+		}
+	}
+
+	var items []json.RawMessage
+	if cmd.all {
+		if items, err = shopify.IteratePath[json.RawMessage](client, listPath, options).All(); err != nil {
+			return err
+		}
+	} else {
+		resource := map[string]json.RawMessage{}
+		pagination, err := client.ListWithPagination(listPath, &resource, options)
+		if err != nil {
+			return err
+		}
+		if raw, ok := resource[path.Base(strings.TrimSuffix(listPath, ".json"))]; ok {
+			if err := json.Unmarshal(raw, &items); err != nil {
+				return err
+			}
+		}
+		if pagination != nil && pagination.NextPageOptions != nil {
+			fmt.Fprintf(c.stderr, "next page: -page-info %s\n", pagination.NextPageOptions.PageInfo)
+		}
+	}
+
+	return writeItems(c.stdout, cmd.output, cmd.outputColumns(), items)
+}
+
+func (c *cli) count(client *shopify.Client, cmd *command) error {
+	listPath, err := cmd.resource.path(cmd.resource.list, cmd.parent, "")
+	if err != nil {
+		return err
+	}
+	options, err := listOptions(cmd.resource.options, cmd.filters)
+	if err != nil {
+		return err
+	}
+
+	count, err := client.Count(strings.TrimSuffix(listPath, ".json")+"/count.json", options)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(c.stdout, count)
+	return nil
+}
+
+// assetKey is the parameter addressing the resources of keyed endpoints.
+type assetKey struct {
+	Key string `url:"asset[key]"`
+}
+
+func (c *cli) get(client *shopify.Client, cmd *command) error {
+	p, options, err := cmd.itemPath()
+	if err != nil {
+		return err
+	}
+	if cmd.fields != "" && !cmd.resource.keyed {
+		options = shopify.ListOptions{Fields: cmd.fields}
+	}
+
+	resource := map[string]json.RawMessage{}
+	if err := client.Get(p, &resource, options, true); err != nil {
+		return err
+	}
+	return c.writeItem(cmd, resource[cmd.resource.singular])
+}
+
+func (c *cli) save(client *shopify.Client, cmd *command) error {
+	data, err := cmd.body(c.stdin)
+	if err != nil {
+		return err
+	}
+
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	// the resource may be given with its envelope
+	if inner, ok := fields[cmd.resource.singular]; ok && len(fields) == 1 {
+		fields = map[string]json.RawMessage{}
+		if err := json.Unmarshal(inner, &fields); err != nil {
+			return fmt.Errorf("invalid JSON: %w", err)
+		}
+	}
+
+	key := "id"
+	if cmd.resource.keyed {
+		key = "key"
+	}
+	if cmd.id != "" {
+		id, _ := json.Marshal(cmd.id)
+		if !cmd.resource.keyed && strings.Trim(cmd.id, "0123456789") == "" {
+			id = json.RawMessage(cmd.id)
+		}
+		fields[key] = id
+	}
+
+	// keyed resources are created and updated by a PUT to the list path
+	method, template := "PUT", cmd.resource.item
+	switch {
+	case cmd.resource.keyed:
+		template = cmd.resource.list
+	case cmd.action == "create":
+		method, template = "POST", cmd.resource.list
+	}
+	p, err := cmd.resource.path(template, cmd.parent, cmd.id)
+	if err != nil {
+		return err
+	}
+
+	body := map[string]interface{}{cmd.resource.singular: fields}
+	resource := map[string]json.RawMessage{}
+	if method == "POST" {
+		err = client.Post(p, body, &resource)
+	} else {
+		err = client.Put(p, body, &resource)
+	}
+	if err != nil {
+		return err
+	}
+
+	return c.writeItem(cmd, resource[cmd.resource.singular])
+}
+
+func (c *cli) delete(client *shopify.Client, cmd *command) error {
+	p, options, err := cmd.itemPath()
+	if err != nil {
+		return err
+	}
+
+	if err := client.DeleteWithOptions(p, options); err != nil {
+		return err
+	}
+	fmt.Fprintf(c.stderr, "deleted %s %s\n", cmd.resource.singular, cmd.id)
+	return nil
+}
+
+// itemPath returns the path and options addressing the resource of the
+// command.
+func (cmd *command) itemPath() (string, interface{}, error) {
+	if cmd.resource.keyed {
+		p, err := cmd.resource.path(cmd.resource.list, cmd.parent, "")
+		return p, assetKey{Key: cmd.id}, err
+	}
+
+	p, err := cmd.resource.path(cmd.resource.item, cmd.parent, cmd.id)
+	return p, nil, err
+}
+
+// body reads the JSON of a resource from the -data or -file flag.
+func (cmd *command) body(stdin io.Reader) ([]byte, error) {
+	switch {
+	case cmd.data != "":
+		return []byte(cmd.data), nil
+	case cmd.file == "-":
+		return io.ReadAll(stdin)
+	case cmd.file != "":
+		return os.ReadFile(cmd.file)
+	}
+	return nil, errors.New("missing -data or -file")
+}
+
+// outputColumns returns the columns of table and csv outputs.
+func (cmd *command) outputColumns() []string {
+	switch {
+	case cmd.columns != "":
+		return strings.Split(cmd.columns, ",")
+	case cmd.fields != "":
+		return strings.Split(cmd.fields, ",")
+	}
+	return cmd.resource.columns
+}
+
+func (c *cli) writeItem(cmd *command, item json.RawMessage) error {
+	if cmd.output == outputJSON {
+		return writeJSON(c.stdout, item)
+	}
+	return writeItems(c.stdout, cmd.output, cmd.outputColumns(), []json.RawMessage{item})
+}
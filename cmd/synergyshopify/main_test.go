@@ -0,0 +1,258 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	shopify "github.com/binodsynergytechs/synergyshopify"
+	"github.com/binodsynergytechs/synergyshopify/shopifytest"
+)
+
+// testCLI returns a cli talking to the fake server, with its credentials in
+// the environment.
+func testCLI(server *shopifytest.Server) (*cli, *bytes.Buffer, *bytes.Buffer) {
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	env := map[string]string{
+		envShop:   shopifytest.ShopName,
+		envToken:  shopifytest.Token,
+		envConfig: filepath.Join(os.TempDir(), "synergyshopify-missing-config.json"),
+	}
+
+	return &cli{
+		stdin:   strings.NewReader(`{"title": "From stdin"}`),
+		stdout:  stdout,
+		stderr:  stderr,
+		getenv:  func(key string) string { return env[key] },
+		options: []shopify.Option{shopify.WithHTTPClient(server.HTTPClient())},
+	}, stdout, stderr
+}
+
+func TestProductCommands(t *testing.T) {
+	server := shopifytest.NewServer()
+	defer server.Close()
+	c, stdout, _ := testCLI(server)
+
+	if err := c.run([]string{"products", "create", "-data", `{"title": "Shirt", "vendor": "Acme"}`}); err != nil {
+		t.Fatalf("create returned error: %v", err)
+	}
+	var product shopify.Product
+	if err := json.Unmarshal(stdout.Bytes(), &product); err != nil || product.ID == 0 || product.Vendor != "Acme" {
+		t.Fatalf("unexpected created product %s, %v", stdout, err)
+	}
+	productID := product.ID
+
+	stdout.Reset()
+	if err := c.run([]string{"products", "create", "-file", "-"}); err != nil {
+		t.Fatalf("create from stdin returned error: %v", err)
+	}
+
+	stdout.Reset()
+	if err := c.run([]string{"products", "update", jsonID(productID), "-data", `{"product": {"title": "T-Shirt"}}`}); err != nil {
+		t.Fatalf("update returned error: %v", err)
+	}
+	if p, _ := server.Get("products", productID); p["title"] != "T-Shirt" {
+		t.Errorf("expected the product to be updated, got %v", p)
+	}
+
+	stdout.Reset()
+	if err := c.run([]string{"products", "list", "-output", "csv", "-columns", "title,vendor"}); err != nil {
+		t.Fatalf("list returned error: %v", err)
+	}
+	if expected := "title,vendor\nT-Shirt,Acme\nFrom stdin,\n"; stdout.String() != expected {
+		t.Errorf("list returned %q, expected %q", stdout.String(), expected)
+	}
+
+	stdout.Reset()
+	if err := c.run([]string{"products", "count", "-filter", "vendor=Acme"}); err != nil {
+		t.Fatalf("count returned error: %v", err)
+	}
+	if stdout.String() != "1\n" {
+		t.Errorf("count returned %q", stdout.String())
+	}
+
+	stdout.Reset()
+	if err := c.run([]string{"products", "get", jsonID(productID), "-output", "table", "-fields", "id,title"}); err != nil {
+		t.Fatalf("get returned error: %v", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(stdout.String()), "\n"); len(lines) != 2 || !strings.HasPrefix(lines[0], "ID") || !strings.Contains(lines[1], "T-Shirt") {
+		t.Errorf("get returned %q", stdout.String())
+	}
+
+	if err := c.run([]string{"products", "delete", jsonID(productID)}); err != nil {
+		t.Fatalf("delete returned error: %v", err)
+	}
+	if server.Count("products") != 1 {
+		t.Error("expected the product to be deleted")
+	}
+}
+
+func TestListPages(t *testing.T) {
+	server := shopifytest.NewServer()
+	defer server.Close()
+	for i := 0; i < 5; i++ {
+		server.Seed("customers", shopify.Customer{Email: "c@example.com", Tags: "vip"})
+	}
+	c, stdout, stderr := testCLI(server)
+
+	if err := c.run([]string{"customers", "list", "-limit", "2"}); err != nil {
+		t.Fatalf("list returned error: %v", err)
+	}
+	var page []shopify.Customer
+	if err := json.Unmarshal(stdout.Bytes(), &page); err != nil || len(page) != 2 {
+		t.Errorf("expected a page of 2 customers, got %s, %v", stdout, err)
+	}
+	if !strings.HasPrefix(stderr.String(), "next page: -page-info ") {
+		t.Errorf("expected the next page to be printed, got %q", stderr.String())
+	}
+
+	pageInfo := strings.TrimSpace(strings.TrimPrefix(stderr.String(), "next page: -page-info "))
+	stdout.Reset()
+	if err := c.run([]string{"customers", "list", "-limit", "2", "-page-info", pageInfo}); err != nil {
+		t.Fatalf("list of the next page returned error: %v", err)
+	}
+	var next []shopify.Customer
+	if err := json.Unmarshal(stdout.Bytes(), &next); err != nil || len(next) != 2 || next[0].ID == page[1].ID {
+		t.Errorf("unexpected next page %s, %v", stdout, err)
+	}
+
+	stdout.Reset()
+	if err := c.run([]string{"customers", "list", "-all", "-limit", "2", "-output", "csv", "-columns", "id"}); err != nil {
+		t.Fatalf("list -all returned error: %v", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(stdout.String()), "\n"); len(lines) != 6 {
+		t.Errorf("expected every customer to be listed, got %q", stdout.String())
+	}
+}
+
+func TestNestedResources(t *testing.T) {
+	server := shopifytest.NewServer()
+	defer server.Close()
+	productID, _ := server.Seed("products", shopify.Product{Title: "Hat"})
+	c, stdout, _ := testCLI(server)
+
+	if err := c.run([]string{"variants", "list"}); err == nil || !strings.Contains(err.Error(), "-parent") {
+		t.Errorf("expected a missing -parent error, got %v", err)
+	}
+
+	if err := c.run([]string{"variants", "list", "-parent", jsonID(productID), "-output", "csv", "-columns", "title"}); err != nil {
+		t.Fatalf("list returned error: %v", err)
+	}
+	if stdout.String() != "title\nDefault Title\n" {
+		t.Errorf("unexpected variants %q", stdout.String())
+	}
+
+	stdout.Reset()
+	if err := c.run([]string{"metafields", "create", "-parent", "products/" + jsonID(productID),
+		"-data", `{"namespace": "custom", "key": "size", "value": "M", "type": "single_line_text_field"}`}); err != nil {
+		t.Fatalf("create returned error: %v", err)
+	}
+	if err := c.run([]string{"metafields", "count"}); err != nil {
+		t.Fatalf("count returned error: %v", err)
+	}
+	if !strings.HasSuffix(stdout.String(), "\n0\n") {
+		t.Errorf("expected the shop to have no metafields, got %q", stdout.String())
+	}
+}
+
+func TestCommandErrors(t *testing.T) {
+	server := shopifytest.NewServer()
+	defer server.Close()
+	c, _, _ := testCLI(server)
+
+	cases := []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"widgets", "list"}, "unknown resource"},
+		{[]string{"locations", "delete", "1"}, "don't support delete"},
+		{[]string{"products", "get"}, "missing id"},
+		{[]string{"products", "list", "-filter", "colour=red"}, `unknown filter "colour"`},
+		{[]string{"orders", "list", "-filter", "created_at_min=yesterday"}, "invalid time"},
+		{[]string{"products", "create"}, "missing -data or -file"},
+		{[]string{"products", "get", "1"}, "Not Found"},
+	}
+
+	for _, tc := range cases {
+		if err := c.run(tc.args); err == nil || !strings.Contains(err.Error(), tc.expected) {
+			t.Errorf("%v: expected an error containing %q, got %v", tc.args, tc.expected, err)
+		}
+	}
+}
+
+func TestListOptions(t *testing.T) {
+	options, err := listOptions(shopify.OrderListOptions{}, map[string]string{
+		"status":         "any",
+		"limit":          "10",
+		"ids":            "1,2",
+		"created_at_min": "2024-01-02",
+	})
+	if err != nil {
+		t.Fatalf("listOptions returned error: %v", err)
+	}
+
+	expected := shopify.OrderListOptions{Status: "any"}
+	expected.Limit = 10
+	expected.IDs = []int64{1, 2}
+	expected.CreatedAtMin = time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	if !reflect.DeepEqual(options, expected) {
+		t.Errorf("listOptions returned %+v, expected %+v", options, expected)
+	}
+
+	payouts, err := listOptions(shopify.PayoutsListOptions{}, map[string]string{"date_min": "2024-01-02"})
+	if err != nil || payouts.(shopify.PayoutsListOptions).DateMin == nil {
+		t.Errorf("listOptions returned %+v, %v", payouts, err)
+	}
+}
+
+func TestCredentials(t *testing.T) {
+	config := filepath.Join(t.TempDir(), "config.json")
+	os.WriteFile(config, []byte(`{
+		"default": "acme",
+		"profiles": {
+			"acme": {"shop": "acme", "token": "acme-token", "api_version": "2024-01"},
+			"other": {"shop": "other", "token": "other-token"}
+		}
+	}`), 0o600)
+
+	cases := []struct {
+		flags    profile
+		profile  string
+		env      map[string]string
+		expected profile
+	}{
+		{profile{}, "", nil, profile{Shop: "acme", Token: "acme-token", APIVersion: "2024-01"}},
+		{profile{}, "other", nil, profile{Shop: "other", Token: "other-token"}},
+		{profile{}, "", map[string]string{envProfile: "other"}, profile{Shop: "other", Token: "other-token"}},
+		{profile{}, "", map[string]string{envShop: "env", envToken: "env-token"}, profile{Shop: "env", Token: "env-token"}},
+		{profile{}, "other", map[string]string{envShop: "env", envToken: "env-token"}, profile{Shop: "other", Token: "other-token"}},
+		{profile{Token: "flag-token"}, "", nil, profile{Shop: "acme", Token: "flag-token", APIVersion: "2024-01"}},
+	}
+
+	for _, c := range cases {
+		env := map[string]string{envConfig: config}
+		for k, v := range c.env {
+			env[k] = v
+		}
+
+		actual, err := credentials(c.flags, c.profile, func(key string) string { return env[key] })
+		if err != nil || actual != c.expected {
+			t.Errorf("credentials(%+v, %q, %v) returned %+v, %v, expected %+v", c.flags, c.profile, c.env, actual, err, c.expected)
+		}
+	}
+
+	_, err := credentials(profile{}, "missing", func(key string) string { return map[string]string{envConfig: config}[key] })
+	if err == nil || !strings.Contains(err.Error(), `no profile "missing"`) {
+		t.Errorf("expected a missing profile error, got %v", err)
+	}
+}
+
+func jsonID(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
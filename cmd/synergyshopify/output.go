@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// Output formats.
+const (
+	outputJSON  = "json"
+	outputTable = "table"
+	outputCSV   = "csv"
+)
+
+// writeItems writes resources in the given format, the columns selecting the
+// fields of table and csv outputs.
+func writeItems(w io.Writer, format string, columns []string, items []json.RawMessage) error {
+	switch format {
+	case outputJSON:
+		if items == nil {
+			items = []json.RawMessage{}
+		}
+		return writeJSON(w, items)
+
+	case outputTable, outputCSV:
+		rows := make([][]string, 0, len(items)+1)
+		header := make([]string, len(columns))
+		for i, column := range columns {
+			header[i] = column
+			if format == outputTable {
+				header[i] = strings.ToUpper(column)
+			}
+		}
+		rows = append(rows, header)
+
+		for _, item := range items {
+			row, err := itemRow(item, columns)
+			if err != nil {
+				return err
+			}
+			rows = append(rows, row)
+		}
+
+		if format == outputCSV {
+			cw := csv.NewWriter(w)
+			cw.WriteAll(rows)
+			return cw.Error()
+		}
+
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		for _, row := range rows {
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+		return tw.Flush()
+	}
+
+	return fmt.Errorf("unknown output %q, expected json, table or csv", format)
+}
+
+// writeJSON writes v as indented JSON.
+func writeJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// itemRow returns the cells of a resource for the given columns.
+func itemRow(item json.RawMessage, columns []string) ([]string, error) {
+	fields := map[string]interface{}{}
+	decoder := json.NewDecoder(bytes.NewReader(item))
+	decoder.UseNumber()
+	if err := decoder.Decode(&fields); err != nil {
+		return nil, err
+	}
+
+	row := make([]string, len(columns))
+	for i, column := range columns {
+		row[i] = cell(fields[column])
+	}
+	return row, nil
+}
+
+// cell formats a field, nested values as compact JSON.
+func cell(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		if v {
+			return "true"
+		}
+		return "false"
+	}
+
+	data, _ := json.Marshal(v)
+	return string(data)
+}
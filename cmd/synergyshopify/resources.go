@@ -0,0 +1,210 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	shopify "github.com/binodsynergytechs/synergyshopify"
+)
+
+// resource describes the REST endpoints of a kind of resource. Paths are
+// relative to the api version and may reference the -parent flag with
+// {parent}.
+type resource struct {
+	// singular is the key of a single resource in request and response
+	// bodies, e.g. "product".
+	singular string
+
+	// list is the path of the list, count and create endpoints, item the one
+	// of a single resource, {id} being replaced by its id.
+	list string
+	item string
+
+	// options is the list options struct the filters are mapped to.
+	options interface{}
+
+	// columns are the default columns of table and csv outputs.
+	columns []string
+
+	// parent describes the -parent flag of nested resources, empty for top
+	// level ones. With optionalParent, the {parent}/ prefix of the paths is
+	// dropped without the flag.
+	parent         string
+	optionalParent bool
+
+	// keyed resources are addressed by a key passed as an asset[key]
+	// parameter, created and updated by a PUT to the list path.
+	keyed bool
+
+	// actions restricts the supported actions, all of them when empty.
+	actions []string
+}
+
+var resources = map[string]resource{
+	"products": {
+		singular: "product", list: "products", item: "products/{id}",
+		options: shopify.ProductListOptions{},
+		columns: []string{"id", "title", "vendor", "product_type", "status"},
+	},
+	"variants": {
+		singular: "variant", list: "products/{parent}/variants", item: "variants/{id}",
+		options: shopify.ListOptions{}, parent: "product id",
+		columns: []string{"id", "product_id", "title", "sku", "price", "inventory_quantity"},
+	},
+	"images": {
+		singular: "image", list: "products/{parent}/images", item: "products/{parent}/images/{id}",
+		options: shopify.ListOptions{}, parent: "product id",
+		columns: []string{"id", "product_id", "position", "src"},
+	},
+	"orders": {
+		singular: "order", list: "orders", item: "orders/{id}",
+		options: shopify.OrderListOptions{},
+		columns: []string{"id", "name", "email", "financial_status", "fulfillment_status", "total_price", "created_at"},
+	},
+	"draft_orders": {
+		singular: "draft_order", list: "draft_orders", item: "draft_orders/{id}",
+		options: shopify.DraftOrderListOptions{},
+		columns: []string{"id", "name", "status", "total_price", "created_at"},
+	},
+	"customers": {
+		singular: "customer", list: "customers", item: "customers/{id}",
+		options: shopify.ListOptions{},
+		columns: []string{"id", "email", "first_name", "last_name", "state"},
+	},
+	"custom_collections": {
+		singular: "custom_collection", list: "custom_collections", item: "custom_collections/{id}",
+		options: shopify.ListOptions{},
+		columns: []string{"id", "handle", "title"},
+	},
+	"smart_collections": {
+		singular: "smart_collection", list: "smart_collections", item: "smart_collections/{id}",
+		options: shopify.ListOptions{},
+		columns: []string{"id", "handle", "title"},
+	},
+	"collects": {
+		singular: "collect", list: "collects", item: "collects/{id}",
+		options: shopify.ListOptions{},
+		columns: []string{"id", "collection_id", "product_id", "position"},
+	},
+	"metafields": {
+		singular: "metafield", list: "{parent}/metafields", item: "{parent}/metafields/{id}",
+		options: shopify.ListOptions{}, parent: "owner path, e.g. products/123", optionalParent: true,
+		columns: []string{"id", "namespace", "key", "type", "value"},
+	},
+	"webhooks": {
+		singular: "webhook", list: "webhooks", item: "webhooks/{id}",
+		options: shopify.WebhookOptions{},
+		columns: []string{"id", "topic", "address", "format"},
+	},
+	"themes": {
+		singular: "theme", list: "themes", item: "themes/{id}",
+		options: shopify.ThemeListOptions{},
+		columns: []string{"id", "name", "role"},
+	},
+	"assets": {
+		singular: "asset", list: "themes/{parent}/assets",
+		options: struct{}{}, parent: "theme id", keyed: true,
+		columns: []string{"key", "content_type", "size", "updated_at"},
+	},
+	"redirects": {
+		singular: "redirect", list: "redirects", item: "redirects/{id}",
+		options: shopify.ListOptions{},
+		columns: []string{"id", "path", "target"},
+	},
+	"price_rules": {
+		singular: "price_rule", list: "price_rules", item: "price_rules/{id}",
+		options: shopify.ListOptions{},
+		columns: []string{"id", "title", "value_type", "value", "starts_at", "ends_at"},
+	},
+	"discount_codes": {
+		singular: "discount_code", list: "price_rules/{parent}/discount_codes", item: "price_rules/{parent}/discount_codes/{id}",
+		options: shopify.ListOptions{}, parent: "price rule id",
+		columns: []string{"id", "code", "usage_count"},
+	},
+	"pages": {
+		singular: "page", list: "pages", item: "pages/{id}",
+		options: shopify.ListOptions{},
+		columns: []string{"id", "title", "handle", "published_at"},
+	},
+	"blogs": {
+		singular: "blog", list: "blogs", item: "blogs/{id}",
+		options: shopify.ListOptions{},
+		columns: []string{"id", "title", "handle"},
+	},
+	"script_tags": {
+		singular: "script_tag", list: "script_tags", item: "script_tags/{id}",
+		options: shopify.ListOptions{},
+		columns: []string{"id", "src", "event", "display_scope"},
+	},
+	"gift_cards": {
+		singular: "gift_card", list: "gift_cards", item: "gift_cards/{id}",
+		options: shopify.ListOptions{},
+		columns: []string{"id", "last_characters", "balance", "currency", "expires_on"},
+	},
+	"locations": {
+		singular: "location", list: "locations", item: "locations/{id}",
+		options: shopify.ListOptions{},
+		columns: []string{"id", "name", "city", "country_code", "active"},
+		actions: []string{"list", "get", "count"},
+	},
+	"inventory_items": {
+		singular: "inventory_item", list: "inventory_items", item: "inventory_items/{id}",
+		options: shopify.ListOptions{},
+		columns: []string{"id", "sku", "cost", "tracked"},
+		actions: []string{"list", "get", "update"},
+	},
+	"inventory_levels": {
+		singular: "inventory_level", list: "inventory_levels",
+		options: shopify.InventoryLevelListOptions{},
+		columns: []string{"inventory_item_id", "location_id", "available", "updated_at"},
+		actions: []string{"list"},
+	},
+	"payouts": {
+		singular: "payout", list: "shopify_payments/payouts", item: "shopify_payments/payouts/{id}",
+		options: shopify.PayoutsListOptions{},
+		columns: []string{"id", "status", "date", "amount", "currency"},
+		actions: []string{"list", "get"},
+	},
+}
+
+// supports reports whether the resource supports the action.
+func (r resource) supports(action string) bool {
+	if r.keyed && action == "count" {
+		return false
+	}
+	if len(r.actions) == 0 {
+		return true
+	}
+	for _, a := range r.actions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// path fills in the parent and id of a path template.
+func (r resource) path(template, parent, id string) (string, error) {
+	if strings.Contains(template, "{parent}") {
+		switch {
+		case parent != "":
+			template = strings.ReplaceAll(template, "{parent}", strings.Trim(parent, "/"))
+		case r.optionalParent:
+			template = strings.TrimPrefix(template, "{parent}/")
+		default:
+			return "", fmt.Errorf("missing -parent flag, the %s", r.parent)
+		}
+	}
+
+	return strings.ReplaceAll(template, "{id}", id) + ".json", nil
+}
+
+func resourceNames() []string {
+	names := make([]string, 0, len(resources))
+	for name := range resources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -195,7 +195,7 @@ func TestCollectionListProductsError(t *testing.T) {
 		t.Errorf("Collection.ListProducts returned products %v, expected no products to be returned", products)
 	}
 
-	expectedError := fmt.Errorf("invalid character 's' looking for beginning of object key string")
+	expectedError := fmt.Errorf("invalid character 's' looking for beginning of object key string (GET /%s/collections/1/products.json, api version %s, 1 attempt)", client.pathPrefix, testApiVersion)
 	if err == nil || err.Error() != expectedError.Error() {
 		t.Errorf("Collection.ListProducts err returned %v, expected %v", err, expectedError)
 	}
@@ -357,7 +357,7 @@ func TestCollectionListProductsWithPaginationRequestError(t *testing.T) {
 		t.Errorf("Collection.ListProductsWithPagination returned pagination %v, expected nil", products)
 	}
 
-	expectedError := fmt.Errorf("invalid character 's' looking for beginning of object key string")
+	expectedError := fmt.Errorf("invalid character 's' looking for beginning of object key string (GET /%s/collections/1/products.json, api version %s, 1 attempt)", client.pathPrefix, testApiVersion)
 	if err == nil || err.Error() != expectedError.Error() {
 		t.Errorf("Collection.ListProductsWithPagination err returned %v, expected %v", err, expectedError)
 	}
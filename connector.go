@@ -3,10 +3,11 @@ package synergyshopify
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"path"
@@ -15,6 +16,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/go-querystring/query"
@@ -68,17 +70,35 @@ type Client struct {
 	// URL Prefix, defaults to "admin" see WithVersion
 	pathPrefix string
 
-	// version you're currently using of the api, defaults to "stable"
-	apiVersion string
-
 	// A permanent access token
 	token string
 
+	// context attached to requests made through the services, see WithContext
+	ctx context.Context
+
 	// max number of retries, defaults to 0 for no retries see WithRetry option
-	retries  int
-	attempts int
+	retries int
+
+	// retry policy, see WithRetryPolicy
+	retry *RetryPolicy
+
+	// api version and rate limits, shared by copies made with WithContext
+	state *clientState
+
+	// optional limiter of the REST API calls, see WithRateLimiter
+	rateLimiter *RateLimiter
 
-	RateLimits RateLimitInfo
+	// cost budget of the GraphQL API, shared by copies made with WithContext
+	graphQLThrottle *graphQLThrottle
+
+	// optional cache of GET responses, see WithCache
+	cache *responseCache
+
+	// interceptors of the requests sent, see WithMiddleware
+	middleware []Middleware
+
+	// masks secrets and personal data in debug logs, see WithRedactedFields
+	redactor *redactor
 
 	// Services used for communicating with the API
 	Product                    ProductService
@@ -121,6 +141,8 @@ type Client struct {
 	Payouts                    PayoutsService
 	GiftCard                   GiftCardService
 	OrderRisk                  OrderRiskService
+	GraphQL                    GraphQLService
+	BulkOperation              BulkOperationService
 }
 
 // A general response error that follows a similar layout to Shopify's response
@@ -185,6 +207,13 @@ type RateLimitError struct {
 // specified without a preceding slash. If specified, the value pointed to by
 // body is JSON encoded and included as the request body.
 func (c *Client) NewRequest(method, relPath string, body, options interface{}) (*http.Request, error) {
+	return c.NewRequestWithContext(c.context(), method, relPath, body, options)
+}
+
+// NewRequestWithContext creates an API request like NewRequest, attaching the
+// given context to it. The context controls the entire lifetime of the request
+// including any retries done by ProcessRequestWithHeaders.
+func (c *Client) NewRequestWithContext(ctx context.Context, method, relPath string, body, options interface{}) (*http.Request, error) {
 	rel, err := url.Parse(relPath)
 	if err != nil {
 		return nil, err
@@ -218,7 +247,7 @@ func (c *Client) NewRequest(method, relPath string, body, options interface{}) (
 		}
 	}
 
-	req, err := http.NewRequest(method, u.String(), bytes.NewBuffer(js))
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewBuffer(js))
 	if err != nil {
 		return nil, err
 	}
@@ -256,13 +285,28 @@ func NewClient(app App, shopName, token string, opts ...Option) *Client {
 			Timeout: time.Second * defaultHttpTimeout,
 		},
 		log:        &LeveledLogger{},
+		redactor:   newRedactor(DefaultRedactedFields()),
 		app:        app,
 		baseURL:    baseURL,
 		token:      token,
-		apiVersion: defaultApiVersion,
 		pathPrefix: defaultApiPathPrefix,
+		state:      &clientState{apiVersion: defaultApiVersion},
+
+		graphQLThrottle: newGraphQLThrottle(),
 	}
 
+	c.initServices()
+
+	// apply any options
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// initServices points every service of the client back at the client itself.
+func (c *Client) initServices() {
 	c.Product = &ProductServiceOp{client: c}
 	c.CustomCollection = &CustomCollectionServiceOp{client: c}
 	c.SmartCollection = &SmartCollectionServiceOp{client: c}
@@ -303,13 +347,75 @@ func NewClient(app App, shopName, token string, opts ...Option) *Client {
 	c.Payouts = &PayoutsServiceOp{client: c}
 	c.GiftCard = &GiftCardServiceOp{client: c}
 	c.OrderRisk = &OrderRiksServiceOp{client: c}
+	c.GraphQL = &GraphQLServiceOp{client: c}
+	c.BulkOperation = &BulkOperationServiceOp{client: c}
+}
 
-	// apply any options
-	for _, opt := range opts {
-		opt(c)
+// WithContext returns a shallow copy of the client whose services send every
+// request with the given context, e.g.
+//
+//	orders, err := client.WithContext(ctx).Order.List(nil)
+//
+// The context is used for the HTTP call as well as for any waits between
+// retries. Services that aren't the ServiceOps of c, e.g. fakes, are kept as
+// is. This is how service methods are given a context, the methods
+// taking one directly being the lower level helpers, e.g. GetContext and
+// PollContext, and GraphQL.QueryContext. The provided ctx must be non-nil.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	if ctx == nil {
+		panic("nil context")
 	}
 
-	return c
+	c2 := new(Client)
+	*c2 = *c
+	c2.ctx = ctx
+	c2.initServices()
+	c2.keepServices(c)
+
+	return c2
+}
+
+// keepServices sets back the services of the client c is a copy of that
+// initServices replaced although they aren't ServiceOps bound to the
+// original, e.g. fakes set by tests.
+func (c *Client) keepServices(original *Client) {
+	from, to := reflect.ValueOf(original).Elem(), reflect.ValueOf(c).Elem()
+	for i := 0; i < from.NumField(); i++ {
+		service := from.Field(i)
+		if service.Kind() != reflect.Interface || service.IsNil() || !to.Field(i).CanSet() {
+			continue
+		}
+		if !boundTo(service.Elem(), original) {
+			to.Field(i).Set(service)
+		}
+	}
+}
+
+// boundTo reports whether service is a ServiceOp of the client c.
+func boundTo(service reflect.Value, c *Client) bool {
+	if service.Kind() != reflect.Pointer || service.IsNil() || service.Elem().Kind() != reflect.Struct {
+		return false
+	}
+
+	client := service.Elem().FieldByName("client")
+	return client.IsValid() && client.Type() == reflect.TypeOf(c) && client.Pointer() == reflect.ValueOf(c).Pointer()
+}
+
+// GraphQLThrottleStatus returns the cost budget of the GraphQL API as
+// estimated from the last GraphQL response, including what has been restored
+// since.
+func (c *Client) GraphQLThrottleStatus() GraphQLThrottleStatus {
+	return c.graphQLThrottle.available()
+}
+
+// context returns the context bound with WithContext, or context.Background()
+// when none was set.
+func (c *Client) context() context.Context {
+	if c.ctx != nil {
+		return c.ctx
+	}
+
+	return context.Background()
 }
 
 // ProcessRequest sends an API request and populates the given interface with the parsed
@@ -326,20 +432,121 @@ func (c *Client) ProcessRequest(req *http.Request, v interface{}) error {
 
 // ProcessRequestWithHeaders executes a request, decoding the response into `v` and also returns any response headers.
 func (c *Client) ProcessRequestWithHeaders(req *http.Request, v interface{}) (http.Header, error) {
+	resp, err := c.Do(req, v)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Header, nil
+}
+
+// Response holds the outcome of a request executed with Do.
+type Response struct {
+	// Header and StatusCode of the last response, if any was received
+	Header     http.Header
+	StatusCode int
+
+	// Attempts is the number of times the request was sent
+	Attempts int
+
+	// Cached reports whether the body was served from the response cache,
+	// either without a request or after a 304 Not Modified response
+	Cached bool
+}
+
+// Do executes a request, retrying it according to the retry policy of the
+// client, and decodes the response into `v`. The returned Response is never
+// nil so the number of attempts is available even when an error is returned.
+// Errors are wrapped in a RequestError describing the request.
+// Do is safe for concurrent use.
+func (c *Client) Do(req *http.Request, v interface{}) (*Response, error) {
 	var resp *http.Response
 	var err error
-	retries := c.retries
-	c.attempts = 0
+	response := new(Response)
+	policy := c.retryPolicy()
+	redirects := 0
+
+	var cached *CacheEntry
+	if c.cache != nil {
+		defer c.cache.invalidate(req)
+
+		cached = c.cache.lookup(req)
+		if cached != nil && cached.fresh() {
+			c.log.Debugf("%s: %s served from cache", req.Method, req.URL.String())
+			response.Header = cached.Header
+			response.StatusCode = http.StatusOK
+			response.Cached = true
+			if err := decodeBody(bytes.NewReader(cached.Body), v); err != nil {
+				return response, c.requestError(req, response, err)
+			}
+			return response, nil
+		}
+	}
+
 	c.logRequest(req)
 
 	for {
-		c.attempts++
-		resp, err = c.Client.Do(req)
+		// don't start another attempt once the caller gave up on the request
+		if err := req.Context().Err(); err != nil {
+			return response, c.requestError(req, response, err)
+		}
+
+		limited := c.rateLimiter != nil && !isGraphQLRequest(req)
+		if limited {
+			if err := c.rateLimiter.Wait(req.Context(), req.URL.Host); err != nil {
+				return response, c.requestError(req, response, err)
+			}
+		}
+
+		response.Attempts++
+		start := time.Now()
+		resp, err = c.send(req)
+		c.logAttempt(req, resp, err, response.Attempts, time.Since(start))
+		if limited {
+			c.rateLimiter.Update(req.URL.Host, resp)
+		}
 		c.logResponse(resp)
 		if err != nil {
-			return nil, err // http client errors, not api responses
+			// http client errors, not api responses
+			if !policy.retryTransportError(req, err, response.Attempts) {
+				return response, c.requestError(req, response, err)
+			}
+
+			wait := policy.backoff(response.Attempts)
+			c.log.Debugf("request failed with %s, retrying in %s", err, wait.String())
+			if err := c.waitToRetry(req, wait); err != nil {
+				return response, c.requestError(req, response, err)
+			}
+			continue
+		}
+		response.Header = resp.Header
+		response.StatusCode = resp.StatusCode
+
+		// the result is to be retrieved with a GET on the Location header,
+		// unless the http client already followed the redirect
+		if resp.StatusCode == http.StatusSeeOther && resp.Header.Get("Location") != "" {
+			resp.Body.Close()
+			if redirects++; redirects > maxSeeOtherRedirects {
+				return response, c.requestError(req, response, errTooManyRedirects)
+			}
+
+			next, err := seeOtherRequest(req, resp)
+			if err != nil {
+				return response, c.requestError(req, response, err)
+			}
+			c.log.Debugf("following 303 See Other to %s", c.redactor.url(next.URL))
+			req = next
+			continue
+		}
+
+		if cached != nil && resp.StatusCode == http.StatusNotModified {
+			resp.Body.Close()
+			c.cache.refresh(req, cached)
+			resp.Body = io.NopCloser(bytes.NewReader(cached.Body))
+			response.Header = cached.Header
+			response.Cached = true
+			break
 		}
-		log.Println("resp.StatusCode while request in shopify", resp.StatusCode)
 
 		respErr := CheckResponseError(resp)
 		if respErr == nil {
@@ -349,61 +556,192 @@ func (c *Client) ProcessRequestWithHeaders(req *http.Request, v interface{}) (ht
 		// retry scenario, close resp and any continue will retry
 		resp.Body.Close()
 
-		if retries <= 1 {
-			return nil, respErr
+		wait, retry := policy.retryResponse(req, respErr, response.Attempts)
+		if !retry {
+			// no retry attempts, just return the err
+			return response, c.requestError(req, response, respErr)
 		}
 
-		if rateLimitErr, isRetryErr := respErr.(RateLimitError); isRetryErr {
-			// back off and retry
-
-			wait := time.Duration(rateLimitErr.RetryAfter) * time.Second
-			c.log.Debugf("rate limited waiting %s", wait.String())
-			time.Sleep(wait)
-			retries--
-			continue
+		c.log.Debugf("request failed with status %d, retrying in %s", resp.StatusCode, wait.String())
+		if err := c.waitToRetry(req, wait); err != nil {
+			return response, c.requestError(req, response, err)
 		}
+	}
 
-		var doRetry bool
-		switch resp.StatusCode {
-		case http.StatusServiceUnavailable:
-			c.log.Debugf("service unavailable, retrying")
-			doRetry = true
-			retries--
-		}
+	defer resp.Body.Close()
 
-		if doRetry {
-			continue
+	if version := c.state.resolveAPIVersion(resp.Header.Get("X-Shopify-API-Version")); version != "" {
+		c.log.Infof("api version not set, now using %s", version)
+	}
+
+	body := io.Reader(resp.Body)
+	if c.cache != nil && !response.Cached {
+		if _, ok := c.cache.ttl(req); ok {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return response, c.requestError(req, response, err)
+			}
+			c.cache.store(req, resp.Header, b)
+			body = bytes.NewReader(b)
 		}
+	}
 
-		// no retry attempts, just return the err
-		return nil, respErr
+	if err := decodeBody(body, v); err != nil {
+		return response, c.requestError(req, response, err)
 	}
 
-	c.logResponse(resp)
-	defer resp.Body.Close()
+	c.state.updateRateLimits(resp.Header)
 
-	if c.apiVersion == defaultApiVersion && resp.Header.Get("X-Shopify-API-Version") != "" {
-		// if using stable on first request set the api version
-		c.apiVersion = resp.Header.Get("X-Shopify-API-Version")
-		c.log.Infof("api version not set, now using %s", c.apiVersion)
+	return response, nil
+}
+
+// requestError wraps err with the metadata of req and of the last response
+// received for it.
+func (c *Client) requestError(req *http.Request, response *Response, err error) error {
+	requestErr := RequestError{
+		Method:     req.Method,
+		Path:       req.URL.Path,
+		APIVersion: c.APIVersion(),
+		Attempts:   response.Attempts,
+		Err:        err,
 	}
 
-	if v != nil {
-		decoder := json.NewDecoder(resp.Body)
-		err := decoder.Decode(&v)
-		if err != nil {
-			return nil, err
+	if response.Header != nil {
+		requestErr.RequestID = response.Header.Get("X-Request-Id")
+		if version := response.Header.Get("X-Shopify-API-Version"); version != "" {
+			requestErr.APIVersion = version
 		}
 	}
 
-	if s := strings.Split(resp.Header.Get("X-Shopify-Shop-Api-Call-Limit"), "/"); len(s) == 2 {
-		c.RateLimits.RequestCount, _ = strconv.Atoi(s[0])
-		c.RateLimits.BucketSize, _ = strconv.Atoi(s[1])
+	return requestErr
+}
+
+// decodeBody decodes a response body into v, letting resources implementing
+// responseDecoder decode it themselves.
+func decodeBody(body io.Reader, v interface{}) error {
+	if d, ok := v.(responseDecoder); ok {
+		return d.decodeResponse(body)
+	}
+
+	if v != nil {
+		return json.NewDecoder(body).Decode(&v)
 	}
 
-	c.RateLimits.RetryAfterSeconds, _ = strconv.ParseFloat(resp.Header.Get("Retry-After"), 64)
+	return nil
+}
 
-	return resp.Header, nil
+// clientState holds the state of a client that is updated by the responses it
+// receives. It is shared by the copies of the client made with WithContext and
+// guarded by a mutex so the client can be used from several goroutines.
+type clientState struct {
+	mu sync.RWMutex
+
+	// version you're currently using of the api, defaults to "stable"
+	apiVersion string
+
+	rateLimits RateLimitInfo
+}
+
+// resolveAPIVersion replaces the "stable" version with the one reported by
+// Shopify the first time it is known, and returns it. It returns an empty
+// string when the version was already resolved.
+func (s *clientState) resolveAPIVersion(version string) string {
+	if version == "" {
+		return ""
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.apiVersion != defaultApiVersion {
+		return ""
+	}
+	s.apiVersion = version
+
+	return version
+}
+
+// updateRateLimits records the rate limit headers of a successful response.
+func (s *clientState) updateRateLimits(header http.Header) {
+	rateLimits := RateLimitInfo{}
+	if l := strings.Split(header.Get("X-Shopify-Shop-Api-Call-Limit"), "/"); len(l) == 2 {
+		rateLimits.RequestCount, _ = strconv.Atoi(l[0])
+		rateLimits.BucketSize, _ = strconv.Atoi(l[1])
+	}
+
+	rateLimits.RetryAfterSeconds, _ = strconv.ParseFloat(header.Get("Retry-After"), 64)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.rateLimits = rateLimits
+}
+
+// RateLimits returns a snapshot of the rate limit information of the last
+// successful response.
+func (c *Client) RateLimits() RateLimitInfo {
+	c.state.mu.RLock()
+	defer c.state.mu.RUnlock()
+
+	return c.state.rateLimits
+}
+
+// APIVersion returns the version of the api used by the client. When the
+// client was created without a version, it is "stable" until the version
+// is reported by the first response.
+func (c *Client) APIVersion() string {
+	c.state.mu.RLock()
+	defer c.state.mu.RUnlock()
+
+	return c.state.apiVersion
+}
+
+// waitToRetry waits before sending req again and rewinds its body.
+func (c *Client) waitToRetry(req *http.Request, wait time.Duration) error {
+	if err := sleepContext(req.Context(), wait); err != nil {
+		return err
+	}
+
+	if req.GetBody == nil {
+		return nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return err
+	}
+	req.Body = body
+
+	return nil
+}
+
+// isGraphQLRequest reports whether req goes to the GraphQL endpoint, which is
+// throttled by query cost instead of the REST API bucket.
+func isGraphQLRequest(req *http.Request) bool {
+	return strings.HasSuffix(req.URL.Path, "/"+graphQLPath)
+}
+
+// sleepContext pauses for the given duration, returning early with the
+// context's error if it is done before the duration has elapsed.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// debugEnabled reports whether the logger of the client emits debug messages.
+// Loggers that don't implement LevelEnabler are assumed to.
+func (c *Client) debugEnabled() bool {
+	if l, ok := c.log.(LevelEnabler); ok {
+		return l.Enabled(LevelDebug)
+	}
+	return true
 }
 
 func (c *Client) logRequest(req *http.Request) {
@@ -411,7 +749,10 @@ func (c *Client) logRequest(req *http.Request) {
 		return
 	}
 	if req.URL != nil {
-		c.log.Debugf("%s: %s", req.Method, req.URL.String())
+		c.log.Debugf("%s: %s", req.Method, c.redactor.url(req.URL))
+	}
+	if len(req.Header) > 0 && c.debugEnabled() {
+		c.log.Debugf("HEADERS: %s", c.redactor.header(req.Header))
 	}
 	c.logBody(&req.Body, "SENT: %s")
 }
@@ -424,18 +765,85 @@ func (c *Client) logResponse(res *http.Response) {
 	c.logBody(&res.Body, "RESP: %s")
 }
 
+// logAttempt logs the outcome of an attempt to send req, at debug level when
+// it succeeded and at warn level otherwise.
+func (c *Client) logAttempt(req *http.Request, resp *http.Response, err error, attempt int, duration time.Duration) {
+	level := LevelDebug
+	attrs := []slog.Attr{
+		slog.String("shop", req.URL.Host),
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.Path),
+	}
+
+	if resp != nil {
+		if resp.StatusCode >= http.StatusBadRequest {
+			level = LevelWarn
+		}
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+	}
+	if err != nil {
+		level = LevelWarn
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	if level == LevelDebug && !c.debugEnabled() {
+		return
+	}
+
+	attrs = append(attrs,
+		slog.Duration("duration", duration),
+		slog.Int("attempt", attempt),
+	)
+	if resp != nil {
+		if id := resp.Header.Get("X-Request-Id"); id != "" {
+			attrs = append(attrs, slog.String("request_id", id))
+		}
+	}
+
+	c.logAttrs(req.Context(), level, "shopify request", attrs...)
+}
+
+// logAttrs logs msg with attrs through the logger of the client, formatting
+// the attributes as key=value pairs for loggers that aren't AttrLoggers.
+func (c *Client) logAttrs(ctx context.Context, level int, msg string, attrs ...slog.Attr) {
+	if l, ok := c.log.(AttrLogger); ok {
+		l.LogAttrs(ctx, level, msg, attrs...)
+		return
+	}
+
+	var b strings.Builder
+	b.WriteString(msg)
+	for _, attr := range attrs {
+		b.WriteString(" ")
+		b.WriteString(attr.String())
+	}
+
+	switch level {
+	case LevelError:
+		c.log.Errorf("%s", b.String())
+	case LevelWarn:
+		c.log.Warnf("%s", b.String())
+	case LevelInfo:
+		c.log.Infof("%s", b.String())
+	default:
+		c.log.Debugf("%s", b.String())
+	}
+}
+
+// logBody logs the given body at debug level with its secrets and personal
+// data masked. The body is buffered to be logged and replaced, so it is left
+// untouched when debug logging is off.
 func (c *Client) logBody(body *io.ReadCloser, format string) {
-	if body == nil {
+	if body == nil || *body == nil || !c.debugEnabled() {
 		return
 	}
 	b, _ := io.ReadAll(*body)
 	if len(b) > 0 {
-		c.log.Debugf(format, string(b))
+		c.log.Debugf(format, c.redactor.body(b))
 	}
 	*body = io.NopCloser(bytes.NewBuffer(b))
 }
 
-func wrapSpecificError(r *http.Response, err ResponseError) error {
+func wrapSpecificError(r *http.Response, err ResponseError, fields map[string][]string) error {
 	// see https://www.shopify.dev/concepts/about-apis/response-codes
 	if err.Status == http.StatusTooManyRequests {
 		f, err1 := strconv.ParseFloat(r.Header.Get("Retry-After"), 64)
@@ -448,16 +856,17 @@ func wrapSpecificError(r *http.Response, err ResponseError) error {
 		}
 	}
 
-	// if err.Status == http.StatusSeeOther {
-	// todo
-	// The response to the request can be found under a different URL in the
-	// Location header and can be retrieved using a GET method on that resource.
-	// }
-
 	if err.Status == http.StatusNotAcceptable {
 		err.Message = http.StatusText(err.Status)
 	}
 
+	if err.Status == http.StatusUnprocessableEntity {
+		return ValidationError{
+			ResponseError: err,
+			Fields:        fields,
+		}
+	}
+
 	return err
 }
 
@@ -473,8 +882,6 @@ func CheckResponseError(r *http.Response) error {
 	}{}
 
 	bodyBytes, err := io.ReadAll(r.Body)
-	log.Println("r.Headers from shopify ", r.Header)
-	log.Println("bodyBytes from shopify ", string(bodyBytes))
 	if err != nil {
 		return err
 	}
@@ -484,15 +891,18 @@ func CheckResponseError(r *http.Response) error {
 	if len(bodyBytes) > 0 {
 		err := json.Unmarshal(bodyBytes, &shopifyError)
 		if err != nil {
-			status := r.StatusCode
-			if r.StatusCode == 400 {
-				status = 429
-				r.Header.Set("Retry-After", "2ß.0")
+			// not a Shopify error, e.g. the html or plain text page of a proxy.
+			// Rate limits are still reported as such so they're retried.
+			if r.StatusCode == http.StatusTooManyRequests {
+				return wrapSpecificError(r, ResponseError{
+					Status:  r.StatusCode,
+					Message: http.StatusText(r.StatusCode),
+				}, nil)
 			}
 			return ResponseDecodingError{
 				Body:    bodyBytes,
 				Message: err.Error(),
-				Status:  status,
+				Status:  r.StatusCode,
 			}
 		}
 	}
@@ -505,9 +915,12 @@ func CheckResponseError(r *http.Response) error {
 
 	// If the errors field is not filled out, we can return here.
 	if shopifyError.Errors == nil {
-		return wrapSpecificError(r, responseError)
+		return wrapSpecificError(r, responseError, nil)
 	}
 
+	// the messages of every field when errors are reported by field
+	var fields map[string][]string
+
 	// Shopify errors usually have the form:
 	// {
 	//   "errors": {
@@ -518,6 +931,7 @@ func CheckResponseError(r *http.Response) error {
 	// }
 	// This structure is flattened to a single array:
 	// [ "title: something is wrong" ]
+	// and kept by field for validation errors.
 	//
 	// Unfortunately, "errors" can also be a single string so we have to deal
 	// with that. Lots of reflection :-(
@@ -535,6 +949,7 @@ func CheckResponseError(r *http.Response) error {
 	case reflect.Map:
 		// A map, parse each error for each key in the map.
 		// json always serializes into map[string]interface{} for objects
+		fields = map[string][]string{}
 		for k, v := range shopifyError.Errors.(map[string]interface{}) {
 			switch reflect.TypeOf(v).Kind() {
 			// Check to make sure the interface is a slice
@@ -548,6 +963,7 @@ func CheckResponseError(r *http.Response) error {
 					}
 					topicAndElem := fmt.Sprintf("%v: %v", k, elem)
 					responseError.Errors = append(responseError.Errors, topicAndElem)
+					fields[k] = append(fields[k], fmt.Sprint(elem))
 				}
 			case reflect.String:
 				elem := v.(string)
@@ -556,11 +972,12 @@ func CheckResponseError(r *http.Response) error {
 				}
 				topicAndElem := fmt.Sprintf("%v: %v", k, elem)
 				responseError.Errors = append(responseError.Errors, topicAndElem)
+				fields[k] = append(fields[k], elem)
 			}
 		}
 	}
 
-	return wrapSpecificError(r, responseError)
+	return wrapSpecificError(r, responseError, fields)
 }
 
 // General list options that can be used for most collections of entities.
@@ -593,11 +1010,17 @@ type CountOptions struct {
 	UpdatedAtMax time.Time `url:"updated_at_max,omitempty"`
 }
 
+// Count performs a GET request for the given count path and returns the count.
 func (c *Client) Count(path string, options interface{}) (int, error) {
+	return c.CountContext(c.context(), path, options)
+}
+
+// CountContext performs a Count with the given context.
+func (c *Client) CountContext(ctx context.Context, path string, options interface{}) (int, error) {
 	resource := struct {
 		Count int `json:"count"`
 	}{}
-	err := c.Get(path, &resource, options, true)
+	err := c.GetContext(ctx, path, &resource, options, true)
 	return resource.Count, err
 }
 
@@ -611,7 +1034,12 @@ func (c *Client) Count(path string, options interface{}) (int, error) {
 // parameters like created_at_min.
 // Any data returned from Shopify will be marshalled into the resource argument.
 func (c *Client) PerformShopifyRequest(method, relPath string, data, options, resource interface{}, needAPIVersion bool) error {
-	_, err := c.ProcessShopifyRequestWithHeader(method, relPath, data, options, resource, needAPIVersion)
+	return c.PerformShopifyRequestContext(c.context(), method, relPath, data, options, resource, needAPIVersion)
+}
+
+// PerformShopifyRequestContext performs a PerformShopifyRequest with the given context.
+func (c *Client) PerformShopifyRequestContext(ctx context.Context, method, relPath string, data, options, resource interface{}, needAPIVersion bool) error {
+	_, err := c.ProcessShopifyRequestWithHeaderContext(ctx, method, relPath, data, options, resource, needAPIVersion)
 	if err != nil {
 		return err
 	}
@@ -620,13 +1048,18 @@ func (c *Client) PerformShopifyRequest(method, relPath string, data, options, re
 
 // ProcessShopifyRequestWithHeader creates an executes a request while returning the response headers.
 func (c *Client) ProcessShopifyRequestWithHeader(method, relPath string, data, options, resource interface{}, needVersion bool) (http.Header, error) {
+	return c.ProcessShopifyRequestWithHeaderContext(c.context(), method, relPath, data, options, resource, needVersion)
+}
+
+// ProcessShopifyRequestWithHeaderContext performs a ProcessShopifyRequestWithHeader with the given context.
+func (c *Client) ProcessShopifyRequestWithHeaderContext(ctx context.Context, method, relPath string, data, options, resource interface{}, needVersion bool) (http.Header, error) {
 	if strings.HasPrefix(relPath, "/") {
 		relPath = strings.TrimLeft(relPath, "/")
 	}
 
 	pathPrefix := findPathPrefix(c.pathPrefix, needVersion)
 	relPath = path.Join(pathPrefix, relPath)
-	req, err := c.NewRequest(method, relPath, data, options)
+	req, err := c.NewRequestWithContext(ctx, method, relPath, data, options)
 	if err != nil {
 		return nil, err
 	}
@@ -646,13 +1079,23 @@ func findPathPrefix(pathPrefix string, versionNeeded bool) string {
 // Get performs a GET request for the given path and saves the result in the
 // given resource.
 func (c *Client) Get(path string, resource, options interface{}, needApiVersion bool) error {
-	return c.PerformShopifyRequest("GET", path, nil, options, resource, needApiVersion)
+	return c.GetContext(c.context(), path, resource, options, needApiVersion)
+}
+
+// GetContext performs a Get with the given context.
+func (c *Client) GetContext(ctx context.Context, path string, resource, options interface{}, needApiVersion bool) error {
+	return c.PerformShopifyRequestContext(ctx, "GET", path, nil, options, resource, needApiVersion)
 }
 
 // ListWithPagination performs a GET request for the given path and saves the result in the
 // given resource and returns the pagination.
 func (c *Client) ListWithPagination(path string, resource, options interface{}) (*Pagination, error) {
-	headers, err := c.ProcessShopifyRequestWithHeader("GET", path, nil, options, resource, true)
+	return c.ListWithPaginationContext(c.context(), path, resource, options)
+}
+
+// ListWithPaginationContext performs a ListWithPagination with the given context.
+func (c *Client) ListWithPaginationContext(ctx context.Context, path string, resource, options interface{}) (*Pagination, error) {
+	headers, err := c.ProcessShopifyRequestWithHeaderContext(ctx, "GET", path, nil, options, resource, true)
 	if err != nil {
 		return nil, err
 	}
@@ -736,12 +1179,22 @@ func extractPagination(linkHeader string) (*Pagination, error) {
 // Post performs a POST request for the given path and saves the result in the
 // given resource.
 func (c *Client) Post(path string, data, resource interface{}) error {
-	return c.PerformShopifyRequest("POST", path, data, nil, resource, true)
+	return c.PostContext(c.context(), path, data, resource)
+}
+
+// PostContext performs a Post with the given context.
+func (c *Client) PostContext(ctx context.Context, path string, data, resource interface{}) error {
+	return c.PerformShopifyRequestContext(ctx, "POST", path, data, nil, resource, true)
 }
 
 // Put performs a PUT request for the given url and  data and return if there is any error
 func (c *Client) Put(path string, data, resource interface{}) error {
-	return c.PerformShopifyRequest("PUT", path, data, nil, resource, true)
+	return c.PutContext(c.context(), path, data, resource)
+}
+
+// PutContext performs a Put with the given context.
+func (c *Client) PutContext(ctx context.Context, path string, data, resource interface{}) error {
+	return c.PerformShopifyRequestContext(ctx, "PUT", path, data, nil, resource, true)
 }
 
 // Delete performs a DELETE request for the given path
@@ -749,7 +1202,17 @@ func (c *Client) Delete(path string) error {
 	return c.DeleteWithOptions(path, nil)
 }
 
+// DeleteContext performs a Delete with the given context.
+func (c *Client) DeleteContext(ctx context.Context, path string) error {
+	return c.DeleteWithOptionsContext(ctx, path, nil)
+}
+
 // DeleteWithOptions performs a DELETE request for the given path WithOptions
 func (c *Client) DeleteWithOptions(path string, options interface{}) error {
-	return c.PerformShopifyRequest("DELETE", path, nil, options, nil, true)
+	return c.DeleteWithOptionsContext(c.context(), path, options)
+}
+
+// DeleteWithOptionsContext performs a DeleteWithOptions with the given context.
+func (c *Client) DeleteWithOptionsContext(ctx context.Context, path string, options interface{}) error {
+	return c.PerformShopifyRequestContext(ctx, "DELETE", path, nil, options, nil, true)
 }
@@ -1,6 +1,7 @@
 package synergyshopify
 
 import (
+	"context"
 	"crypto/tls"
 	"encoding/json"
 	"errors"
@@ -12,6 +13,7 @@ import (
 	"os"
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -340,6 +342,7 @@ func TestDo(t *testing.T) {
 
 		err = client.ProcessRequest(req, body)
 		if err != nil {
+			err = unwrapRequestError(t, err, "GET", "/"+c.url)
 			if e, ok := err.(*url.Error); ok {
 				err = e.Err
 			} else if e, ok := err.(*json.SyntaxError); ok {
@@ -355,6 +358,24 @@ func TestDo(t *testing.T) {
 	}
 }
 
+// unwrapRequestError checks that err is a RequestError describing a request
+// with the given method and path, and returns the error it wraps.
+func unwrapRequestError(t *testing.T, err error, method, path string) error {
+	t.Helper()
+
+	requestErr, ok := err.(RequestError)
+	if !ok {
+		t.Errorf("expected a RequestError, actual %#v", err)
+		return err
+	}
+
+	if requestErr.Method != method || requestErr.Path != path || requestErr.APIVersion != testApiVersion {
+		t.Errorf("RequestError expected %s %s with api version %s, actual %#v", method, path, testApiVersion, requestErr)
+	}
+
+	return requestErr.Err
+}
+
 func TestRetry(t *testing.T) {
 	setup()
 	defer teardown()
@@ -445,13 +466,14 @@ func TestRetry(t *testing.T) {
 			t.Error("error creating request: ", err)
 		}
 
-		err = client.ProcessRequest(req, body)
+		resp, err := client.Do(req, body)
 
-		if client.attempts != c.retries {
-			t.Errorf("Do(): attempts do not match retries %#v, actual %#v", client.attempts, c.retries)
+		if resp.Attempts != c.retries {
+			t.Errorf("Do(): attempts do not match retries %#v, actual %#v", resp.Attempts, c.retries)
 		}
 
 		if err != nil {
+			err = unwrapRequestError(t, err, "GET", "/"+c.relPath)
 			if e, ok := err.(*url.Error); ok {
 				err = e.Err
 			} else if e, ok := err.(*json.SyntaxError); ok {
@@ -491,10 +513,10 @@ func TestClientDoAutoApiVersion(t *testing.T) {
 		t.Errorf("TestClientDoApiVersion(): errored %s", err)
 	}
 
-	if expected != testClient.apiVersion {
+	if expected != testClient.APIVersion() {
 		t.Errorf(
 			"TestClientDoApiVersion(): client unable to get API Version from X-Shopify-API-Version: expected %s received %s",
-			expected, testClient.apiVersion)
+			expected, testClient.APIVersion())
 	}
 }
 
@@ -899,8 +921,8 @@ func TestDoRateLimit(t *testing.T) {
 				if !reflect.DeepEqual(err, c.expected) {
 					t.Errorf("Do(): expected error %#v, actual %#v", c.expected, err)
 				}
-			} else if err == nil && !reflect.DeepEqual(client.RateLimits, c.expected) {
-				t.Errorf("%s: expected %#v, actual %#v", c.description, c.expected, client.RateLimits)
+			} else if err == nil && !reflect.DeepEqual(client.RateLimits(), c.expected) {
+				t.Errorf("%s: expected %#v, actual %#v", c.description, c.expected, client.RateLimits())
 			}
 		})
 	}
@@ -942,3 +964,144 @@ func TestListWithPagination(t *testing.T) {
 		t.Fatalf("Expected prev page: %s   got: %s", "123", pagination.PreviousPageOptions.PageInfo)
 	}
 }
+
+func TestNewRequestWithContext(t *testing.T) {
+	testClient := NewClient(app, "fooshop", "abcd", WithVersion(testApiVersion))
+
+	type ctxKey struct{}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "bar")
+
+	req, err := testClient.NewRequestWithContext(ctx, "GET", "foo", nil, nil)
+	if err != nil {
+		t.Fatalf("NewRequestWithContext() err = %v, expected nil", err)
+	}
+
+	if req.Context().Value(ctxKey{}) != "bar" {
+		t.Errorf("NewRequestWithContext() context was not attached to the request")
+	}
+
+	req, err = testClient.NewRequest("GET", "foo", nil, nil)
+	if err != nil {
+		t.Fatalf("NewRequest() err = %v, expected nil", err)
+	}
+
+	if req.Context() != context.Background() {
+		t.Errorf("NewRequest() expected background context, actual %v", req.Context())
+	}
+}
+
+func TestWithContext(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/foo/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"foo": "bar"}`))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ctxClient := client.WithContext(ctx)
+
+	if ctxClient == client {
+		t.Fatal("WithContext() expected a copy of the client")
+	}
+
+	if ctxClient.Product.(*ProductServiceOp).client != ctxClient {
+		t.Error("WithContext() services should point at the new client")
+	}
+
+	if client.Product.(*ProductServiceOp).client != client {
+		t.Error("WithContext() services of the original client should be untouched")
+	}
+
+	var resource struct {
+		Foo string `json:"foo"`
+	}
+	if err := ctxClient.Get("foo/1.json", &resource, nil, true); err != nil {
+		t.Errorf("Client.Get returned error: %v", err)
+	}
+
+	cancel()
+
+	err := ctxClient.Get("foo/1.json", &resource, nil, true)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Client.Get with canceled context expected %v, actual %v", context.Canceled, err)
+	}
+
+	err = client.GetContext(ctx, "foo/1.json", &resource, nil, true)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Client.GetContext with canceled context expected %v, actual %v", context.Canceled, err)
+	}
+
+	if err := client.Get("foo/1.json", &resource, nil, true); err != nil {
+		t.Errorf("Client.Get on the original client returned error: %v", err)
+	}
+}
+
+func TestRetryContextCanceled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/foo/1",
+		func(req *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(http.StatusTooManyRequests, `{"errors":"Exceeded 2 calls per second for api client."}`)
+			resp.Header.Add("Retry-After", "10.0")
+			return resp, nil
+		})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	req, err := client.NewRequestWithContext(ctx, "GET", "foo/1", nil, nil)
+	if err != nil {
+		t.Fatal("error creating request: ", err)
+	}
+
+	start := time.Now()
+	err = client.ProcessRequest(req, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("ProcessRequest() expected %v, actual %v", context.DeadlineExceeded, err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("ProcessRequest() did not stop waiting on context deadline, took %s", elapsed)
+	}
+}
+
+func TestClientConcurrentUse(t *testing.T) {
+	testClient := NewClient(app, "fooshop", "abcd", WithRetry(maxRetries))
+	httpmock.ActivateNonDefault(testClient.Client)
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/admin/api/2024-01/foo.json",
+		createResponderWithHeaders(200, `{"foo": "bar"}`, map[string]string{
+			"X-Shopify-Shop-Api-Call-Limit": "15/40",
+			"X-Shopify-API-Version":         testApiVersion,
+		}))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(c *Client) {
+			defer wg.Done()
+
+			var resource struct {
+				Foo string `json:"foo"`
+			}
+			if err := c.Get("foo.json", &resource, nil, true); err != nil {
+				t.Errorf("Client.Get returned error: %v", err)
+			}
+			_ = c.RateLimits()
+			_ = c.APIVersion()
+		}(testClient.WithContext(context.Background()))
+	}
+	wg.Wait()
+
+	if testClient.APIVersion() != testApiVersion {
+		t.Errorf("Client.APIVersion() = %s, expected %s", testClient.APIVersion(), testApiVersion)
+	}
+
+	expected := RateLimitInfo{RequestCount: 15, BucketSize: 40}
+	if testClient.RateLimits() != expected {
+		t.Errorf("Client.RateLimits() = %#v, expected %#v", testClient.RateLimits(), expected)
+	}
+}
@@ -16,6 +16,10 @@ type DiscountCodeService interface {
 	List(int64) ([]PriceRuleDiscountCode, error)
 	Get(int64, int64) (*PriceRuleDiscountCode, error)
 	Delete(int64, int64) error
+	Lookup(string) (*PriceRuleDiscountCode, error)
+	CreateBatch(int64, []PriceRuleDiscountCode) (*DiscountCodeCreation, error)
+	GetBatch(int64, int64) (*DiscountCodeCreation, error)
+	WaitBatch(int64, int64) (*DiscountCodeCreation, error)
 }
 
 // DiscountCodeServiceOp handles communication with the discount code
@@ -44,6 +48,30 @@ type DiscountCodeResource struct {
 	PriceRuleDiscountCode *PriceRuleDiscountCode `json:"discount_code"`
 }
 
+// DiscountCodeCreation represents a job creating discount codes in bulk
+type DiscountCodeCreation struct {
+	ID            int64      `json:"id,omitempty"`
+	PriceRuleID   int64      `json:"price_rule_id,omitempty"`
+	Status        string     `json:"status,omitempty"`
+	CodesCount    int        `json:"codes_count,omitempty"`
+	ImportedCount int        `json:"imported_count,omitempty"`
+	FailedCount   int        `json:"failed_count,omitempty"`
+	StartedAt     *time.Time `json:"started_at,omitempty"`
+	CompletedAt   *time.Time `json:"completed_at,omitempty"`
+	CreatedAt     *time.Time `json:"created_at,omitempty"`
+	UpdatedAt     *time.Time `json:"updated_at,omitempty"`
+}
+
+// DiscountCodeCreationResource represents the result from the batch endpoints
+type DiscountCodeCreationResource struct {
+	DiscountCodeCreation *DiscountCodeCreation `json:"discount_code_creation"`
+}
+
+// discountCodeLookupOptions are the options of the lookup endpoint
+type discountCodeLookupOptions struct {
+	Code string `url:"code"`
+}
+
 // Create a discount code
 func (s *DiscountCodeServiceOp) Create(priceRuleID int64, dc PriceRuleDiscountCode) (*PriceRuleDiscountCode, error) {
 	path := fmt.Sprintf(discountCodeBasePath+".json", priceRuleID)
@@ -82,3 +110,38 @@ func (s *DiscountCodeServiceOp) Get(priceRuleID int64, discountCodeID int64) (*P
 func (s *DiscountCodeServiceOp) Delete(priceRuleID int64, discountCodeID int64) error {
 	return s.client.Delete(fmt.Sprintf(discountCodeBasePath+"/%d.json", priceRuleID, discountCodeID))
 }
+
+// Lookup the discount code with the given code. Shopify answers with a 303
+// See Other to the discount code, which is followed.
+func (s *DiscountCodeServiceOp) Lookup(code string) (*PriceRuleDiscountCode, error) {
+	resource := new(DiscountCodeResource)
+	err := s.client.Get("discount_codes/lookup.json", resource, discountCodeLookupOptions{Code: code}, true)
+	return resource.PriceRuleDiscountCode, err
+}
+
+// CreateBatch starts a job creating up to 100 discount codes for a price rule
+func (s *DiscountCodeServiceOp) CreateBatch(priceRuleID int64, codes []PriceRuleDiscountCode) (*DiscountCodeCreation, error) {
+	path := fmt.Sprintf("price_rules/%d/batch.json", priceRuleID)
+	wrappedData := DiscountCodesResource{DiscountCodes: codes}
+	resource := new(DiscountCodeCreationResource)
+	err := s.client.Post(path, wrappedData, resource)
+	return resource.DiscountCodeCreation, err
+}
+
+// GetBatch gets the status of a discount code creation job
+func (s *DiscountCodeServiceOp) GetBatch(priceRuleID int64, batchID int64) (*DiscountCodeCreation, error) {
+	path := fmt.Sprintf("price_rules/%d/batch/%d.json", priceRuleID, batchID)
+	resource := new(DiscountCodeCreationResource)
+	err := s.client.Get(path, resource, nil, true)
+	return resource.DiscountCodeCreation, err
+}
+
+// WaitBatch polls a discount code creation job until it is completed
+func (s *DiscountCodeServiceOp) WaitBatch(priceRuleID int64, batchID int64) (*DiscountCodeCreation, error) {
+	path := fmt.Sprintf("price_rules/%d/batch/%d.json", priceRuleID, batchID)
+	resource := new(DiscountCodeCreationResource)
+	err := s.client.Poll(path, resource, func(*Response) bool {
+		return resource.DiscountCodeCreation != nil && resource.DiscountCodeCreation.Status == "completed"
+	})
+	return resource.DiscountCodeCreation, err
+}
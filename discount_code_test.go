@@ -2,6 +2,7 @@ package synergyshopify
 
 import (
 	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/jarcoal/httpmock"
@@ -125,3 +126,90 @@ func TestDiscountCodeDelete(t *testing.T) {
 		t.Errorf("DiscountCode.Delete returned error: %v", err)
 	}
 }
+
+func TestDiscountCodeLookup(t *testing.T) {
+	setup()
+	defer teardown()
+
+	location := fmt.Sprintf("https://fooshop.myshopify.com/%s/price_rules/507328175/discount_codes/507328175.json", client.pathPrefix)
+	httpmock.RegisterResponder(
+		"GET",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/discount_codes/lookup.json?code=SUMMERSALE10OFF", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(303, "")
+			resp.Header.Set("Location", location)
+			return resp, nil
+		},
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		location,
+		httpmock.NewStringResponder(
+			200,
+			`{"discount_code":{"id":507328175,"price_rule_id":507328175,"code":"SUMMERSALE10OFF","usage_count":0,"created_at":"2018-07-05T12:41:00-04:00","updated_at":"2018-07-05T12:41:00-04:00"}}`,
+		),
+	)
+
+	dc, err := client.DiscountCode.Lookup("SUMMERSALE10OFF")
+	if err != nil {
+		t.Fatalf("DiscountCode.Lookup returned error: %v", err)
+	}
+
+	expected := &PriceRuleDiscountCode{ID: 507328175, Code: "SUMMERSALE10OFF"}
+	if dc.ID != expected.ID || dc.Code != expected.Code {
+		t.Errorf("DiscountCode.Lookup returned %+v, expected %+v", dc, expected)
+	}
+}
+
+func TestDiscountCodeCreateBatch(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder(
+		"POST",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/price_rules/507328175/batch.json", client.pathPrefix),
+		httpmock.NewStringResponder(
+			201,
+			`{"discount_code_creation":{"id":989355119,"price_rule_id":507328175,"status":"queued","codes_count":3,"imported_count":0,"failed_count":0}}`,
+		),
+	)
+
+	job, err := client.DiscountCode.CreateBatch(507328175, []PriceRuleDiscountCode{{Code: "A"}, {Code: "B"}, {Code: "C"}})
+	if err != nil {
+		t.Fatalf("DiscountCode.CreateBatch returned error: %v", err)
+	}
+
+	if job.ID != 989355119 || job.Status != "queued" || job.CodesCount != 3 {
+		t.Errorf("DiscountCode.CreateBatch returned %+v", job)
+	}
+}
+
+func TestDiscountCodeWaitBatch(t *testing.T) {
+	setup()
+	defer teardown()
+
+	polls := 0
+	httpmock.RegisterResponder(
+		"GET",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/price_rules/507328175/batch/989355119.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			polls++
+			status := "running"
+			if polls == 3 {
+				status = "completed"
+			}
+			resp := httpmock.NewStringResponse(200, fmt.Sprintf(`{"discount_code_creation":{"id":989355119,"status":%q,"imported_count":3}}`, status))
+			resp.Header.Set("Retry-After", "0.01")
+			return resp, nil
+		},
+	)
+
+	job, err := client.DiscountCode.WaitBatch(507328175, 989355119)
+	if err != nil {
+		t.Fatalf("DiscountCode.WaitBatch returned error: %v", err)
+	}
+
+	if job.Status != "completed" || polls != 3 {
+		t.Errorf("DiscountCode.WaitBatch returned %+v after %d polls, expected completed after 3", job, polls)
+	}
+}
@@ -17,6 +17,7 @@ const (
 // See: https://help.shopify.com/api/reference/orders/draftorder
 type DraftOrderService interface {
 	List(interface{}) ([]DraftOrder, error)
+	ListWithPagination(interface{}) ([]DraftOrder, *Pagination, error)
 	Count(interface{}) (int, error)
 	Get(int64, interface{}) (*DraftOrder, error)
 	Create(DraftOrder) (*DraftOrder, error)
@@ -133,10 +134,24 @@ func (s *DraftOrderServiceOp) Create(draftOrder DraftOrder) (*DraftOrder, error)
 
 // List draft orders
 func (s *DraftOrderServiceOp) List(options interface{}) ([]DraftOrder, error) {
+	draftOrders, _, err := s.ListWithPagination(options)
+	if err != nil {
+		return nil, err
+	}
+	return draftOrders, nil
+}
+
+// ListWithPagination lists draft orders and return pagination to retrieve next/previous results.
+func (s *DraftOrderServiceOp) ListWithPagination(options interface{}) ([]DraftOrder, *Pagination, error) {
 	path := fmt.Sprintf("%s.json", draftOrdersBasePath)
 	resource := new(DraftOrdersResource)
-	err := s.client.Get(path, resource, options, true)
-	return resource.DraftOrders, err
+
+	pagination, err := s.client.ListWithPagination(path, resource, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resource.DraftOrders, pagination, nil
 }
 
 // Count draft orders
@@ -0,0 +1,219 @@
+package synergyshopify
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Sentinel errors classifying the errors returned by the client by the status
+// of the response, to be used with errors.Is, e.g.
+//
+//	_, err := client.Product.Get(id, nil)
+//	if errors.Is(err, ErrNotFound) {
+//		...
+//	}
+//
+// The details of the response remain available with errors.As on
+// ResponseError, RateLimitError, ValidationError or ResponseDecodingError.
+var (
+	// ErrUnauthorized matches 401 responses, e.g. an invalid access token.
+	ErrUnauthorized = errors.New("unauthorized")
+
+	// ErrForbidden matches 403 responses, e.g. a missing access scope.
+	ErrForbidden = errors.New("forbidden")
+
+	// ErrNotFound matches 404 responses.
+	ErrNotFound = errors.New("not found")
+
+	// ErrConflict matches 409 responses.
+	ErrConflict = errors.New("conflict")
+
+	// ErrValidation matches 422 responses and GraphQL user errors.
+	ErrValidation = errors.New("validation failed")
+
+	// ErrRateLimited matches 429 responses.
+	ErrRateLimited = errors.New("rate limited")
+
+	// ErrServerError matches 5xx responses.
+	ErrServerError = errors.New("server error")
+)
+
+// statusError returns the sentinel error matching the given response status,
+// if any.
+func statusError(status int) error {
+	switch status {
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusForbidden:
+		return ErrForbidden
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusConflict:
+		return ErrConflict
+	case http.StatusUnprocessableEntity:
+		return ErrValidation
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	}
+
+	if status >= http.StatusInternalServerError {
+		return ErrServerError
+	}
+
+	return nil
+}
+
+// IsTransient reports whether err is a failure that may go away when the
+// request is sent again: rate limits, timeouts, 500, 502, 503 and 504
+// responses, including the html error pages of proxies, and network errors.
+// Other errors, such as validation errors, missing scopes, canceled contexts
+// or invalid certificates, are permanent.
+//
+// Requests that timed out, e.g. past the Timeout of the http.Client, are
+// transient even though their error matches context.DeadlineExceeded: whether
+// the context of the caller is done is for the caller to check.
+func IsTransient(err error) bool {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) && urlErr.Timeout() {
+		return true
+	}
+
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	switch errorStatus(err) {
+	case 0:
+		// no response, e.g. a network error
+	case http.StatusRequestTimeout, http.StatusTooManyRequests, http.StatusInternalServerError,
+		http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+
+	var certErr *tls.CertificateVerificationError
+	var unknownAuthorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var invalidCertErr x509.CertificateInvalidError
+	if errors.As(err, &certErr) || errors.As(err, &unknownAuthorityErr) ||
+		errors.As(err, &hostnameErr) || errors.As(err, &invalidCertErr) {
+		return false
+	}
+
+	// bodies that can't be decoded won't change
+	var decodingErr ResponseDecodingError
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &decodingErr) || errors.As(err, &syntaxErr) || errors.As(err, &typeErr) {
+		return false
+	}
+
+	// GraphQL errors come with a 200 response, throttling is retried by Query
+	var graphQLErr GraphQLResponseError
+	var userErrs UserErrorsError
+	if errors.As(err, &graphQLErr) || errors.As(err, &userErrs) {
+		return false
+	}
+
+	return true
+}
+
+// Is reports whether the status of the response matches target, one of the
+// sentinel errors such as ErrNotFound.
+func (e ResponseError) Is(target error) bool {
+	sentinel := statusError(e.Status)
+	return sentinel != nil && sentinel == target
+}
+
+// Is reports whether the status of the response matches target, one of the
+// sentinel errors such as ErrServerError.
+func (e ResponseDecodingError) Is(target error) bool {
+	sentinel := statusError(e.Status)
+	return sentinel != nil && sentinel == target
+}
+
+// Unwrap returns the underlying ResponseError so it can be retrieved with
+// errors.As.
+func (e RateLimitError) Unwrap() error {
+	return e.ResponseError
+}
+
+// ValidationError is returned for 422 responses. Fields maps the invalid
+// fields to their messages, e.g.
+//
+//	{"title": ["can't be blank"], "handle": ["has already been taken"]}
+//
+// when Shopify reports the errors by field.
+type ValidationError struct {
+	ResponseError
+	Fields map[string][]string
+}
+
+// Unwrap returns the underlying ResponseError so it can be retrieved with
+// errors.As.
+func (e ValidationError) Unwrap() error {
+	return e.ResponseError
+}
+
+// Is reports whether target is ErrValidation.
+func (e UserErrorsError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// RequestError is returned by ProcessRequestWithHeaders and Do for any failed
+// request. It wraps the error of the last attempt, which remains available
+// with errors.Is and errors.As, and describes the request to ease reporting
+// the failure to Shopify support.
+type RequestError struct {
+	// Method and Path of the request. The path never includes the query
+	// string nor credentials.
+	Method string
+	Path   string
+
+	// RequestID is the X-Request-Id header of the last response, if any.
+	RequestID string
+
+	// APIVersion is the version of the api used for the request.
+	APIVersion string
+
+	// Attempts is the number of times the request was sent.
+	Attempts int
+
+	Err error
+}
+
+func (e RequestError) Error() string {
+	var details []string
+	if e.Method != "" || e.Path != "" {
+		details = append(details, strings.TrimSpace(e.Method+" "+e.Path))
+	}
+	if e.RequestID != "" {
+		details = append(details, "request id "+e.RequestID)
+	}
+	if e.APIVersion != "" {
+		details = append(details, "api version "+e.APIVersion)
+	}
+	if e.Attempts == 1 {
+		details = append(details, "1 attempt")
+	} else if e.Attempts > 1 {
+		details = append(details, fmt.Sprintf("%d attempts", e.Attempts))
+	}
+
+	if len(details) == 0 {
+		return e.Err.Error()
+	}
+
+	return fmt.Sprintf("%s (%s)", e.Err, strings.Join(details, ", "))
+}
+
+func (e RequestError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,278 @@
+package synergyshopify
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestErrorSentinels(t *testing.T) {
+	sentinels := []error{
+		ErrUnauthorized, ErrForbidden, ErrNotFound, ErrConflict,
+		ErrValidation, ErrRateLimited, ErrServerError,
+	}
+
+	cases := []struct {
+		status   int
+		body     string
+		expected error
+	}{
+		{400, `{"errors": {"order": "Required parameter missing"}}`, nil},
+		{401, `{"errors": "[API] Invalid API key or access token"}`, ErrUnauthorized},
+		{403, `{"errors": "This action requires merchant approval for read_customers scope."}`, ErrForbidden},
+		{404, `{"errors": "Not Found"}`, ErrNotFound},
+		{409, `{"errors": "conflict"}`, ErrConflict},
+		{422, `{"errors": {"title": ["can't be blank"]}}`, ErrValidation},
+		{429, `{"errors": "Exceeded 2 calls per second"}`, ErrRateLimited},
+		{500, `{"errors": "oops"}`, ErrServerError},
+		{503, `<html></html>`, ErrServerError},
+	}
+
+	for _, c := range cases {
+		err := CheckResponseError(httpmock.NewStringResponse(c.status, c.body))
+		wrapped := fmt.Errorf("wrapped: %w", RequestError{Attempts: 2, Err: err})
+
+		for _, sentinel := range sentinels {
+			expected := sentinel == c.expected
+			if errors.Is(err, sentinel) != expected || errors.Is(wrapped, sentinel) != expected {
+				t.Errorf("status %d: errors.Is(%v) returned %v, expected %v", c.status, sentinel, !expected, expected)
+			}
+		}
+	}
+}
+
+func TestErrorAsResponseError(t *testing.T) {
+	cases := []struct {
+		status int
+		body   string
+	}{
+		{404, `{"errors": "Not Found"}`},
+		{422, `{"errors": {"title": ["can't be blank"]}}`},
+		{429, `{"errors": "Exceeded 2 calls per second"}`},
+	}
+
+	for _, c := range cases {
+		err := CheckResponseError(httpmock.NewStringResponse(c.status, c.body))
+
+		var responseErr ResponseError
+		if !errors.As(err, &responseErr) {
+			t.Errorf("status %d: expected a ResponseError, got %T", c.status, err)
+			continue
+		}
+		if responseErr.Status != c.status {
+			t.Errorf("status %d: ResponseError.Status is %d", c.status, responseErr.Status)
+		}
+	}
+}
+
+func TestValidationError(t *testing.T) {
+	err := CheckResponseError(httpmock.NewStringResponse(422,
+		`{"errors": {"title": ["can't be blank", "is too short"], "handle": "has already been taken"}}`))
+
+	var validationErr ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected a ValidationError, got %T", err)
+	}
+
+	expected := map[string][]string{
+		"title":  {"can't be blank", "is too short"},
+		"handle": {"has already been taken"},
+	}
+	if !reflect.DeepEqual(validationErr.Fields, expected) {
+		t.Errorf("ValidationError.Fields is %v, expected %v", validationErr.Fields, expected)
+	}
+
+	// the flattened messages are kept as well
+	if len(validationErr.Errors) != 3 {
+		t.Errorf("ValidationError.Errors is %v, expected 3 messages", validationErr.Errors)
+	}
+
+	err = CheckResponseError(httpmock.NewStringResponse(422, `{"error": "Unprocessable Entity"}`))
+	if !errors.As(err, &validationErr) || validationErr.Fields != nil {
+		t.Errorf("expected a ValidationError without fields, got %#v", err)
+	}
+}
+
+func TestUserErrorsErrorIsValidation(t *testing.T) {
+	err := UserErrorsError{UserErrors: []UserError{{Field: []string{"title"}, Message: "can't be blank"}}}
+	if !errors.Is(err, ErrValidation) {
+		t.Error("expected user errors to match ErrValidation")
+	}
+}
+
+func TestRequestErrorError(t *testing.T) {
+	cases := []struct {
+		err      RequestError
+		expected string
+	}{
+		{
+			RequestError{
+				Method:     "GET",
+				Path:       "/admin/api/2024-01/products/1.json",
+				RequestID:  "abc-123",
+				APIVersion: "2024-01",
+				Attempts:   3,
+				Err:        ResponseError{Message: "oh no"},
+			},
+			"oh no (GET /admin/api/2024-01/products/1.json, request id abc-123, api version 2024-01, 3 attempts)",
+		},
+		{
+			RequestError{Method: "POST", Path: "/admin/graphql.json", Attempts: 1, Err: ResponseError{Message: "oh no"}},
+			"oh no (POST /admin/graphql.json, 1 attempt)",
+		},
+		{
+			RequestError{Err: ResponseError{Message: "oh no"}},
+			"oh no",
+		},
+	}
+
+	for _, c := range cases {
+		if actual := fmt.Sprint(c.err); actual != c.expected {
+			t.Errorf("RequestError.Error(): expected %s, actual %s", c.expected, actual)
+		}
+
+		var responseErr ResponseError
+		if !errors.As(c.err, &responseErr) || responseErr.Message != "oh no" {
+			t.Errorf("RequestError should unwrap to the error of the last attempt")
+		}
+	}
+}
+
+func TestRequestErrorMetadata(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(404, `{"errors": "Not Found"}`)
+			resp.Header.Set("X-Request-Id", "abc-123")
+			resp.Header.Set("X-Shopify-API-Version", "2024-01")
+			return resp, nil
+		})
+
+	_, err := client.Product.Get(1, ListOptions{Fields: "id"})
+
+	var requestErr RequestError
+	if !errors.As(err, &requestErr) {
+		t.Fatalf("expected a RequestError, got %#v", err)
+	}
+
+	expected := RequestError{
+		Method:     "GET",
+		Path:       fmt.Sprintf("/%s/products/1.json", client.pathPrefix),
+		RequestID:  "abc-123",
+		APIVersion: "2024-01",
+		Attempts:   1,
+		Err:        ResponseError{Status: 404, Message: "Not Found"},
+	}
+	if !reflect.DeepEqual(requestErr, expected) {
+		t.Errorf("expected %#v, got %#v", expected, requestErr)
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Error("expected the error to match ErrNotFound")
+	}
+}
+
+func TestCheckResponseErrorMalformedBody(t *testing.T) {
+	cases := []struct {
+		resp     *http.Response
+		expected error
+	}{
+		{
+			httpmock.NewStringResponse(400, `<html><body>Bad Request</body></html>`),
+			ResponseDecodingError{
+				Body:    []byte(`<html><body>Bad Request</body></html>`),
+				Message: "invalid character '<' looking for beginning of value",
+				Status:  400,
+			},
+		},
+		{
+			httpmock.NewStringResponse(502, `Bad Gateway`),
+			ResponseDecodingError{
+				Body:    []byte(`Bad Gateway`),
+				Message: "invalid character 'B' looking for beginning of value",
+				Status:  502,
+			},
+		},
+		{
+			httpmock.NewStringResponse(429, `<html>slow down</html>`),
+			RateLimitError{
+				ResponseError: ResponseError{Status: 429, Message: "Too Many Requests"},
+				RetryAfter:    2,
+			},
+		},
+	}
+
+	for _, c := range cases {
+		actual := CheckResponseError(c.resp)
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("CheckResponseError(): expected %#v, actual %#v", c.expected, actual)
+		}
+		if c.resp.Header.Get("Retry-After") != "" {
+			t.Errorf("CheckResponseError() should not change the response headers")
+		}
+	}
+}
+
+func TestIsTransient(t *testing.T) {
+	cases := []struct {
+		err      error
+		expected bool
+	}{
+		{nil, false},
+		{errors.New("connection reset by peer"), true},
+		{context.Canceled, false},
+		{fmt.Errorf("get: %w", context.DeadlineExceeded), false},
+		{&url.Error{Op: "Get", URL: "https://fooshop.myshopify.com", Err: context.DeadlineExceeded}, true},
+		{&url.Error{Op: "Get", URL: "https://fooshop.myshopify.com", Err: context.Canceled}, false},
+		{&url.Error{Op: "Get", URL: "https://fooshop.myshopify.com", Err: x509.UnknownAuthorityError{}}, false},
+		{&json.SyntaxError{}, false},
+		{RateLimitError{ResponseError: ResponseError{Status: 429}}, true},
+		{ResponseError{Status: 503}, true},
+		{ResponseError{Status: 500}, true},
+		{ResponseError{Status: 501}, false},
+		{ResponseError{Status: 404}, false},
+		{ValidationError{ResponseError: ResponseError{Status: 422}}, false},
+		{ResponseDecodingError{Status: 502, Body: []byte("<html></html>")}, true},
+		{ResponseDecodingError{Status: 400, Body: []byte("<html></html>")}, false},
+		{RequestError{Attempts: 3, Err: ResponseError{Status: 504}}, true},
+		{UserErrorsError{ResponseError: ResponseError{Status: 200}}, false},
+	}
+
+	for _, c := range cases {
+		if actual := IsTransient(c.err); actual != c.expected {
+			t.Errorf("IsTransient(%#v) returned %v, expected %v", c.err, actual, c.expected)
+		}
+	}
+}
+
+func TestMalformedBadRequestNotRetried(t *testing.T) {
+	setup()
+	defer teardown()
+
+	WithRetryPolicy(DefaultRetryPolicy(3))(client)
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/foo/1",
+		httpmock.NewStringResponder(400, `<html><body>Bad Request</body></html>`))
+
+	req, _ := client.NewRequest("GET", "foo/1", nil, nil)
+	resp, err := client.Do(req, nil)
+
+	var decodingErr ResponseDecodingError
+	if !errors.As(err, &decodingErr) || decodingErr.Status != 400 {
+		t.Errorf("expected a decoding error with status 400, got %#v", err)
+	}
+	if errors.Is(err, ErrRateLimited) {
+		t.Error("a malformed bad request should not be reported as a rate limit")
+	}
+	if resp.Attempts != 1 {
+		t.Errorf("expected 1 attempt, got %d", resp.Attempts)
+	}
+}
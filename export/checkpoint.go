@@ -0,0 +1,97 @@
+package export
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// Checkpoint is the progress of an export, as of its last complete page.
+type Checkpoint struct {
+	// Resource is the exported resource, e.g. "products".
+	Resource string `json:"resource"`
+	// LastID is the id of the last exported item, the next page being
+	// requested after it.
+	LastID int64 `json:"last_id"`
+	// Exported counts the exported items, across resumed runs.
+	Exported int `json:"exported"`
+	// Offset is the size of the output up to the last complete page.
+	Offset int64 `json:"offset"`
+}
+
+// CheckpointStore persists the checkpoint of an export.
+type CheckpointStore interface {
+	// Load returns the saved checkpoint, the zero Checkpoint if none was.
+	Load() (Checkpoint, error)
+	Save(Checkpoint) error
+}
+
+// FileCheckpoint is a CheckpointStore saving the checkpoint as JSON to the
+// file at its path.
+type FileCheckpoint string
+
+// Load reads the checkpoint file, a missing file being no checkpoint.
+func (f FileCheckpoint) Load() (Checkpoint, error) {
+	var checkpoint Checkpoint
+
+	data, err := os.ReadFile(string(f))
+	if errors.Is(err, os.ErrNotExist) {
+		return checkpoint, nil
+	}
+	if err != nil {
+		return checkpoint, err
+	}
+
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		return checkpoint, fmt.Errorf("invalid checkpoint file %s: %w", f, err)
+	}
+	return checkpoint, nil
+}
+
+// Save replaces the checkpoint file, atomically so that an interrupted save
+// leaves the previous checkpoint.
+func (f FileCheckpoint) Save(checkpoint Checkpoint) error {
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(string(f)), filepath.Base(string(f))+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), string(f))
+}
+
+// OpenFile opens the output file of an export for the given checkpoint: it
+// is created if needed and truncated to the offset of the checkpoint, which
+// drops the items written after it was saved, the file being positioned at
+// its end.
+func OpenFile(path string, checkpoint Checkpoint) (*os.File, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := f.Truncate(checkpoint.Offset); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if _, err := f.Seek(checkpoint.Offset, io.SeekStart); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return f, nil
+}
@@ -0,0 +1,290 @@
+// Package export dumps the products, customers and orders of a shop to CSV,
+// the product CSV layout of the Shopify admin, or JSON lines:
+//
+//	f, _ := os.Create("products.csv")
+//	checkpoint, err := export.Products(ctx, client, f, export.Options{
+//		Format: export.FormatShopifyCSV,
+//		Filter: synergyshopify.ListOptions{UpdatedAtMin: since},
+//	})
+//
+// Every page is requested after the id of the last exported item, which makes
+// an export resumable: with a CheckpointStore, the progress is saved after
+// every page and a failed export started again with the same store and an
+// output opened with OpenFile carries on from the last saved page.
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	shopify "github.com/binodsynergytechs/synergyshopify"
+)
+
+// Format is the output format of an export.
+type Format string
+
+// Output formats.
+const (
+	// FormatJSONL writes an item per line as JSON.
+	FormatJSONL Format = "jsonl"
+	// FormatCSV writes an item per row, a column per selected field.
+	FormatCSV Format = "csv"
+	// FormatShopifyCSV writes products in the CSV layout of the Shopify
+	// admin, which the admin and the importer package can import.
+	FormatShopifyCSV Format = "shopify_csv"
+)
+
+// maxPageSize is the largest limit of the list endpoints.
+const maxPageSize = 250
+
+// Options configures an export.
+type Options struct {
+	// Format defaults to FormatJSONL.
+	Format Format
+
+	// Fields selects the top level fields of the items written as JSON lines
+	// or CSV columns, in that order, e.g. []string{"id", "email"}. It is also
+	// sent as the fields parameter of the requests. FormatShopifyCSV ignores
+	// it.
+	Fields []string
+
+	// Filter holds the date range filters of the requests, e.g. CreatedAtMin
+	// and UpdatedAtMax, and the page size as Limit, 250 by default. The
+	// pagination fields PageInfo, Page and SinceID and the Order are managed
+	// by the export.
+	Filter shopify.ListOptions
+
+	// Metafields adds all the metafields of every product, requested one
+	// product at a time, as a "metafields" field.
+	Metafields bool
+
+	// MetafieldKeys are the "namespace.key" of the product metafields written
+	// as columns of FormatShopifyCSV. They imply Metafields.
+	MetafieldKeys []string
+
+	// Checkpoint saves the progress after every page, if set.
+	Checkpoint CheckpointStore
+}
+
+// resource is an exportable resource.
+type resource struct {
+	name string
+	path string
+
+	// columns of FormatCSV without Fields
+	columns []string
+
+	// status filter, for orders whose default is only the open ones
+	status string
+}
+
+var (
+	products = resource{
+		name:    "products",
+		path:    "products.json",
+		columns: []string{"id", "title", "handle", "vendor", "product_type", "tags", "status", "created_at", "updated_at"},
+	}
+	customers = resource{
+		name:    "customers",
+		path:    "customers.json",
+		columns: []string{"id", "email", "first_name", "last_name", "phone", "state", "orders_count", "total_spent", "tags", "created_at", "updated_at"},
+	}
+	orders = resource{
+		name:    "orders",
+		path:    "orders.json",
+		columns: []string{"id", "name", "email", "financial_status", "fulfillment_status", "currency", "total_price", "created_at", "updated_at"},
+		status:  "any",
+	}
+)
+
+// listOptions are the options of the requests of an export.
+type listOptions struct {
+	shopify.ListOptions
+	Status string `url:"status,omitempty"`
+}
+
+// Products exports the products of the shop with their variants, images and
+// options, and optionally their metafields.
+func Products(ctx context.Context, client *shopify.Client, w io.Writer, opts Options) (Checkpoint, error) {
+	return run(ctx, client, w, products, opts)
+}
+
+// Customers exports the customers of the shop.
+func Customers(ctx context.Context, client *shopify.Client, w io.Writer, opts Options) (Checkpoint, error) {
+	return run(ctx, client, w, customers, opts)
+}
+
+// Orders exports the orders of the shop, whatever their status.
+func Orders(ctx context.Context, client *shopify.Client, w io.Writer, opts Options) (Checkpoint, error) {
+	return run(ctx, client, w, orders, opts)
+}
+
+// run exports every page of a resource and returns the progress, as saved
+// with the last complete page.
+func run(ctx context.Context, client *shopify.Client, w io.Writer, res resource, opts Options) (Checkpoint, error) {
+	if opts.Format == "" {
+		opts.Format = FormatJSONL
+	}
+	if len(opts.MetafieldKeys) > 0 {
+		opts.Metafields = true
+	}
+	if opts.Metafields && res.name != products.name {
+		return Checkpoint{}, fmt.Errorf("export: metafields are only exported with products")
+	}
+
+	checkpoint := Checkpoint{Resource: res.name}
+	if opts.Checkpoint != nil {
+		saved, err := opts.Checkpoint.Load()
+		if err != nil {
+			return checkpoint, fmt.Errorf("export: loading checkpoint: %w", err)
+		}
+		if saved.Resource != "" && saved.Resource != res.name {
+			return checkpoint, fmt.Errorf("export: checkpoint of %s, not %s", saved.Resource, res.name)
+		}
+		if saved.Resource != "" {
+			checkpoint = saved
+		}
+	}
+
+	counter := &countingWriter{w: w}
+	out, err := newWriter(counter, res, opts)
+	if err != nil {
+		return checkpoint, err
+	}
+
+	// the header is only written once, not when resuming
+	if checkpoint.Offset == 0 {
+		if err := out.header(); err != nil {
+			return checkpoint, err
+		}
+		if err := out.flush(); err != nil {
+			return checkpoint, err
+		}
+	}
+
+	client = client.WithContext(ctx)
+	options := listOptions{ListOptions: opts.Filter, Status: res.status}
+	options.PageInfo, options.Page, options.Order = "", 0, ""
+	if options.Limit <= 0 || options.Limit > maxPageSize {
+		options.Limit = maxPageSize
+	}
+	if len(opts.Fields) > 0 && opts.Format != FormatShopifyCSV {
+		options.Fields = requestFields(opts.Fields)
+	}
+
+	for {
+		options.SinceID = checkpoint.LastID
+		next := checkpoint
+
+		// the page is read before the metafields are fetched, which would
+		// otherwise hold the response open past the timeout of the client
+		var page []json.RawMessage
+		_, err := shopify.Stream(client, res.path, options, func(item json.RawMessage) error {
+			page = append(page, item)
+			return nil
+		})
+		if err != nil {
+			return checkpoint, err
+		}
+
+		for _, item := range page {
+			var ids struct {
+				ID int64 `json:"id"`
+			}
+			if err := json.Unmarshal(item, &ids); err != nil {
+				return checkpoint, err
+			}
+
+			if opts.Metafields {
+				path := fmt.Sprintf("products/%d/metafields.json", ids.ID)
+				metafields, err := shopify.IteratePath[shopify.Metafield](client, path, shopify.ListOptions{Limit: maxPageSize}).All()
+				if err != nil {
+					return checkpoint, fmt.Errorf("export: metafields of product %d: %w", ids.ID, err)
+				}
+				if metafields == nil {
+					metafields = []shopify.Metafield{}
+				}
+				if item, err = withField(item, "metafields", metafields); err != nil {
+					return checkpoint, err
+				}
+			}
+
+			if err := out.write(item); err != nil {
+				return checkpoint, err
+			}
+			next.LastID = ids.ID
+			next.Exported++
+		}
+
+		if err := out.flush(); err != nil {
+			return checkpoint, err
+		}
+		next.Offset = checkpoint.Offset + counter.reset()
+		checkpoint = next
+
+		if opts.Checkpoint != nil {
+			if err := opts.Checkpoint.Save(checkpoint); err != nil {
+				return checkpoint, fmt.Errorf("export: saving checkpoint: %w", err)
+			}
+		}
+
+		if len(page) < options.Limit {
+			return checkpoint, nil
+		}
+	}
+}
+
+// requestFields returns the fields parameter of the selected fields, which
+// always includes the id the pages are requested after.
+func requestFields(fields []string) string {
+	for _, field := range fields {
+		if field == "id" {
+			return strings.Join(fields, ",")
+		}
+	}
+	return strings.Join(append([]string{"id"}, fields...), ",")
+}
+
+// withField returns the JSON object item with the given field set.
+func withField(item json.RawMessage, name string, value interface{}) (json.RawMessage, error) {
+	fields, err := decodeObject(item)
+	if err != nil {
+		return nil, err
+	}
+	fields[name] = value
+	return json.Marshal(fields)
+}
+
+// decodeObject decodes a JSON object, keeping the numbers as they are.
+func decodeObject(item json.RawMessage) (map[string]interface{}, error) {
+	fields := map[string]interface{}{}
+	decoder := json.NewDecoder(bytes.NewReader(item))
+	decoder.UseNumber()
+	if err := decoder.Decode(&fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// countingWriter counts the bytes written since its last reset.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// reset returns the bytes written since the last reset.
+func (c *countingWriter) reset() int64 {
+	n := c.n
+	c.n = 0
+	return n
+}
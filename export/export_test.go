@@ -0,0 +1,329 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	shopify "github.com/binodsynergytechs/synergyshopify"
+	"github.com/binodsynergytechs/synergyshopify/shopifytest"
+)
+
+func TestProductsShopifyCSV(t *testing.T) {
+	server := shopifytest.NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	product, err := client.Product.Create(shopify.Product{
+		Title:    "Shirt",
+		Vendor:   "Acme",
+		Tags:     "cotton, summer",
+		Options:  []shopify.ProductOption{{Name: "Size", Position: 1, Values: []string{"S", "L"}}},
+		Variants: []shopify.Variant{{Option1: "S", Sku: "SHIRT-S", Price: "10.00"}, {Option1: "L", Sku: "SHIRT-L", Price: "12.00"}},
+		Images: []shopify.Image{
+			{Src: "https://cdn.example.com/front.png", Position: 1},
+			{Src: "https://cdn.example.com/back.png", Position: 2},
+			{Src: "https://cdn.example.com/side.png", Position: 3},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Product.Create returned error: %v", err)
+	}
+	if _, err := client.Product.CreateMetafield(product.ID, shopify.Metafield{Namespace: "custom", Key: "fabric", Value: "cotton", Type: "single_line_text_field"}); err != nil {
+		t.Fatalf("Product.CreateMetafield returned error: %v", err)
+	}
+
+	var out bytes.Buffer
+	checkpoint, err := Products(context.Background(), client, &out, Options{
+		Format:        FormatShopifyCSV,
+		MetafieldKeys: []string{"custom.fabric"},
+	})
+	if err != nil {
+		t.Fatalf("Products returned error: %v", err)
+	}
+	if checkpoint.Exported != 1 || checkpoint.LastID != product.ID {
+		t.Errorf("Products returned %+v", checkpoint)
+	}
+
+	rows, err := csv.NewReader(&out).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+	if len(rows) != 4 {
+		t.Fatalf("expected a header and a row per image, got %q", rows)
+	}
+	if header := rows[0]; len(header) != len(ProductColumns)+1 || header[len(header)-1] != "fabric (product.metafields.custom.fabric)" {
+		t.Errorf("unexpected header %q", header)
+	}
+
+	get := func(row []string, column string) string {
+		for i, c := range rows[0] {
+			if c == column {
+				return row[i]
+			}
+		}
+		t.Fatalf("no column %q", column)
+		return ""
+	}
+	cases := []struct {
+		row      int
+		column   string
+		expected string
+	}{
+		{1, ColumnHandle, "shirt"},
+		{1, ColumnTitle, "Shirt"},
+		{1, ColumnTags, "cotton, summer"},
+		{1, ColumnOption1Name, "Size"},
+		{1, ColumnOption1Value, "S"},
+		{1, ColumnVariantPrice, "10.00"},
+		{1, ColumnImageSrc, "https://cdn.example.com/front.png"},
+		{1, MetafieldColumn("custom.fabric"), "cotton"},
+		{2, ColumnHandle, "shirt"},
+		{2, ColumnTitle, ""},
+		{2, ColumnOption1Value, "L"},
+		{2, ColumnVariantSKU, "SHIRT-L"},
+		{2, ColumnImagePosition, "2"},
+		{3, ColumnVariantSKU, ""},
+		{3, ColumnImageSrc, "https://cdn.example.com/side.png"},
+	}
+	for _, c := range cases {
+		if actual := get(rows[c.row], c.column); actual != c.expected {
+			t.Errorf("row %d, %s: got %q, expected %q", c.row, c.column, actual, c.expected)
+		}
+	}
+}
+
+func TestCustomersJSONL(t *testing.T) {
+	server := shopifytest.NewServer()
+	defer server.Close()
+	for _, email := range []string{"a@example.com", "b@example.com", "c@example.com"} {
+		server.Seed("customers", shopify.Customer{Email: email, FirstName: "Jo"})
+	}
+
+	var out bytes.Buffer
+	checkpoint, err := Customers(context.Background(), server.Client(), &out, Options{
+		Fields: []string{"email"},
+		Filter: shopify.ListOptions{Limit: 2, CreatedAtMin: time.Now().Add(-time.Hour)},
+	})
+	if err != nil {
+		t.Fatalf("Customers returned error: %v", err)
+	}
+	if checkpoint.Exported != 3 {
+		t.Errorf("expected 3 customers to be exported, got %+v", checkpoint)
+	}
+
+	expected := `{"email":"a@example.com"}` + "\n" + `{"email":"b@example.com"}` + "\n" + `{"email":"c@example.com"}` + "\n"
+	if out.String() != expected {
+		t.Errorf("Customers wrote %q, expected %q", out.String(), expected)
+	}
+
+	var pages []string
+	for _, r := range server.Requests() {
+		if strings.HasSuffix(r.Path, "/customers.json") {
+			pages = append(pages, r.Query.Get("since_id"))
+			if r.Query.Get("fields") != "id,email" || r.Query.Get("created_at_min") == "" {
+				t.Errorf("unexpected query %v", r.Query)
+			}
+		}
+	}
+	if len(pages) != 2 || pages[0] != "" || pages[1] == "" {
+		t.Errorf("expected two pages, the second after the last id, got since_id %q", pages)
+	}
+}
+
+func TestOrdersCSV(t *testing.T) {
+	server := shopifytest.NewServer()
+	defer server.Close()
+	server.Seed("orders", shopify.Order{Email: "a@example.com", FinancialStatus: "paid"})
+
+	var out bytes.Buffer
+	if _, err := Orders(context.Background(), server.Client(), &out, Options{Format: FormatCSV, Fields: []string{"name", "email", "financial_status"}}); err != nil {
+		t.Fatalf("Orders returned error: %v", err)
+	}
+	if expected := "name,email,financial_status\n#1001,a@example.com,paid\n"; out.String() != expected {
+		t.Errorf("Orders wrote %q, expected %q", out.String(), expected)
+	}
+
+	requests := server.Requests()
+	if status := requests[len(requests)-1].Query.Get("status"); status != "any" {
+		t.Errorf("expected orders of any status to be requested, got %q", status)
+	}
+
+	if _, err := Orders(context.Background(), server.Client(), &out, Options{Format: FormatShopifyCSV}); err == nil {
+		t.Error("expected an error exporting orders as product CSV")
+	}
+}
+
+// failingStore is a checkpoint store failing every save after the first
+// ones.
+type failingStore struct {
+	FileCheckpoint
+	saves int
+}
+
+func (f *failingStore) Save(c Checkpoint) error {
+	if f.saves == 0 {
+		return errors.New("disk full")
+	}
+	f.saves--
+	return f.FileCheckpoint.Save(c)
+}
+
+func TestResume(t *testing.T) {
+	server := shopifytest.NewServer()
+	defer server.Close()
+	for i := 0; i < 5; i++ {
+		server.Seed("products", shopify.Product{Title: "Product"})
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "products.csv")
+	store := FileCheckpoint(filepath.Join(dir, "checkpoint.json"))
+	opts := Options{Format: FormatCSV, Fields: []string{"id"}, Filter: shopify.ListOptions{Limit: 2}}
+
+	// the second page is written but not saved
+	f, err := OpenFile(path, Checkpoint{})
+	if err != nil {
+		t.Fatalf("OpenFile returned error: %v", err)
+	}
+	opts.Checkpoint = &failingStore{FileCheckpoint: store, saves: 1}
+	if _, err := Products(context.Background(), server.Client(), f, opts); err == nil {
+		t.Fatal("expected the export to fail")
+	}
+	f.Close()
+
+	checkpoint, err := store.Load()
+	if err != nil || checkpoint.Exported != 2 || checkpoint.Resource != "products" {
+		t.Fatalf("unexpected saved checkpoint %+v, %v", checkpoint, err)
+	}
+
+	f, err = OpenFile(path, checkpoint)
+	if err != nil {
+		t.Fatalf("OpenFile returned error: %v", err)
+	}
+	opts.Checkpoint = store
+	checkpoint, err = Products(context.Background(), server.Client(), f, opts)
+	f.Close()
+	if err != nil || checkpoint.Exported != 5 {
+		t.Fatalf("Products returned %+v, %v", checkpoint, err)
+	}
+
+	data, _ := os.ReadFile(path)
+	ids := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(ids) != 6 || ids[0] != "id" {
+		t.Fatalf("expected a header and every product once, got %q", ids)
+	}
+	seen := map[string]bool{}
+	for _, id := range ids[1:] {
+		if seen[id] {
+			t.Errorf("product %s exported twice", id)
+		}
+		seen[id] = true
+	}
+
+	if _, err := Customers(context.Background(), server.Client(), &bytes.Buffer{}, Options{Checkpoint: store}); err == nil {
+		t.Error("expected an error resuming the export of another resource")
+	}
+}
+
+// slowTransport delays the requests whose path contains path.
+type slowTransport struct {
+	http.RoundTripper
+	path  string
+	delay time.Duration
+}
+
+func (t slowTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if strings.Contains(req.URL.Path, t.path) {
+		time.Sleep(t.delay)
+	}
+	return t.RoundTripper.RoundTrip(req)
+}
+
+func TestProductsMetafieldsTimeout(t *testing.T) {
+	server := shopifytest.NewServer()
+	defer server.Close()
+	// products large enough for the page not to be read at once
+	for i := 0; i < 20; i++ {
+		server.Seed("products", shopify.Product{Title: "Product", BodyHTML: strings.Repeat("<p>Cotton</p>", 1000)})
+	}
+
+	// fetching every metafield takes longer than the timeout of a request
+	httpClient := server.HTTPClient()
+	httpClient.Transport = slowTransport{RoundTripper: httpClient.Transport, path: "metafields", delay: 20 * time.Millisecond}
+	httpClient.Timeout = 200 * time.Millisecond
+	client := server.Client(shopify.WithHTTPClient(httpClient))
+
+	checkpoint, err := Products(context.Background(), client, &bytes.Buffer{}, Options{Metafields: true})
+	if err != nil || checkpoint.Exported != 20 {
+		t.Errorf("Products returned %+v, %v", checkpoint, err)
+	}
+}
+
+func TestProductsAllMetafields(t *testing.T) {
+	server := shopifytest.NewServer()
+	defer server.Close()
+	id, _ := server.Seed("products", shopify.Product{Title: "Shirt"})
+	for i := 0; i < 260; i++ {
+		server.Seed("metafields", shopify.Metafield{
+			Namespace: "custom", Key: fmt.Sprintf("key%d", i), Value: "value", Type: "single_line_text_field",
+			OwnerResource: "product", OwnerId: id,
+		})
+	}
+
+	var out bytes.Buffer
+	if _, err := Products(context.Background(), server.Client(), &out, Options{Metafields: true}); err != nil {
+		t.Fatalf("Products returned error: %v", err)
+	}
+
+	var product struct {
+		Metafields []shopify.Metafield `json:"metafields"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &product); err != nil {
+		t.Fatalf("invalid product %q: %v", out.String(), err)
+	}
+	if len(product.Metafields) != 260 {
+		t.Errorf("expected the 260 metafields of the product, got %d", len(product.Metafields))
+	}
+
+	pages := 0
+	for _, r := range server.Requests() {
+		if strings.HasSuffix(r.Path, "/metafields.json") {
+			pages++
+			if r.Query.Get("limit") != "250" {
+				t.Errorf("unexpected query %v", r.Query)
+			}
+		}
+	}
+	if pages != 2 {
+		t.Errorf("expected two pages of metafields, got %d", pages)
+	}
+}
+
+func TestProductRows(t *testing.T) {
+	rows := ProductRows(shopify.Product{Handle: "hat", Title: "Hat", Status: "draft"}, nil)
+	if len(rows) != 1 || len(rows[0]) != len(ProductColumns) {
+		t.Fatalf("expected a single row, got %q", rows)
+	}
+
+	expected := map[string]string{ColumnHandle: "hat", ColumnTitle: "Hat", ColumnPublished: "false", ColumnStatus: "draft"}
+	actual := map[string]string{}
+	for i, column := range ProductColumns {
+		if rows[0][i] != "" {
+			actual[column] = rows[0][i]
+		}
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("ProductRows returned %v, expected %v", actual, expected)
+	}
+}
@@ -0,0 +1,201 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
+	shopify "github.com/binodsynergytechs/synergyshopify"
+)
+
+// Columns of the product CSV layout of the Shopify admin.
+const (
+	ColumnHandle                  = "Handle"
+	ColumnTitle                   = "Title"
+	ColumnBody                    = "Body (HTML)"
+	ColumnVendor                  = "Vendor"
+	ColumnType                    = "Type"
+	ColumnTags                    = "Tags"
+	ColumnPublished               = "Published"
+	ColumnOption1Name             = "Option1 Name"
+	ColumnOption1Value            = "Option1 Value"
+	ColumnOption2Name             = "Option2 Name"
+	ColumnOption2Value            = "Option2 Value"
+	ColumnOption3Name             = "Option3 Name"
+	ColumnOption3Value            = "Option3 Value"
+	ColumnVariantSKU              = "Variant SKU"
+	ColumnVariantGrams            = "Variant Grams"
+	ColumnVariantInventoryTracker = "Variant Inventory Tracker"
+	ColumnVariantInventoryQty     = "Variant Inventory Qty"
+	ColumnVariantInventoryPolicy  = "Variant Inventory Policy"
+	ColumnVariantFulfillment      = "Variant Fulfillment Service"
+	ColumnVariantPrice            = "Variant Price"
+	ColumnVariantCompareAtPrice   = "Variant Compare At Price"
+	ColumnVariantRequiresShipping = "Variant Requires Shipping"
+	ColumnVariantTaxable          = "Variant Taxable"
+	ColumnVariantBarcode          = "Variant Barcode"
+	ColumnImageSrc                = "Image Src"
+	ColumnImagePosition           = "Image Position"
+	ColumnSEOTitle                = "SEO Title"
+	ColumnSEODescription          = "SEO Description"
+	ColumnVariantImage            = "Variant Image"
+	ColumnVariantWeightUnit       = "Variant Weight Unit"
+	ColumnVariantTaxCode          = "Variant Tax Code"
+	ColumnStatus                  = "Status"
+)
+
+// ProductColumns are the columns of FormatShopifyCSV, in order, before the
+// metafield columns.
+var ProductColumns = []string{
+	ColumnHandle, ColumnTitle, ColumnBody, ColumnVendor, ColumnType, ColumnTags, ColumnPublished,
+	ColumnOption1Name, ColumnOption1Value, ColumnOption2Name, ColumnOption2Value, ColumnOption3Name, ColumnOption3Value,
+	ColumnVariantSKU, ColumnVariantGrams, ColumnVariantInventoryTracker, ColumnVariantInventoryQty,
+	ColumnVariantInventoryPolicy, ColumnVariantFulfillment, ColumnVariantPrice, ColumnVariantCompareAtPrice,
+	ColumnVariantRequiresShipping, ColumnVariantTaxable, ColumnVariantBarcode,
+	ColumnImageSrc, ColumnImagePosition, ColumnSEOTitle, ColumnSEODescription,
+	ColumnVariantImage, ColumnVariantWeightUnit, ColumnVariantTaxCode, ColumnStatus,
+}
+
+// MetafieldColumn returns the column of a product metafield given as
+// "namespace.key", e.g. "size (product.metafields.custom.size)".
+func MetafieldColumn(namespaceKey string) string {
+	key := namespaceKey[strings.LastIndex(namespaceKey, ".")+1:]
+	return key + " (product.metafields." + namespaceKey + ")"
+}
+
+// ProductRows returns the rows of a product in the product CSV layout: the
+// first one holds the product fields, then every row a variant and an image
+// by position, only the handle linking them to the product.
+func ProductRows(p shopify.Product, metafieldKeys []string) [][]string {
+	options := append([]shopify.ProductOption(nil), p.Options...)
+	sort.SliceStable(options, func(i, j int) bool { return options[i].Position < options[j].Position })
+
+	images := make(map[int64]string, len(p.Images))
+	for _, image := range p.Images {
+		images[image.ID] = image.Src
+	}
+
+	n := len(p.Variants)
+	if len(p.Images) > n {
+		n = len(p.Images)
+	}
+	if n == 0 {
+		n = 1
+	}
+
+	width := len(ProductColumns) + len(metafieldKeys)
+	rows := make([][]string, n)
+	for i := range rows {
+		row := make(map[string]string, width)
+		row[ColumnHandle] = p.Handle
+
+		if i == 0 {
+			row[ColumnTitle] = p.Title
+			row[ColumnBody] = p.BodyHTML
+			row[ColumnVendor] = p.Vendor
+			row[ColumnType] = p.ProductType
+			row[ColumnTags] = p.Tags
+			row[ColumnPublished] = strconv.FormatBool(p.PublishedAt != nil)
+			row[ColumnSEOTitle] = p.MetafieldsGlobalTitleTag
+			row[ColumnSEODescription] = p.MetafieldsGlobalDescriptionTag
+			row[ColumnStatus] = p.Status
+
+			names := []string{ColumnOption1Name, ColumnOption2Name, ColumnOption3Name}
+			for j, option := range options {
+				if j < len(names) {
+					row[names[j]] = option.Name
+				}
+			}
+		}
+
+		if i < len(p.Variants) {
+			v := p.Variants[i]
+			row[ColumnOption1Value] = v.Option1
+			row[ColumnOption2Value] = v.Option2
+			row[ColumnOption3Value] = v.Option3
+			row[ColumnVariantSKU] = v.Sku
+			row[ColumnVariantGrams] = strconv.Itoa(v.Grams)
+			row[ColumnVariantInventoryTracker] = v.InventoryManagement
+			row[ColumnVariantInventoryQty] = strconv.Itoa(v.InventoryQuantity)
+			row[ColumnVariantInventoryPolicy] = v.InventoryPolicy
+			row[ColumnVariantFulfillment] = v.FulfillmentService
+			row[ColumnVariantPrice] = v.Price
+			row[ColumnVariantCompareAtPrice] = v.CompareAtPrice
+			row[ColumnVariantRequiresShipping] = strconv.FormatBool(v.RequireShipping)
+			row[ColumnVariantTaxable] = strconv.FormatBool(v.Taxable)
+			row[ColumnVariantBarcode] = v.Barcode
+			row[ColumnVariantImage] = images[v.ImageID]
+			row[ColumnVariantWeightUnit] = v.WeightUnit
+			row[ColumnVariantTaxCode] = v.TaxCode
+		}
+
+		if i < len(p.Images) {
+			image := p.Images[i]
+			row[ColumnImageSrc] = image.Src
+			position := image.Position
+			if position == 0 {
+				position = i + 1
+			}
+			row[ColumnImagePosition] = strconv.Itoa(position)
+		}
+
+		cells := make([]string, 0, width)
+		for _, column := range ProductColumns {
+			cells = append(cells, row[column])
+		}
+		for _, key := range metafieldKeys {
+			value := ""
+			if i == 0 {
+				value = metafieldValue(p.Metafields, key)
+			}
+			cells = append(cells, value)
+		}
+		rows[i] = cells
+	}
+
+	return rows
+}
+
+// metafieldValue returns the value of the metafield "namespace.key".
+func metafieldValue(metafields []shopify.Metafield, namespaceKey string) string {
+	for _, m := range metafields {
+		if m.Namespace+"."+m.Key == namespaceKey {
+			return cell(m.Value)
+		}
+	}
+	return ""
+}
+
+// productCSVWriter writes products in the product CSV layout.
+type productCSVWriter struct {
+	w             *csv.Writer
+	metafieldKeys []string
+}
+
+func (p *productCSVWriter) header() error {
+	header := append([]string(nil), ProductColumns...)
+	for _, key := range p.metafieldKeys {
+		header = append(header, MetafieldColumn(key))
+	}
+	return p.w.Write(header)
+}
+
+func (p *productCSVWriter) write(item json.RawMessage) error {
+	var product shopify.Product
+	if err := json.Unmarshal(item, &product); err != nil {
+		return err
+	}
+	for _, row := range ProductRows(product, p.metafieldKeys) {
+		if err := p.w.Write(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *productCSVWriter) flush() error {
+	p.w.Flush()
+	return p.w.Error()
+}
@@ -0,0 +1,134 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// writer writes the items of an export in a format.
+type writer interface {
+	// header writes what precedes the items, e.g. the CSV header.
+	header() error
+	write(item json.RawMessage) error
+	// flush writes any buffered data.
+	flush() error
+}
+
+// newWriter returns the writer of the format of the options.
+func newWriter(w io.Writer, res resource, opts Options) (writer, error) {
+	switch opts.Format {
+	case FormatJSONL:
+		return &jsonlWriter{w: w, fields: opts.Fields}, nil
+
+	case FormatCSV:
+		columns := opts.Fields
+		if len(columns) == 0 {
+			columns = res.columns
+			if opts.Metafields {
+				columns = append(columns[:len(columns):len(columns)], "metafields")
+			}
+		}
+		return &csvWriter{w: csv.NewWriter(w), columns: columns}, nil
+
+	case FormatShopifyCSV:
+		if res.name != products.name {
+			return nil, fmt.Errorf("export: %s can't be exported as %s", res.name, opts.Format)
+		}
+		return &productCSVWriter{w: csv.NewWriter(w), metafieldKeys: opts.MetafieldKeys}, nil
+	}
+
+	return nil, fmt.Errorf("export: unknown format %q", opts.Format)
+}
+
+// jsonlWriter writes an item per line.
+type jsonlWriter struct {
+	w      io.Writer
+	fields []string
+	buf    bytes.Buffer
+}
+
+func (j *jsonlWriter) header() error { return nil }
+
+func (j *jsonlWriter) write(item json.RawMessage) error {
+	j.buf.Reset()
+
+	if len(j.fields) == 0 {
+		if err := json.Compact(&j.buf, item); err != nil {
+			return err
+		}
+		j.buf.WriteByte('\n')
+	} else {
+		fields, err := decodeObject(item)
+		if err != nil {
+			return err
+		}
+		selected := make(map[string]interface{}, len(j.fields))
+		for _, name := range j.fields {
+			if v, ok := fields[name]; ok {
+				selected[name] = v
+			}
+		}
+
+		encoder := json.NewEncoder(&j.buf)
+		encoder.SetEscapeHTML(false)
+		if err := encoder.Encode(selected); err != nil {
+			return err
+		}
+	}
+
+	_, err := j.w.Write(j.buf.Bytes())
+	return err
+}
+
+func (j *jsonlWriter) flush() error { return nil }
+
+// csvWriter writes an item per row, nested values as JSON.
+type csvWriter struct {
+	w       *csv.Writer
+	columns []string
+}
+
+func (c *csvWriter) header() error {
+	return c.w.Write(c.columns)
+}
+
+func (c *csvWriter) write(item json.RawMessage) error {
+	fields, err := decodeObject(item)
+	if err != nil {
+		return err
+	}
+
+	row := make([]string, len(c.columns))
+	for i, column := range c.columns {
+		row[i] = cell(fields[column])
+	}
+	return c.w.Write(row)
+}
+
+func (c *csvWriter) flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// cell formats a field, nested values as compact JSON.
+func cell(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		if v {
+			return "true"
+		}
+		return "false"
+	}
+
+	data, _ := json.Marshal(v)
+	return string(data)
+}
@@ -17,6 +17,7 @@ type GiftCardService interface {
 	Create(GiftCard) (*GiftCard, error)
 	Update(GiftCard) (*GiftCard, error)
 	List() ([]GiftCard, error)
+	ListWithPagination(interface{}) ([]GiftCard, *Pagination, error)
 	Disable(int64) (*GiftCard, error)
 	Count(interface{}) (int, error)
 }
@@ -71,10 +72,24 @@ func (s *GiftCardServiceOp) Get(giftCardID int64) (*GiftCard, error) {
 
 // List retrieves a list of gift cards
 func (s *GiftCardServiceOp) List() ([]GiftCard, error) {
+	giftCards, _, err := s.ListWithPagination(nil)
+	if err != nil {
+		return nil, err
+	}
+	return giftCards, nil
+}
+
+// ListWithPagination lists gift cards and return pagination to retrieve next/previous results.
+func (s *GiftCardServiceOp) ListWithPagination(options interface{}) ([]GiftCard, *Pagination, error) {
 	path := fmt.Sprintf("%s.json", giftCardsBasePath)
 	resource := new(GiftCardsResource)
-	err := s.client.Get(path, resource, nil, true)
-	return resource.GiftCards, err
+
+	pagination, err := s.client.ListWithPagination(path, resource, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resource.GiftCards, pagination, nil
 }
 
 // Create creates a gift card
@@ -1,6 +1,6 @@
 module github.com/binodsynergytechs/synergyshopify
 
-go 1.20
+go 1.21
 
 require (
 	github.com/google/go-querystring v1.1.0
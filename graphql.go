@@ -0,0 +1,388 @@
+package synergyshopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	graphQLPath = "graphql.json"
+
+	// graphQLThrottledCode is the error code Shopify uses when a query was
+	// rejected because its cost exceeds the available budget
+	graphQLThrottledCode = "THROTTLED"
+
+	// number of attempts for throttled queries when no retries are configured
+	defaultGraphQLThrottleAttempts = 3
+)
+
+// GraphQLService is an interface for interfacing with the GraphQL Admin API
+// of Shopify. Queries and mutations are sent the same way.
+// See: https://shopify.dev/docs/api/admin-graphql
+type GraphQLService interface {
+	Query(query string, variables, response interface{}) error
+	QueryContext(ctx context.Context, query string, variables, response interface{}) error
+}
+
+// GraphQLServiceOp handles communication with the GraphQL endpoint of the
+// Shopify API.
+type GraphQLServiceOp struct {
+	client *Client
+}
+
+// GraphQLRequest is the body posted to the graphql.json endpoint
+type GraphQLRequest struct {
+	Query     string      `json:"query"`
+	Variables interface{} `json:"variables,omitempty"`
+}
+
+// GraphQLResponse represents the envelope returned by the graphql.json endpoint
+type GraphQLResponse struct {
+	Data       json.RawMessage    `json:"data"`
+	Errors     []GraphQLError     `json:"errors,omitempty"`
+	Extensions *GraphQLExtensions `json:"extensions,omitempty"`
+}
+
+// GraphQLExtensions represents the `extensions` of a GraphQL response
+type GraphQLExtensions struct {
+	Cost *GraphQLCost `json:"cost,omitempty"`
+}
+
+// GraphQLCost represents the calculated cost of a GraphQL query, see
+// https://shopify.dev/docs/api/usage/rate-limits#graphql-admin-api-rate-limits
+type GraphQLCost struct {
+	RequestedQueryCost float64               `json:"requestedQueryCost"`
+	ActualQueryCost    *float64              `json:"actualQueryCost"`
+	ThrottleStatus     GraphQLThrottleStatus `json:"throttleStatus"`
+}
+
+// GraphQLThrottleStatus represents the state of the cost bucket of a shop
+type GraphQLThrottleStatus struct {
+	MaximumAvailable   float64 `json:"maximumAvailable"`
+	CurrentlyAvailable float64 `json:"currentlyAvailable"`
+	RestoreRate        float64 `json:"restoreRate"`
+}
+
+// GraphQLErrorLocation points at the part of the query that caused an error
+type GraphQLErrorLocation struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// GraphQLError represents a single entry of the top level `errors` list of a
+// GraphQL response.
+type GraphQLError struct {
+	Message    string                 `json:"message"`
+	Locations  []GraphQLErrorLocation `json:"locations,omitempty"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+// Code returns the `extensions.code` of the error, e.g. THROTTLED
+func (e GraphQLError) Code() string {
+	code, _ := e.Extensions["code"].(string)
+	return code
+}
+
+func (e GraphQLError) Error() string {
+	return e.Message
+}
+
+// GraphQLResponseError occurs when a GraphQL response contains top level
+// `errors`. Embeds the ResponseError to allow consumers to handle it the same
+// way as a normal ResponseError.
+type GraphQLResponseError struct {
+	ResponseError
+	GraphQLErrors []GraphQLError
+}
+
+// HasCode reports whether any of the errors carries the given extensions code.
+func (e GraphQLResponseError) HasCode(code string) bool {
+	for _, err := range e.GraphQLErrors {
+		if err.Code() == code {
+			return true
+		}
+	}
+	return false
+}
+
+// UserError represents an entry of the `userErrors` list returned by a
+// mutation payload.
+type UserError struct {
+	Field   []string `json:"field"`
+	Message string   `json:"message"`
+	Code    string   `json:"code,omitempty"`
+}
+
+func (e UserError) Error() string {
+	if len(e.Field) == 0 {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %s", strings.Join(e.Field, "."), e.Message)
+}
+
+// UserErrorsError occurs when a mutation payload contains `userErrors`. The
+// decoded data is still available to the caller. Embeds the ResponseError to
+// allow consumers to handle it the same way as a normal ResponseError.
+type UserErrorsError struct {
+	ResponseError
+	UserErrors []UserError
+}
+
+// Query sends a query or mutation with the given variables and decodes the
+// `data` of the response into response. Top level `errors` are returned as a
+// GraphQLResponseError and any `userErrors` of the mutation payloads as a
+// UserErrorsError.
+//
+// Queries are throttled by their calculated cost: when the last known cost of
+// the query exceeds the budget available to the shop, Query waits for the
+// budget to be restored before sending it. THROTTLED errors are retried up to
+// the number of attempts of the client, see WithRetry and WithRetryPolicy.
+// Since they are read-only, queries are also retried on the failures retried
+// for idempotent requests, mutations aren't.
+func (s *GraphQLServiceOp) Query(query string, variables, response interface{}) error {
+	return s.QueryContext(s.client.context(), query, variables, response)
+}
+
+// QueryContext performs a Query with the given context, which bounds the
+// requests and the waits for the cost budget.
+func (s *GraphQLServiceOp) QueryContext(ctx context.Context, query string, variables, response interface{}) error {
+	if isGraphQLQuery(query) {
+		ctx = MarkIdempotent(ctx)
+	}
+	data := GraphQLRequest{Query: query, Variables: variables}
+
+	attempts := s.client.retryPolicy().MaxAttempts
+	if attempts <= 1 {
+		attempts = defaultGraphQLThrottleAttempts
+	}
+
+	var resource *GraphQLResponse
+	for attempt := 1; ; attempt++ {
+		wait := s.client.graphQLThrottle.delay(query)
+		if wait > 0 {
+			s.client.log.Debugf("graphql query cost exceeds available budget, waiting %s", wait.String())
+			if err := sleepContext(ctx, wait); err != nil {
+				return err
+			}
+		}
+
+		resource = new(GraphQLResponse)
+		err := s.client.PostContext(ctx, graphQLPath, data, resource)
+		if err != nil {
+			return err
+		}
+
+		if resource.Extensions != nil {
+			s.client.graphQLThrottle.update(query, resource.Extensions.Cost)
+		}
+
+		if attempt >= attempts || !isThrottled(resource.Errors) {
+			break
+		}
+
+		s.client.log.Debugf("graphql query throttled, retrying")
+		if s.client.graphQLThrottle.delay(query) == 0 {
+			// no cost information, back off for a moment anyway
+			if err := sleepContext(ctx, time.Second); err != nil {
+				return err
+			}
+		}
+	}
+
+	if response != nil && len(resource.Data) > 0 && string(resource.Data) != "null" {
+		err := json.Unmarshal(resource.Data, response)
+		if err != nil {
+			return ResponseDecodingError{
+				Body:    resource.Data,
+				Message: err.Error(),
+				Status:  http.StatusOK,
+			}
+		}
+	}
+
+	if len(resource.Errors) > 0 {
+		return newGraphQLResponseError(resource.Errors)
+	}
+
+	userErrors := findUserErrors(resource.Data)
+	if len(userErrors) > 0 {
+		return newUserErrorsError(userErrors)
+	}
+
+	return nil
+}
+
+func newGraphQLResponseError(graphQLErrors []GraphQLError) GraphQLResponseError {
+	responseError := ResponseError{Status: http.StatusOK}
+	for _, e := range graphQLErrors {
+		responseError.Errors = append(responseError.Errors, e.Message)
+	}
+	responseError.Message = strings.Join(responseError.Errors, ", ")
+
+	return GraphQLResponseError{
+		ResponseError: responseError,
+		GraphQLErrors: graphQLErrors,
+	}
+}
+
+func newUserErrorsError(userErrors []UserError) UserErrorsError {
+	responseError := ResponseError{Status: http.StatusOK}
+	for _, e := range userErrors {
+		responseError.Errors = append(responseError.Errors, e.Error())
+	}
+	responseError.Message = strings.Join(responseError.Errors, ", ")
+
+	return UserErrorsError{
+		ResponseError: responseError,
+		UserErrors:    userErrors,
+	}
+}
+
+// isGraphQLQuery reports whether a document only holds queries, i.e. no
+// mutation or subscription operation.
+func isGraphQLQuery(document string) bool {
+	depth := 0
+	for i := 0; i < len(document); i++ {
+		switch c := document[i]; {
+		case c == '#':
+			for i < len(document) && document[i] != '\n' {
+				i++
+			}
+		case c == '"':
+			for i++; i < len(document) && document[i] != '"'; i++ {
+				if document[i] == '\\' {
+					i++
+				}
+			}
+		case c == '{' || c == '(':
+			depth++
+		case c == '}' || c == ')':
+			depth--
+		case depth == 0 && isGraphQLNameChar(c):
+			start := i
+			for i < len(document) && isGraphQLNameChar(document[i]) {
+				i++
+			}
+			if name := document[start:i]; name == "mutation" || name == "subscription" {
+				return false
+			}
+			i--
+		}
+	}
+	return true
+}
+
+func isGraphQLNameChar(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+func isThrottled(graphQLErrors []GraphQLError) bool {
+	return len(graphQLErrors) > 0 && newGraphQLResponseError(graphQLErrors).HasCode(graphQLThrottledCode)
+}
+
+// graphQLThrottle keeps track of the cost budget of a shop as reported by the
+// GraphQL responses and of the requested cost of the queries sent so far.
+type graphQLThrottle struct {
+	mu        sync.Mutex
+	status    GraphQLThrottleStatus
+	updatedAt time.Time
+	costs     map[string]float64
+}
+
+// maximum number of distinct queries to remember the cost of
+const maxGraphQLThrottleCosts = 1000
+
+func newGraphQLThrottle() *graphQLThrottle {
+	return &graphQLThrottle{costs: map[string]float64{}}
+}
+
+// update records the cost reported by a response to the given query.
+func (t *graphQLThrottle) update(query string, cost *GraphQLCost) {
+	if cost == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.status = cost.ThrottleStatus
+	t.updatedAt = time.Now()
+
+	if len(t.costs) >= maxGraphQLThrottleCosts {
+		t.costs = map[string]float64{}
+	}
+	t.costs[query] = cost.RequestedQueryCost
+}
+
+// available returns the budget estimated to be available right now, taking
+// into account what has been restored since the last response.
+func (t *graphQLThrottle) available() GraphQLThrottleStatus {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	status := t.status
+	if t.updatedAt.IsZero() {
+		return status
+	}
+
+	restored := status.RestoreRate * time.Since(t.updatedAt).Seconds()
+	status.CurrentlyAvailable = math.Min(status.MaximumAvailable, status.CurrentlyAvailable+restored)
+
+	return status
+}
+
+// delay returns how long to wait before the given query can be sent without
+// exceeding the available budget.
+func (t *graphQLThrottle) delay(query string) time.Duration {
+	t.mu.Lock()
+	cost, ok := t.costs[query]
+	t.mu.Unlock()
+	if !ok {
+		return 0
+	}
+
+	status := t.available()
+	if status.RestoreRate <= 0 || cost <= status.CurrentlyAvailable {
+		return 0
+	}
+
+	seconds := (cost - status.CurrentlyAvailable) / status.RestoreRate
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// findUserErrors collects the `userErrors` of every mutation payload, i.e. of
+// every top level field of data.
+func findUserErrors(data json.RawMessage) []UserError {
+	payloads := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &payloads); err != nil {
+		return nil
+	}
+
+	// keep the output stable regardless of map ordering
+	keys := make([]string, 0, len(payloads))
+	for k := range payloads {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var userErrors []UserError
+	for _, k := range keys {
+		payload := struct {
+			UserErrors []UserError `json:"userErrors"`
+		}{}
+		if err := json.Unmarshal(payloads[k], &payload); err != nil {
+			continue
+		}
+		userErrors = append(userErrors, payload.UserErrors...)
+	}
+
+	return userErrors
+}
@@ -0,0 +1,313 @@
+package synergyshopify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestGraphQLQuery(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+			request := GraphQLRequest{}
+			if err := json.Unmarshal(body, &request); err != nil {
+				t.Errorf("GraphQL.Query sent invalid body: %v", err)
+			}
+
+			expected := GraphQLRequest{
+				Query:     "query($id: ID!) { product(id: $id) { title } }",
+				Variables: map[string]interface{}{"id": "gid://shopify/Product/1"},
+			}
+			if !reflect.DeepEqual(request, expected) {
+				t.Errorf("GraphQL.Query sent %+v, expected %+v", request, expected)
+			}
+
+			if req.Header.Get("X-Shopify-Access-Token") != "abcd" {
+				t.Errorf("GraphQL.Query did not send the access token")
+			}
+
+			return httpmock.NewStringResponse(200, `{"data": {"product": {"title": "foo"}}}`), nil
+		})
+
+	resp := struct {
+		Product struct {
+			Title string `json:"title"`
+		} `json:"product"`
+	}{}
+	err := client.GraphQL.Query(
+		"query($id: ID!) { product(id: $id) { title } }",
+		map[string]interface{}{"id": "gid://shopify/Product/1"},
+		&resp,
+	)
+	if err != nil {
+		t.Fatalf("GraphQL.Query returned error: %v", err)
+	}
+
+	if resp.Product.Title != "foo" {
+		t.Errorf("GraphQL.Query returned title %q, expected %q", resp.Product.Title, "foo")
+	}
+}
+
+func TestGraphQLQueryErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{
+			"errors": [
+				{"message": "Field 'foo' doesn't exist on type 'QueryRoot'", "locations": [{"line": 1, "column": 3}], "path": ["query", "foo"], "extensions": {"code": "undefinedField"}}
+			]
+		}`))
+
+	err := client.GraphQL.Query("{ foo }", nil, nil)
+
+	expected := GraphQLResponseError{
+		ResponseError: ResponseError{
+			Status:  200,
+			Message: "Field 'foo' doesn't exist on type 'QueryRoot'",
+			Errors:  []string{"Field 'foo' doesn't exist on type 'QueryRoot'"},
+		},
+		GraphQLErrors: []GraphQLError{
+			{
+				Message:    "Field 'foo' doesn't exist on type 'QueryRoot'",
+				Locations:  []GraphQLErrorLocation{{Line: 1, Column: 3}},
+				Path:       []interface{}{"query", "foo"},
+				Extensions: map[string]interface{}{"code": "undefinedField"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(err, expected) {
+		t.Errorf("GraphQL.Query returned error %#v, expected %#v", err, expected)
+	}
+
+	if !expected.HasCode("undefinedField") {
+		t.Errorf("GraphQLResponseError.HasCode expected true")
+	}
+}
+
+func TestGraphQLQueryUserErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{
+			"data": {
+				"productCreate": {
+					"product": null,
+					"userErrors": [{"field": ["title"], "message": "Title can't be blank"}]
+				}
+			}
+		}`))
+
+	resp := struct {
+		ProductCreate struct {
+			Product *struct {
+				ID string `json:"id"`
+			} `json:"product"`
+		} `json:"productCreate"`
+	}{}
+	err := client.GraphQL.Query("mutation { productCreate(input: {}) { product { id } userErrors { field message } } }", nil, &resp)
+
+	expected := UserErrorsError{
+		ResponseError: ResponseError{
+			Status:  200,
+			Message: "title: Title can't be blank",
+			Errors:  []string{"title: Title can't be blank"},
+		},
+		UserErrors: []UserError{{Field: []string{"title"}, Message: "Title can't be blank"}},
+	}
+	if !reflect.DeepEqual(err, expected) {
+		t.Errorf("GraphQL.Query returned error %#v, expected %#v", err, expected)
+	}
+}
+
+func TestGraphQLQueryResponseError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		httpmock.NewStringResponder(401, `{"errors": "[API] Invalid API key or access token (unrecognized login or wrong password)"}`))
+
+	err := client.GraphQL.Query("{ shop { name } }", nil, nil)
+
+	expected := RequestError{
+		Method:     "POST",
+		Path:       fmt.Sprintf("/%s/graphql.json", client.pathPrefix),
+		APIVersion: testApiVersion,
+		Attempts:   1,
+		Err: ResponseError{
+			Status:  401,
+			Message: "[API] Invalid API key or access token (unrecognized login or wrong password)",
+		},
+	}
+	if !reflect.DeepEqual(err, expected) {
+		t.Errorf("GraphQL.Query returned error %#v, expected %#v", err, expected)
+	}
+}
+
+func TestGraphQLQueryThrottled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls == 1 {
+				return httpmock.NewStringResponse(200, `{
+					"errors": [{"message": "Throttled", "extensions": {"code": "THROTTLED"}}],
+					"extensions": {"cost": {"requestedQueryCost": 50, "actualQueryCost": null, "throttleStatus": {"maximumAvailable": 1000, "currentlyAvailable": 0, "restoreRate": 1000}}}
+				}`), nil
+			}
+
+			return httpmock.NewStringResponse(200, `{
+				"data": {"shop": {"name": "foo"}},
+				"extensions": {"cost": {"requestedQueryCost": 50, "actualQueryCost": 1, "throttleStatus": {"maximumAvailable": 1000, "currentlyAvailable": 999, "restoreRate": 50}}}
+			}`), nil
+		})
+
+	resp := struct {
+		Shop struct {
+			Name string `json:"name"`
+		} `json:"shop"`
+	}{}
+	err := client.GraphQL.Query("{ shop { name } }", nil, &resp)
+	if err != nil {
+		t.Fatalf("GraphQL.Query returned error: %v", err)
+	}
+
+	if calls != 2 {
+		t.Errorf("GraphQL.Query expected 2 calls, actual %d", calls)
+	}
+
+	if resp.Shop.Name != "foo" {
+		t.Errorf("GraphQL.Query returned name %q, expected %q", resp.Shop.Name, "foo")
+	}
+
+	status := client.GraphQLThrottleStatus()
+	if status.MaximumAvailable != 1000 || status.RestoreRate != 50 || status.CurrentlyAvailable < 999 {
+		t.Errorf("Client.GraphQLThrottleStatus returned %+v", status)
+	}
+}
+
+func TestGraphQLQueryThrottledExhausted(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			calls++
+			return httpmock.NewStringResponse(200, `{
+				"errors": [{"message": "Throttled", "extensions": {"code": "THROTTLED"}}],
+				"extensions": {"cost": {"requestedQueryCost": 10, "actualQueryCost": null, "throttleStatus": {"maximumAvailable": 1000, "currentlyAvailable": 0, "restoreRate": 1000}}}
+			}`), nil
+		})
+
+	err := client.GraphQL.Query("{ shop { name } }", nil, nil)
+	if e, ok := err.(GraphQLResponseError); !ok || !e.HasCode("THROTTLED") {
+		t.Errorf("GraphQL.Query expected throttled error, actual %#v", err)
+	}
+
+	if calls != maxRetries {
+		t.Errorf("GraphQL.Query expected %d calls, actual %d", maxRetries, calls)
+	}
+}
+
+func TestGraphQLThrottleDelay(t *testing.T) {
+	throttle := newGraphQLThrottle()
+
+	if d := throttle.delay("{ shop { name } }"); d != 0 {
+		t.Errorf("graphQLThrottle.delay for unknown query expected 0, actual %s", d)
+	}
+
+	throttle.update("{ shop { name } }", &GraphQLCost{
+		RequestedQueryCost: 110,
+		ThrottleStatus: GraphQLThrottleStatus{
+			MaximumAvailable:   1000,
+			CurrentlyAvailable: 10,
+			RestoreRate:        50,
+		},
+	})
+
+	d := throttle.delay("{ shop { name } }")
+	if d <= 1900*time.Millisecond || d > 2*time.Second {
+		t.Errorf("graphQLThrottle.delay expected about 2s, actual %s", d)
+	}
+
+	if d := throttle.delay("{ products { id } }"); d != 0 {
+		t.Errorf("graphQLThrottle.delay for unknown query expected 0, actual %s", d)
+	}
+}
+
+func TestGraphQLQueryRetriesUnavailable(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls == 1 {
+				return httpmock.NewStringResponse(503, ``), nil
+			}
+			return httpmock.NewStringResponse(200, `{"data": {"shop": {"name": "foo"}}}`), nil
+		})
+
+	if err := client.GraphQL.Query("{ shop { name } }", nil, nil); err != nil || calls != 2 {
+		t.Errorf("GraphQL.Query returned %v after %d calls, expected the query to be retried", err, calls)
+	}
+
+	calls = 0
+	err := client.GraphQL.Query(`mutation { productDelete(input: {id: "gid://shopify/Product/1"}) { deletedProductId } }`, nil, nil)
+	if err == nil || calls != 1 {
+		t.Errorf("GraphQL.Query returned %v after %d calls, expected the mutation not to be retried", err, calls)
+	}
+}
+
+func TestIsGraphQLQuery(t *testing.T) {
+	cases := map[string]bool{
+		"{ shop { name } }": true,
+		"query($id: ID!) { product(id: $id) { title } }":                                      true,
+		"# mutation\nquery Shop { shop { name } }":                                            true,
+		`query { products(query: "mutation") { edges { node { id } } } }`:                     true,
+		"fragment F on Product { id }\nquery { product(id: 1) { ...F } }":                     true,
+		"mutation($input: ProductInput!) { productCreate(input: $input) { product { id } } }": false,
+		"query A { shop { name } }\nmutation B { x { y } }":                                   false,
+		"subscription { x }": false,
+	}
+	for document, expected := range cases {
+		if actual := isGraphQLQuery(document); actual != expected {
+			t.Errorf("isGraphQLQuery(%q) = %v, expected %v", document, actual, expected)
+		}
+	}
+}
+
+func TestGraphQLQueryContext(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"data": {"shop": {"name": "foo"}}}`))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := client.GraphQL.QueryContext(ctx, "{ shop { name } }", nil, nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("GraphQL.QueryContext returned %v, expected %v", err, context.Canceled)
+	}
+	if calls := httpmock.GetTotalCallCount(); calls != 0 {
+		t.Errorf("expected no request with a canceled context, got %d", calls)
+	}
+}
@@ -0,0 +1,445 @@
+// Package importer creates and updates products from a CSV in the product
+// layout of the Shopify admin, as written by the export package:
+//
+//	report, err := importer.Import(ctx, client, f, importer.Options{DryRun: true})
+//	for _, p := range report.Products {
+//		fmt.Println(p.Handle, p.Action, p.Changes)
+//	}
+//	for _, err := range report.Errors {
+//		fmt.Println(err)
+//	}
+//
+// Products are matched by handle, their variants by SKU or option values and
+// their images by file name. Only the non-empty cells of a CSV are imported,
+// an empty cell leaving the existing value as it is.
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+
+	shopify "github.com/binodsynergytechs/synergyshopify"
+	"github.com/binodsynergytechs/synergyshopify/export"
+)
+
+// Options configures an import.
+type Options struct {
+	// DryRun reports the changes of the import without making them.
+	DryRun bool
+
+	// LocationID is the location whose inventory the Variant Inventory Qty
+	// column sets. Without it, the quantity is sent as the inventory_quantity
+	// of the variants, which only older API versions accept.
+	LocationID int64
+}
+
+// Action is what an import does to a product.
+type Action string
+
+// Import actions.
+const (
+	ActionCreate    Action = "create"
+	ActionUpdate    Action = "update"
+	ActionUnchanged Action = "unchanged"
+	// ActionSkip is the action of products with invalid rows.
+	ActionSkip Action = "skip"
+	// ActionFail is the action of products whose changes failed, possibly
+	// after some of them were made.
+	ActionFail Action = "fail"
+)
+
+// ProductReport is the outcome of the import of a product.
+type ProductReport struct {
+	Handle string
+	// Line is the line of the first row of the product.
+	Line      int
+	Action    Action
+	ProductID int64
+	// Changes describes the changes of an update, e.g. `title: "Shirt" -> "T-Shirt"`.
+	Changes []string
+}
+
+// Report is the outcome of an import.
+type Report struct {
+	DryRun   bool
+	Products []ProductReport
+	Errors   []*RowError
+}
+
+// Count returns the number of products imported with the given action.
+func (r *Report) Count(action Action) int {
+	n := 0
+	for _, p := range r.Products {
+		if p.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+// Import parses a product CSV, see Parse, and creates the products missing
+// from the shop and updates the others, one product at a time. The products
+// with invalid rows are skipped and the invalid rows and failed changes
+// collected as the Errors of the report.
+func Import(ctx context.Context, client *shopify.Client, r io.Reader, opts Options) (*Report, error) {
+	products, errs, err := Parse(r)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{DryRun: opts.DryRun, Errors: errs}
+	invalid := map[string]bool{}
+	for _, err := range errs {
+		invalid[err.Handle] = true
+	}
+
+	im := &importer{client: client.WithContext(ctx), opts: opts}
+	for _, p := range products {
+		if invalid[p.Handle] {
+			report.Products = append(report.Products, ProductReport{Handle: p.Handle, Line: p.Line, Action: ActionSkip})
+			continue
+		}
+
+		result, err := im.importProduct(p)
+		if err != nil {
+			if ctx.Err() != nil {
+				return report, ctx.Err()
+			}
+
+			var rowErr *RowError
+			if !errors.As(err, &rowErr) {
+				rowErr = &RowError{Line: p.Line, Err: err}
+			}
+			rowErr.Handle = p.Handle
+			report.Errors = append(report.Errors, rowErr)
+			result.Action = ActionFail
+		}
+		report.Products = append(report.Products, result)
+	}
+
+	return report, nil
+}
+
+// importer imports the products of a CSV.
+type importer struct {
+	client *shopify.Client
+	opts   Options
+}
+
+// importProduct creates or updates a product.
+func (im *importer) importProduct(p Product) (ProductReport, error) {
+	result := ProductReport{Handle: p.Handle, Line: p.Line}
+
+	products, err := im.client.Product.List(shopify.ProductListOptions{Handle: p.Handle})
+	if err != nil {
+		return result, err
+	}
+	for i := range products {
+		if products[i].Handle == p.Handle {
+			result.ProductID = products[i].ID
+			return result, im.update(p, &products[i], &result)
+		}
+	}
+
+	return result, im.create(p, &result)
+}
+
+// create creates a product with its variants and images.
+func (im *importer) create(p Product, result *ProductReport) error {
+	result.Action = ActionCreate
+
+	product := fields(productFields, p.Fields)
+	product["handle"] = p.Handle
+
+	var options []map[string]interface{}
+	for i, columns := range optionColumns {
+		name := p.Fields[columns[0]]
+		for _, v := range p.Variants {
+			if v.Fields[columns[1]] != "" && name == "" {
+				return &RowError{Line: v.Line, Column: columns[1], Err: fmt.Errorf("no option%d name", i+1)}
+			}
+		}
+		if name != "" {
+			options = append(options, map[string]interface{}{"name": name})
+		}
+	}
+	if options != nil {
+		product["options"] = options
+	}
+
+	var variants []map[string]interface{}
+	for _, v := range p.Variants {
+		variants = append(variants, im.variantFields(v.Fields))
+	}
+	if variants != nil {
+		product["variants"] = variants
+	}
+
+	var images []map[string]interface{}
+	for _, image := range p.Images {
+		images = append(images, map[string]interface{}{"src": image.Src, "position": image.Position})
+	}
+	if images != nil {
+		product["images"] = images
+	}
+
+	if im.opts.DryRun {
+		return nil
+	}
+
+	resource := new(shopify.ProductResource)
+	if err := im.client.Post("products.json", map[string]interface{}{"product": product}, resource); err != nil {
+		return err
+	}
+	result.ProductID = resource.Product.ID
+
+	for i, v := range p.Variants {
+		if i < len(resource.Product.Variants) {
+			if err := im.setInventory(v, resource.Product.Variants[i].InventoryItemId); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// update updates the changed fields, variants and images of a product.
+func (im *importer) update(p Product, existing *shopify.Product, result *ProductReport) error {
+	rows := export.ProductRows(*existing, nil)
+	cells := func(row int) map[string]string {
+		m := make(map[string]string, len(export.ProductColumns))
+		for i, column := range export.ProductColumns {
+			m[column] = rows[row][i]
+		}
+		return m
+	}
+
+	product, changes := diff(productFields, p.Fields, cells(0), "")
+	result.Changes = append(result.Changes, changes...)
+
+	type variantUpdate struct {
+		csv       Variant
+		id        int64
+		item      int64
+		fields    map[string]interface{}
+		inventory bool
+	}
+	var creates []Variant
+	var updates []variantUpdate
+	for _, v := range p.Variants {
+		i := matchVariant(v, existing.Variants)
+		if i < 0 {
+			creates = append(creates, v)
+			result.Changes = append(result.Changes, "new variant "+v.label())
+			continue
+		}
+
+		current := cells(i)
+		cellsToSend := v.Fields
+		if im.opts.LocationID != 0 {
+			cellsToSend = withoutInventory(cellsToSend)
+		}
+		prefix := "variant " + v.label() + ": "
+		u := variantUpdate{csv: v, id: existing.Variants[i].ID, item: existing.Variants[i].InventoryItemId}
+
+		var changes []string
+		u.fields, changes = diff(variantFields, cellsToSend, current, prefix)
+		qty, ok := v.Fields[export.ColumnVariantInventoryQty]
+		if ok && im.opts.LocationID != 0 && !variantFields[export.ColumnVariantInventoryQty].equal(qty, current[export.ColumnVariantInventoryQty]) {
+			u.inventory = true
+			changes = append(changes, fmt.Sprintf("%sinventory %q -> %q", prefix, current[export.ColumnVariantInventoryQty], qty))
+		}
+		if len(changes) > 0 {
+			result.Changes = append(result.Changes, changes...)
+			updates = append(updates, u)
+		}
+	}
+
+	var newImages []Image
+	var movedImages []shopify.Image
+	for _, image := range p.Images {
+		i := matchImage(image, existing.Images)
+		if i < 0 {
+			newImages = append(newImages, image)
+			result.Changes = append(result.Changes, "new image "+image.Src)
+			continue
+		}
+		if current := existing.Images[i]; current.Position != image.Position {
+			movedImages = append(movedImages, shopify.Image{ID: current.ID, Position: image.Position})
+			result.Changes = append(result.Changes, fmt.Sprintf("image %s: position %d -> %d", image.Src, current.Position, image.Position))
+		}
+	}
+
+	if len(result.Changes) == 0 {
+		result.Action = ActionUnchanged
+		return nil
+	}
+	result.Action = ActionUpdate
+	if im.opts.DryRun {
+		return nil
+	}
+
+	if len(product) > 0 {
+		product["id"] = existing.ID
+		path := fmt.Sprintf("products/%d.json", existing.ID)
+		if err := im.client.Put(path, map[string]interface{}{"product": product}, nil); err != nil {
+			return &RowError{Line: p.Line, Err: err}
+		}
+	}
+
+	for _, v := range creates {
+		resource := new(shopify.VariantResource)
+		path := fmt.Sprintf("products/%d/variants.json", existing.ID)
+		if err := im.client.Post(path, map[string]interface{}{"variant": im.variantFields(v.Fields)}, resource); err != nil {
+			return &RowError{Line: v.Line, Err: err}
+		}
+		if err := im.setInventory(v, resource.Variant.InventoryItemId); err != nil {
+			return &RowError{Line: v.Line, Err: err}
+		}
+	}
+
+	for _, u := range updates {
+		if len(u.fields) > 0 {
+			u.fields["id"] = u.id
+			path := fmt.Sprintf("variants/%d.json", u.id)
+			if err := im.client.Put(path, map[string]interface{}{"variant": u.fields}, nil); err != nil {
+				return &RowError{Line: u.csv.Line, Err: err}
+			}
+		}
+		if u.inventory {
+			if err := im.setInventory(u.csv, u.item); err != nil {
+				return &RowError{Line: u.csv.Line, Err: err}
+			}
+		}
+	}
+
+	for _, image := range newImages {
+		if _, err := im.client.Image.Create(existing.ID, shopify.Image{Src: image.Src, Position: image.Position}); err != nil {
+			return &RowError{Line: image.Line, Err: err}
+		}
+	}
+	for _, image := range movedImages {
+		if _, err := im.client.Image.Update(existing.ID, image); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// variantFields returns the fields of a new variant, its inventory being set
+// separately with a location.
+func (im *importer) variantFields(cells map[string]string) map[string]interface{} {
+	if im.opts.LocationID != 0 {
+		cells = withoutInventory(cells)
+	}
+	return fields(variantFields, cells)
+}
+
+// setInventory sets the inventory of a variant at the location of the
+// options, if both are given.
+func (im *importer) setInventory(v Variant, inventoryItemID int64) error {
+	qty, ok := v.Fields[export.ColumnVariantInventoryQty]
+	if im.opts.LocationID == 0 || !ok || inventoryItemID == 0 {
+		return nil
+	}
+
+	available, _ := variantFields[export.ColumnVariantInventoryQty].value(qty)
+	_, err := im.client.InventoryLevel.Set(shopify.InventoryLevel{
+		InventoryItemId: inventoryItemID,
+		LocationId:      im.opts.LocationID,
+		Available:       available.(int),
+	})
+	return err
+}
+
+// fields returns the JSON fields set by the given cells.
+func fields(columns map[string]field, cells map[string]string) map[string]interface{} {
+	m := map[string]interface{}{}
+	for column, cell := range cells {
+		if f, ok := columns[column]; ok {
+			// the cells were validated by Parse
+			m[f.name], _ = f.value(cell)
+		}
+	}
+	return m
+}
+
+// diff returns the JSON fields of the cells differing from the current ones,
+// and a description of the changes, in column order.
+func diff(columns map[string]field, cells, current map[string]string, prefix string) (map[string]interface{}, []string) {
+	changed := map[string]interface{}{}
+	var changes []string
+
+	for _, column := range export.ProductColumns {
+		f, ok := columns[column]
+		cell, set := cells[column]
+		if !ok || !set || f.equal(cell, current[column]) {
+			continue
+		}
+		changed[f.name], _ = f.value(cell)
+		changes = append(changes, fmt.Sprintf("%s%s: %q -> %q", prefix, f.name, current[column], cell))
+	}
+
+	return changed, changes
+}
+
+// withoutInventory returns the cells without the inventory quantity.
+func withoutInventory(cells map[string]string) map[string]string {
+	if _, ok := cells[export.ColumnVariantInventoryQty]; !ok {
+		return cells
+	}
+
+	m := make(map[string]string, len(cells))
+	for column, cell := range cells {
+		if column != export.ColumnVariantInventoryQty {
+			m[column] = cell
+		}
+	}
+	return m
+}
+
+// matchVariant returns the index of the existing variant with the SKU of the
+// variant, or else its option values, -1 if none.
+func matchVariant(v Variant, variants []shopify.Variant) int {
+	if sku := v.Fields[export.ColumnVariantSKU]; sku != "" {
+		for i, existing := range variants {
+			if existing.Sku == sku {
+				return i
+			}
+		}
+	}
+
+	options := v.options()
+	for i, existing := range variants {
+		if [3]string{existing.Option1, existing.Option2, existing.Option3} == options {
+			return i
+		}
+	}
+	return -1
+}
+
+// matchImage returns the index of the existing image with the file name of
+// the image, Shopify serving images from its own CDN, -1 if none.
+func matchImage(image Image, images []shopify.Image) int {
+	name := fileName(image.Src)
+	for i, existing := range images {
+		if existing.Src == image.Src || fileName(existing.Src) == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// fileName returns the file name of an image URL.
+func fileName(src string) string {
+	u, err := url.Parse(src)
+	if err != nil {
+		return src
+	}
+	return path.Base(u.Path)
+}
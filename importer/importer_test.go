@@ -0,0 +1,212 @@
+package importer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	shopify "github.com/binodsynergytechs/synergyshopify"
+	"github.com/binodsynergytechs/synergyshopify/export"
+	"github.com/binodsynergytechs/synergyshopify/shopifytest"
+)
+
+const productsCSV = "\ufeffHandle,Title,Vendor,Option1 Name,Option1 Value,Variant SKU,Variant Price,Variant Inventory Qty,Image Src,Image Position,Comment\n" +
+	"shirt,Shirt,Acme,Size,S,SHIRT-S,12,5,https://example.com/front.png,1,ignored\n" +
+	"shirt,,,,L,SHIRT-L,12.50,3,https://example.com/back.png,2,\n" +
+	"hat,Hat,Acme,,,HAT,8.00,,,,\n"
+
+func TestParse(t *testing.T) {
+	products, errs, err := Parse(strings.NewReader(productsCSV))
+	if err != nil || len(errs) != 0 {
+		t.Fatalf("Parse returned %v, %v", errs, err)
+	}
+	if len(products) != 2 {
+		t.Fatalf("expected 2 products, got %+v", products)
+	}
+
+	shirt := products[0]
+	if shirt.Handle != "shirt" || shirt.Line != 2 || shirt.Fields[export.ColumnTitle] != "Shirt" || shirt.Fields[export.ColumnOption1Name] != "Size" {
+		t.Errorf("unexpected product %+v", shirt)
+	}
+	if len(shirt.Variants) != 2 || shirt.Variants[1].Line != 3 || shirt.Variants[1].Fields[export.ColumnVariantPrice] != "12.50" {
+		t.Errorf("unexpected variants %+v", shirt.Variants)
+	}
+	expected := []Image{{Line: 2, Src: "https://example.com/front.png", Position: 1}, {Line: 3, Src: "https://example.com/back.png", Position: 2}}
+	if !reflect.DeepEqual(shirt.Images, expected) {
+		t.Errorf("unexpected images %+v", shirt.Images)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	_, _, err := Parse(strings.NewReader("Title,Vendor\nShirt,Acme\n"))
+	if err == nil || !strings.Contains(err.Error(), "missing Handle column") {
+		t.Errorf("expected a missing column error, got %v", err)
+	}
+
+	csv := "Handle,Title,Option1 Name,Option1 Value,Variant Price,Variant Taxable\n" +
+		"shirt,Shirt,Size,S,ten,\n" +
+		",Orphan,,,,\n" +
+		"hat,Hat,Size,M,8,\n" +
+		"hat,,,M,9,maybe\n"
+	products, errs, err := Parse(strings.NewReader(csv))
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if len(products) != 2 {
+		t.Errorf("expected the products of the invalid rows, got %+v", products)
+	}
+
+	var messages []string
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	expected := []string{
+		`line 2 (shirt): Variant Price: invalid price "ten"`,
+		`line 3: Handle: missing handle`,
+		`line 5 (hat): Variant Taxable: invalid boolean "maybe"`,
+		`line 5 (hat): duplicate variant M of line 4`,
+	}
+	if !reflect.DeepEqual(messages, expected) {
+		t.Errorf("Parse returned errors\n%q, expected\n%q", messages, expected)
+	}
+}
+
+func TestImport(t *testing.T) {
+	server := shopifytest.NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	existing, err := client.Product.Create(shopify.Product{
+		Title:    "Shirt",
+		Vendor:   "Acme",
+		Options:  []shopify.ProductOption{{Name: "Size", Position: 1, Values: []string{"S"}}},
+		Variants: []shopify.Variant{{Option1: "S", Sku: "SHIRT-S", Price: "10.00", InventoryQuantity: 5}},
+		Images:   []shopify.Image{{Src: "https://cdn.shopify.com/s/files/front.png?v=1", Position: 1}},
+	})
+	if err != nil {
+		t.Fatalf("Product.Create returned error: %v", err)
+	}
+
+	before := len(server.Requests())
+	report, err := Import(context.Background(), client, strings.NewReader(productsCSV), Options{DryRun: true})
+	if err != nil {
+		t.Fatalf("Import returned error: %v", err)
+	}
+	if len(report.Errors) != 0 || report.Count(ActionUpdate) != 1 || report.Count(ActionCreate) != 1 {
+		t.Fatalf("unexpected report %+v, errors %v", report.Products, report.Errors)
+	}
+	expected := []string{
+		`variant SHIRT-S: price: "10.00" -> "12"`,
+		"new variant SHIRT-L",
+		"new image https://example.com/back.png",
+	}
+	if shirt := report.Products[0]; shirt.ProductID != existing.ID || !reflect.DeepEqual(shirt.Changes, expected) {
+		t.Errorf("unexpected report of the update %+v, expected changes %q", shirt, expected)
+	}
+	for _, r := range server.Requests()[before:] {
+		if r.Method != "GET" {
+			t.Errorf("unexpected %s %s in a dry run", r.Method, r.Path)
+		}
+	}
+
+	report, err = Import(context.Background(), client, strings.NewReader(productsCSV), Options{})
+	if err != nil || len(report.Errors) != 0 {
+		t.Fatalf("Import returned %v, %v", report.Errors, err)
+	}
+
+	shirt, err := client.Product.Get(existing.ID, nil)
+	if err != nil {
+		t.Fatalf("Product.Get returned error: %v", err)
+	}
+	if len(shirt.Variants) != 2 || shirt.Variants[0].Price != "12" || shirt.Variants[1].Sku != "SHIRT-L" || len(shirt.Images) != 2 {
+		t.Errorf("unexpected updated product %+v", shirt)
+	}
+
+	hats, err := client.Product.List(shopify.ProductListOptions{Handle: "hat"})
+	if err != nil || len(hats) != 1 || hats[0].ID != report.Products[1].ProductID || hats[0].Variants[0].Sku != "HAT" {
+		t.Errorf("unexpected created product %+v, %v", hats, err)
+	}
+
+	report, err = Import(context.Background(), client, strings.NewReader(productsCSV), Options{DryRun: true})
+	if err != nil || report.Count(ActionUnchanged) != 2 {
+		t.Errorf("expected a second import to change nothing, got %+v, %v", report.Products, err)
+	}
+}
+
+func TestImportErrors(t *testing.T) {
+	server := shopifytest.NewServer()
+	defer server.Close()
+	server.InjectFault(shopifytest.Fault{Status: 422, Method: "POST", Path: "products.json", Body: `{"errors": {"title": ["is too long"]}}`})
+
+	csv := "Handle,Title,Option1 Value,Variant Price\n" +
+		"shirt,Shirt,,ten\n" +
+		"hat,Hat,,8\n" +
+		"cap,Cap,Red,8\n"
+	report, err := Import(context.Background(), server.Client(), strings.NewReader(csv), Options{})
+	if err != nil {
+		t.Fatalf("Import returned error: %v", err)
+	}
+
+	actions := []Action{report.Products[0].Action, report.Products[1].Action, report.Products[2].Action}
+	if !reflect.DeepEqual(actions, []Action{ActionSkip, ActionFail, ActionFail}) {
+		t.Errorf("unexpected actions %v", actions)
+	}
+	if len(report.Errors) != 3 {
+		t.Fatalf("expected 3 errors, got %v", report.Errors)
+	}
+	var validation shopify.ValidationError
+	if err := report.Errors[1]; err.Line != 3 || err.Handle != "hat" || !errors.As(err, &validation) {
+		t.Errorf("expected the validation error of hat, got %v", err)
+	}
+	if err := report.Errors[2]; err.Line != 4 || !strings.Contains(err.Error(), "no option1 name") {
+		t.Errorf("expected the missing option name of cap, got %v", err)
+	}
+}
+
+func TestImportInventory(t *testing.T) {
+	server := shopifytest.NewServer()
+	defer server.Close()
+	client := server.Client()
+	const locationID = 655441491
+
+	report, err := Import(context.Background(), client, strings.NewReader(productsCSV), Options{LocationID: locationID})
+	if err != nil || len(report.Errors) != 0 {
+		t.Fatalf("Import returned %v, %v", report.Errors, err)
+	}
+
+	shirt, _ := client.Product.Get(report.Products[0].ProductID, nil)
+	levels, err := client.InventoryLevel.List(shopify.InventoryLevelListOptions{LocationIds: []int64{locationID}})
+	if err != nil || len(levels) != 2 {
+		t.Fatalf("expected the inventory of both shirts to be set, got %+v, %v", levels, err)
+	}
+	if levels[0].InventoryItemId != shirt.Variants[0].InventoryItemId || levels[0].Available != 5 || levels[1].Available != 3 {
+		t.Errorf("unexpected inventory levels %+v", levels)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	server := shopifytest.NewServer()
+	defer server.Close()
+	client := server.Client()
+	if _, err := Import(context.Background(), client, strings.NewReader(productsCSV), Options{}); err != nil {
+		t.Fatalf("Import returned error: %v", err)
+	}
+
+	var out bytes.Buffer
+	if _, err := export.Products(context.Background(), client, &out, export.Options{Format: export.FormatShopifyCSV}); err != nil {
+		t.Fatalf("export.Products returned error: %v", err)
+	}
+
+	report, err := Import(context.Background(), client, &out, Options{DryRun: true})
+	if err != nil || len(report.Errors) != 0 {
+		t.Fatalf("Import returned %v, %v", report.Errors, err)
+	}
+	for _, p := range report.Products {
+		if p.Action != ActionUnchanged {
+			t.Errorf("expected the exported product %s to be unchanged, got %s %q", p.Handle, p.Action, p.Changes)
+		}
+	}
+}
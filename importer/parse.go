@@ -0,0 +1,340 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/binodsynergytechs/synergyshopify/export"
+)
+
+// kind is the type of the values of a column.
+type kind int
+
+const (
+	text kind = iota
+	boolean
+	integer
+	price
+)
+
+// field is the product or variant field a column sets.
+type field struct {
+	name string
+	kind kind
+}
+
+// productFields are the columns setting product fields, read from the first
+// row of a product.
+var productFields = map[string]field{
+	export.ColumnTitle:          {"title", text},
+	export.ColumnBody:           {"body_html", text},
+	export.ColumnVendor:         {"vendor", text},
+	export.ColumnType:           {"product_type", text},
+	export.ColumnTags:           {"tags", text},
+	export.ColumnPublished:      {"published", boolean},
+	export.ColumnSEOTitle:       {"metafields_global_title_tag", text},
+	export.ColumnSEODescription: {"metafields_global_description_tag", text},
+	export.ColumnStatus:         {"status", text},
+}
+
+// variantFields are the columns setting variant fields.
+var variantFields = map[string]field{
+	export.ColumnOption1Value:            {"option1", text},
+	export.ColumnOption2Value:            {"option2", text},
+	export.ColumnOption3Value:            {"option3", text},
+	export.ColumnVariantSKU:              {"sku", text},
+	export.ColumnVariantGrams:            {"grams", integer},
+	export.ColumnVariantInventoryTracker: {"inventory_management", text},
+	export.ColumnVariantInventoryQty:     {"inventory_quantity", integer},
+	export.ColumnVariantInventoryPolicy:  {"inventory_policy", text},
+	export.ColumnVariantFulfillment:      {"fulfillment_service", text},
+	export.ColumnVariantPrice:            {"price", price},
+	export.ColumnVariantCompareAtPrice:   {"compare_at_price", price},
+	export.ColumnVariantRequiresShipping: {"requires_shipping", boolean},
+	export.ColumnVariantTaxable:          {"taxable", boolean},
+	export.ColumnVariantBarcode:          {"barcode", text},
+	export.ColumnVariantWeightUnit:       {"weight_unit", text},
+	export.ColumnVariantTaxCode:          {"tax_code", text},
+}
+
+// optionColumns are the name and value columns of the three options.
+var optionColumns = [][2]string{
+	{export.ColumnOption1Name, export.ColumnOption1Value},
+	{export.ColumnOption2Name, export.ColumnOption2Value},
+	{export.ColumnOption3Name, export.ColumnOption3Value},
+}
+
+// value parses a cell into the JSON value of the field.
+func (f field) value(cell string) (interface{}, error) {
+	switch f.kind {
+	case boolean:
+		b, err := strconv.ParseBool(cell)
+		if err != nil {
+			return nil, fmt.Errorf("invalid boolean %q", cell)
+		}
+		return b, nil
+	case integer:
+		n, err := strconv.Atoi(cell)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer %q", cell)
+		}
+		return n, nil
+	case price:
+		d, err := decimal.NewFromString(cell)
+		if err != nil || d.IsNegative() {
+			return nil, fmt.Errorf("invalid price %q", cell)
+		}
+	}
+	return cell, nil
+}
+
+// equal reports whether two cells hold the same value, e.g. "10" and "10.00"
+// for a price.
+func (f field) equal(a, b string) bool {
+	if f.kind == price {
+		da, errA := decimal.NewFromString(a)
+		db, errB := decimal.NewFromString(b)
+		if errA == nil && errB == nil {
+			return da.Equal(db)
+		}
+	}
+
+	va, errA := f.value(a)
+	vb, errB := f.value(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return va == vb
+}
+
+// Product is a product of a CSV, its columns holding the non-empty cells of
+// its rows.
+type Product struct {
+	Handle string
+	// Line is the line of its first row.
+	Line int
+
+	// Fields are the product and option name columns of its first row.
+	Fields   map[string]string
+	Variants []Variant
+	Images   []Image
+}
+
+// Variant is a variant of a product of a CSV.
+type Variant struct {
+	Line   int
+	Fields map[string]string
+}
+
+// Image is an image of a product of a CSV.
+type Image struct {
+	Line     int
+	Src      string
+	Position int
+}
+
+// label names a variant in reports, by its SKU or its option values.
+func (v Variant) label() string {
+	if sku := v.Fields[export.ColumnVariantSKU]; sku != "" {
+		return sku
+	}
+
+	var values []string
+	for _, columns := range optionColumns {
+		if value := v.Fields[columns[1]]; value != "" {
+			values = append(values, value)
+		}
+	}
+	if len(values) == 0 {
+		return fmt.Sprintf("line %d", v.Line)
+	}
+	return strings.Join(values, " / ")
+}
+
+// options returns the option values of a variant.
+func (v Variant) options() [3]string {
+	return [3]string{
+		v.Fields[export.ColumnOption1Value],
+		v.Fields[export.ColumnOption2Value],
+		v.Fields[export.ColumnOption3Value],
+	}
+}
+
+// RowError is an invalid row of a CSV, or a failed change of the product of
+// the row.
+type RowError struct {
+	Line   int
+	Handle string
+	// Column is the invalid column, if any.
+	Column string
+	Err    error
+}
+
+func (e *RowError) Error() string {
+	msg := fmt.Sprintf("line %d", e.Line)
+	if e.Handle != "" {
+		msg += " (" + e.Handle + ")"
+	}
+	if e.Column != "" {
+		msg += ": " + e.Column
+	}
+	return msg + ": " + e.Err.Error()
+}
+
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+// Parse reads a CSV in the product layout of the Shopify admin, whose rows are
+// grouped by the Handle column: the first row of a product holds its fields
+// and option names, and every row one of its variants and one of its images.
+// Unknown columns are ignored.
+//
+// The invalid rows are returned as RowErrors, the other rows of their
+// products still being parsed. Only an unreadable CSV or one without a Handle
+// column is an error.
+func Parse(r io.Reader) ([]Product, []*RowError, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("importer: reading header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			// spreadsheets often save a byte order mark
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		columns[strings.TrimSpace(name)] = i
+	}
+	if _, ok := columns[export.ColumnHandle]; !ok {
+		return nil, nil, fmt.Errorf("importer: missing %s column", export.ColumnHandle)
+	}
+
+	var products []Product
+	var errs []*RowError
+	index := map[string]int{}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			errs = append(errs, &RowError{Line: parseErr.StartLine, Err: parseErr.Err})
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		cells := make(map[string]string, len(columns))
+		for name, i := range columns {
+			if i < len(record) {
+				if cell := strings.TrimSpace(record[i]); cell != "" {
+					cells[name] = cell
+				}
+			}
+		}
+		if len(cells) == 0 {
+			continue
+		}
+
+		handle := cells[export.ColumnHandle]
+		if handle == "" {
+			errs = append(errs, &RowError{Line: line, Column: export.ColumnHandle, Err: errors.New("missing handle")})
+			continue
+		}
+
+		i, ok := index[handle]
+		if !ok {
+			i = len(products)
+			index[handle] = i
+			products = append(products, Product{Handle: handle, Line: line, Fields: map[string]string{}})
+		}
+		errs = append(errs, products[i].add(line, cells, !ok)...)
+	}
+
+	return products, errs, nil
+}
+
+// add adds a row to the product.
+func (p *Product) add(line int, cells map[string]string, first bool) []*RowError {
+	var errs []*RowError
+	invalid := func(column string, err error) {
+		errs = append(errs, &RowError{Line: line, Handle: p.Handle, Column: column, Err: err})
+	}
+
+	if first {
+		for _, column := range export.ProductColumns {
+			f, ok := productFields[column]
+			if cell, set := cells[column]; ok && set {
+				if _, err := f.value(cell); err != nil {
+					invalid(column, err)
+					continue
+				}
+				p.Fields[column] = cell
+			}
+		}
+		for _, columns := range optionColumns {
+			if name, ok := cells[columns[0]]; ok {
+				p.Fields[columns[0]] = name
+			}
+		}
+	}
+
+	variant := Variant{Line: line, Fields: map[string]string{}}
+	for _, column := range export.ProductColumns {
+		f, ok := variantFields[column]
+		if cell, set := cells[column]; ok && set {
+			if _, err := f.value(cell); err != nil {
+				invalid(column, err)
+				continue
+			}
+			variant.Fields[column] = cell
+		}
+	}
+	if len(variant.Fields) > 0 {
+		for _, other := range p.Variants {
+			if other.options() == variant.options() {
+				invalid("", fmt.Errorf("duplicate variant %s of line %d", variant.label(), other.Line))
+			}
+			if sku := variant.Fields[export.ColumnVariantSKU]; sku != "" && other.Fields[export.ColumnVariantSKU] == sku {
+				invalid(export.ColumnVariantSKU, fmt.Errorf("duplicate SKU %q of line %d", sku, other.Line))
+			}
+		}
+		p.Variants = append(p.Variants, variant)
+	}
+
+	if src, ok := cells[export.ColumnImageSrc]; ok {
+		image := Image{Line: line, Src: src, Position: len(p.Images) + 1}
+		if position, ok := cells[export.ColumnImagePosition]; ok {
+			n, err := strconv.Atoi(position)
+			if err != nil || n < 1 {
+				invalid(export.ColumnImagePosition, fmt.Errorf("invalid position %q", position))
+			} else {
+				image.Position = n
+			}
+		}
+
+		duplicate := false
+		for _, other := range p.Images {
+			duplicate = duplicate || other.Src == src
+		}
+		if !duplicate {
+			p.Images = append(p.Images, image)
+		}
+	}
+
+	return errs
+}
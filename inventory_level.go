@@ -12,6 +12,7 @@ const inventoryLevelsBasePath = "inventory_levels"
 // See https://help.shopify.com/en/api/reference/inventory/inventorylevel
 type InventoryLevelService interface {
 	List(interface{}) ([]InventoryLevel, error)
+	ListWithPagination(interface{}) ([]InventoryLevel, *Pagination, error)
 	Adjust(interface{}) (*InventoryLevel, error)
 	Delete(int64, int64) error
 	Connect(InventoryLevel) (*InventoryLevel, error)
@@ -60,10 +61,24 @@ type InventoryLevelAdjustOptions struct {
 
 // List inventory levels
 func (s *InventoryLevelServiceOp) List(options interface{}) ([]InventoryLevel, error) {
+	inventoryLevels, _, err := s.ListWithPagination(options)
+	if err != nil {
+		return nil, err
+	}
+	return inventoryLevels, nil
+}
+
+// ListWithPagination lists inventory levels and return pagination to retrieve next/previous results.
+func (s *InventoryLevelServiceOp) ListWithPagination(options interface{}) ([]InventoryLevel, *Pagination, error) {
 	path := fmt.Sprintf("%s.json", inventoryLevelsBasePath)
 	resource := new(InventoryLevelsResource)
-	err := s.client.Get(path, resource, options, true)
-	return resource.InventoryLevels, err
+
+	pagination, err := s.client.ListWithPagination(path, resource, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resource.InventoryLevels, pagination, nil
 }
 
 // Delete an inventory level
@@ -0,0 +1,187 @@
+package synergyshopify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-querystring/query"
+)
+
+// Iterator walks every page of a list endpoint lazily, fetching the next page
+// only once the items of the current one have been consumed.
+//
+//	it := Iterate(client.Product.ListWithPagination, ProductListOptions{Vendor: "foo"})
+//	for it.Next() {
+//		product := it.Value()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+//
+// Stopping early is done by no longer calling Next. An Iterator is not safe
+// for concurrent use.
+type Iterator[T any] struct {
+	list     func(interface{}) ([]T, *Pagination, error)
+	original interface{}
+	options  interface{}
+
+	items   []T
+	current T
+	pages   int
+	done    bool
+	err     error
+}
+
+// Iterate returns an iterator over all the results of a ListWithPagination
+// method of a service, e.g. client.Order.ListWithPagination, starting with the
+// given options. The following pages only keep the `limit` and `fields` of
+// the options since Shopify rejects any other filter along with a page_info.
+func Iterate[T any](list func(options interface{}) ([]T, *Pagination, error), options interface{}) *Iterator[T] {
+	return &Iterator[T]{
+		list:     list,
+		original: options,
+		options:  options,
+	}
+}
+
+// IteratePath returns an iterator over all the results of the list endpoint at
+// the given path, e.g. "orders.json", for endpoints without a service method.
+// The results are decoded from the array of the response envelope named after
+// the last segment of the path, e.g. "orders", or from its only array.
+func IteratePath[T any](c *Client, path string, options interface{}) *Iterator[T] {
+	return Iterate(func(options interface{}) ([]T, *Pagination, error) {
+		resource := map[string]json.RawMessage{}
+		pagination, err := c.ListWithPagination(path, &resource, options)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		raw, err := envelopeArray(resource, path)
+		if raw == nil || err != nil {
+			return nil, nil, err
+		}
+
+		var items []T
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return nil, nil, err
+		}
+		return items, pagination, nil
+	}, options)
+}
+
+// envelopeArray returns the array of a list response envelope, the one named
+// after the last segment of the path or else the only one, nil if there is
+// none. An envelope with several other arrays is an error.
+func envelopeArray(envelope map[string]json.RawMessage, path string) (json.RawMessage, error) {
+	isArray := func(raw json.RawMessage) bool {
+		return bytes.HasPrefix(bytes.TrimSpace(raw), []byte("["))
+	}
+
+	key, _, _ := strings.Cut(path[strings.LastIndex(path, "/")+1:], "?")
+	key = strings.TrimSuffix(key, ".json")
+	if raw, ok := envelope[key]; ok && isArray(raw) {
+		return raw, nil
+	}
+
+	var keys []string
+	for k, raw := range envelope {
+		if isArray(raw) {
+			keys = append(keys, k)
+		}
+	}
+	switch len(keys) {
+	case 0:
+		return nil, nil
+	case 1:
+		return envelope[keys[0]], nil
+	}
+
+	sort.Strings(keys)
+	return nil, ResponseDecodingError{
+		Message: fmt.Sprintf("ambiguous response envelope of %s: arrays %s", path, strings.Join(keys, ", ")),
+	}
+}
+
+// Next advances the iterator to the next item, fetching the next page when
+// needed. It returns false when there are no more items or an error occurred.
+func (it *Iterator[T]) Next() bool {
+	for len(it.items) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+		it.fetch()
+	}
+
+	it.current, it.items = it.items[0], it.items[1:]
+	return true
+}
+
+// Value returns the current item.
+func (it *Iterator[T]) Value() T {
+	return it.current
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Pages returns the number of pages fetched so far.
+func (it *Iterator[T]) Pages() int {
+	return it.pages
+}
+
+// All consumes the iterator and returns the remaining items.
+func (it *Iterator[T]) All() ([]T, error) {
+	var items []T
+	for it.Next() {
+		items = append(items, it.Value())
+	}
+	return items, it.Err()
+}
+
+func (it *Iterator[T]) fetch() {
+	items, pagination, err := it.list(it.options)
+	if err != nil {
+		it.err = err
+		return
+	}
+
+	it.pages++
+	it.items = items
+
+	if pagination == nil || pagination.NextPageOptions == nil {
+		it.done = true
+		return
+	}
+
+	it.options = nextPageOptions(it.original, pagination.NextPageOptions)
+}
+
+// nextPageOptions carries over the filters of the original options that
+// Shopify allows along with a page_info.
+func nextPageOptions(original interface{}, next *ListOptions) ListOptions {
+	options := *next
+	if original == nil {
+		return options
+	}
+
+	values, err := query.Values(original)
+	if err != nil {
+		return options
+	}
+
+	if options.Fields == "" {
+		options.Fields = values.Get("fields")
+	}
+	if options.Limit == 0 {
+		options.Limit, _ = strconv.Atoi(values.Get("limit"))
+	}
+
+	return options
+}
@@ -0,0 +1,243 @@
+package synergyshopify
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func registerPagedResponders(path, key, idField string) {
+	url := fmt.Sprintf("https://fooshop.myshopify.com/%s/%s", client.pathPrefix, path)
+
+	httpmock.RegisterResponderWithQuery("GET", url,
+		map[string]string{"vendor": "foo", "fields": "id", "limit": "2"},
+		httpmock.NewStringResponder(200, fmt.Sprintf(`{"%s": [{"%s":1},{"%s":2}]}`, key, idField, idField)).
+			HeaderSet(http.Header{
+				"Link": {fmt.Sprintf(`<%s?page_info=abc&limit=2>; rel="next"`, url)},
+			}))
+
+	httpmock.RegisterResponderWithQuery("GET", url,
+		map[string]string{"page_info": "abc", "fields": "id", "limit": "2"},
+		httpmock.NewStringResponder(200, fmt.Sprintf(`{"%s": [{"%s":3}]}`, key, idField)).
+			HeaderSet(http.Header{
+				"Link": {fmt.Sprintf(`<%s?page_info=abc&limit=2>; rel="previous"`, url)},
+			}))
+}
+
+func TestIterate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	registerPagedResponders("products.json", "products", "id")
+
+	options := ProductListOptions{
+		ListOptions: ListOptions{Fields: "id", Limit: 2},
+		Vendor:      "foo",
+	}
+	it := Iterate(client.Product.ListWithPagination, options)
+
+	var ids []int64
+	for it.Next() {
+		ids = append(ids, it.Value().ID)
+	}
+
+	if err := it.Err(); err != nil {
+		t.Fatalf("Iterator.Err returned %v", err)
+	}
+
+	expected := []int64{1, 2, 3}
+	if !reflect.DeepEqual(ids, expected) {
+		t.Errorf("Iterator returned ids %v, expected %v", ids, expected)
+	}
+
+	if it.Pages() != 2 {
+		t.Errorf("Iterator.Pages returned %d, expected 2", it.Pages())
+	}
+
+	if it.Next() {
+		t.Errorf("Iterator.Next returned true after the last item")
+	}
+}
+
+func TestIterateEarlyStop(t *testing.T) {
+	setup()
+	defer teardown()
+
+	registerPagedResponders("webhooks.json", "webhooks", "id")
+
+	options := ListOptions{Fields: "id", Limit: 2, Vendor: "foo"}
+	it := Iterate(client.Webhook.ListWithPagination, options)
+
+	if !it.Next() || it.Value().ID != 1 {
+		t.Fatalf("Iterator.Next expected the first webhook, got %+v", it.Value())
+	}
+
+	if calls := httpmock.GetTotalCallCount(); calls != 1 {
+		t.Errorf("Iterator expected 1 call, actual %d", calls)
+	}
+}
+
+func TestIterateError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/redirects.json", client.pathPrefix),
+		httpmock.NewStringResponder(404, `{"errors": "Not Found"}`))
+
+	redirects, err := Iterate(client.Redirect.ListWithPagination, nil).All()
+	if redirects != nil {
+		t.Errorf("Iterator.All returned %v, expected nil", redirects)
+	}
+
+	expected := RequestError{
+		Method:     "GET",
+		Path:       fmt.Sprintf("/%s/redirects.json", client.pathPrefix),
+		APIVersion: testApiVersion,
+		Attempts:   1,
+		Err:        ResponseError{Status: 404, Message: "Not Found"},
+	}
+	if !reflect.DeepEqual(err, expected) {
+		t.Errorf("Iterator.All returned error %#v, expected %#v", err, expected)
+	}
+}
+
+func TestIteratePath(t *testing.T) {
+	setup()
+	defer teardown()
+
+	registerPagedResponders("gift_cards.json", "gift_cards", "id")
+
+	options := ListOptions{Fields: "id", Limit: 2, Vendor: "foo"}
+	giftCards, err := IteratePath[GiftCard](client, "gift_cards.json", options).All()
+	if err != nil {
+		t.Fatalf("IteratePath returned error %v", err)
+	}
+
+	expected := []GiftCard{{ID: 1}, {ID: 2}, {ID: 3}}
+	if !reflect.DeepEqual(giftCards, expected) {
+		t.Errorf("IteratePath returned %+v, expected %+v", giftCards, expected)
+	}
+}
+
+func TestEnvelopeArray(t *testing.T) {
+	envelope := map[string]json.RawMessage{
+		"orders":  json.RawMessage(`[{"id": 1}]`),
+		"refunds": json.RawMessage(`[{"id": 2}]`),
+		"count":   json.RawMessage(`2`),
+	}
+
+	cases := map[string]string{
+		"orders.json":                    `[{"id": 1}]`,
+		"admin/api/2024-01/refunds.json": `[{"id": 2}]`,
+		"orders.json?status=any":         `[{"id": 1}]`,
+	}
+	for path, expected := range cases {
+		if raw, err := envelopeArray(envelope, path); err != nil || string(raw) != expected {
+			t.Errorf("envelopeArray(%q) returned %s, %v, expected %s", path, raw, err, expected)
+		}
+	}
+
+	if raw, err := envelopeArray(envelope, "gift_cards.json"); err == nil || !strings.Contains(err.Error(), "arrays orders, refunds") {
+		t.Errorf("envelopeArray returned %s, %v, expected an ambiguous envelope error", raw, err)
+	}
+
+	delete(envelope, "refunds")
+	if raw, err := envelopeArray(envelope, "gift_cards/search.json"); err != nil || string(raw) != `[{"id": 1}]` {
+		t.Errorf("envelopeArray returned %s, %v, expected the only array", raw, err)
+	}
+}
+
+func TestNextPageOptions(t *testing.T) {
+	next := &ListOptions{PageInfo: "abc"}
+
+	cases := []struct {
+		original interface{}
+		expected ListOptions
+	}{
+		{nil, ListOptions{PageInfo: "abc"}},
+		{ListOptions{Limit: 50, Fields: "id,title", SinceID: 5}, ListOptions{PageInfo: "abc", Limit: 50, Fields: "id,title"}},
+		{&OrderListOptions{ListOptions: ListOptions{Fields: "id"}, Status: "any"}, ListOptions{PageInfo: "abc", Fields: "id"}},
+		{map[string]string{"limit": "5"}, ListOptions{PageInfo: "abc"}},
+	}
+
+	for _, c := range cases {
+		actual := nextPageOptions(c.original, next)
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("nextPageOptions(%+v) returned %+v, expected %+v", c.original, actual, c.expected)
+		}
+	}
+}
+
+func TestIterateServices(t *testing.T) {
+	setup()
+	defer teardown()
+
+	cases := []struct {
+		path    string
+		key     string
+		idField string
+		ids     func() ([]int64, error)
+	}{
+		{"draft_orders.json", "draft_orders", "id", func() ([]int64, error) {
+			items, err := Iterate(client.DraftOrder.ListWithPagination, ListOptions{Fields: "id", Limit: 2, Vendor: "foo"}).All()
+			ids := []int64{}
+			for _, item := range items {
+				ids = append(ids, item.ID)
+			}
+			return ids, err
+		}},
+		{"metafields.json", "metafields", "id", func() ([]int64, error) {
+			items, err := Iterate(client.Metafield.ListWithPagination, ListOptions{Fields: "id", Limit: 2, Vendor: "foo"}).All()
+			ids := []int64{}
+			for _, item := range items {
+				ids = append(ids, item.ID)
+			}
+			return ids, err
+		}},
+		{"gift_cards.json", "gift_cards", "id", func() ([]int64, error) {
+			items, err := Iterate(client.GiftCard.ListWithPagination, ListOptions{Fields: "id", Limit: 2, Vendor: "foo"}).All()
+			ids := []int64{}
+			for _, item := range items {
+				ids = append(ids, item.ID)
+			}
+			return ids, err
+		}},
+		{"price_rules.json", "price_rules", "id", func() ([]int64, error) {
+			items, err := Iterate(client.PriceRule.ListWithPagination, ListOptions{Fields: "id", Limit: 2, Vendor: "foo"}).All()
+			ids := []int64{}
+			for _, item := range items {
+				ids = append(ids, item.ID)
+			}
+			return ids, err
+		}},
+		{"inventory_levels.json", "inventory_levels", "inventory_item_id", func() ([]int64, error) {
+			items, err := Iterate(client.InventoryLevel.ListWithPagination, ListOptions{Fields: "id", Limit: 2, Vendor: "foo"}).All()
+			ids := []int64{}
+			for _, item := range items {
+				ids = append(ids, item.InventoryItemId)
+			}
+			return ids, err
+		}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.path, func(t *testing.T) {
+			registerPagedResponders(c.path, c.key, c.idField)
+
+			ids, err := c.ids()
+			if err != nil {
+				t.Fatalf("Iterate returned error %v", err)
+			}
+
+			expected := []int64{1, 2, 3}
+			if !reflect.DeepEqual(ids, expected) {
+				t.Errorf("Iterate returned ids %v, expected %v", ids, expected)
+			}
+		})
+	}
+}
@@ -1,8 +1,10 @@
 package synergyshopify
 
 import (
+	"context"
 	"fmt"
 	"io"
+	"log/slog"
 	"os"
 )
 
@@ -22,6 +24,20 @@ type LeveledLoggerInterface interface {
 	Warnf(format string, v ...interface{})
 }
 
+// LevelEnabler can be implemented by a LeveledLoggerInterface to let the
+// client skip the work of building messages that would not be emitted, such
+// as buffering request and response bodies for debug logging.
+type LevelEnabler interface {
+	Enabled(level int) bool
+}
+
+// AttrLogger can be implemented by a LeveledLoggerInterface to receive the
+// events of the client as a message with structured attributes instead of a
+// formatted string, see SlogLogger.
+type AttrLogger interface {
+	LogAttrs(ctx context.Context, level int, msg string, attrs ...slog.Attr)
+}
+
 // It prints warnings and errors to `os.Stderr` and other messages to
 // `os.Stdout`.
 type LeveledLogger struct {
@@ -39,6 +55,11 @@ type LeveledLogger struct {
 	stdoutOverride io.Writer
 }
 
+// Enabled reports whether messages of the given level are emitted.
+func (l *LeveledLogger) Enabled(level int) bool {
+	return l.Level >= level
+}
+
 // Debugf logs a debug message using Printf conventions.
 func (l *LeveledLogger) Debugf(format string, v ...interface{}) {
 	if l.Level >= LevelDebug {
@@ -83,3 +104,70 @@ func (l *LeveledLogger) stdout() io.Writer {
 
 	return os.Stdout
 }
+
+// SlogLogger adapts a *slog.Logger to LeveledLoggerInterface. The requests of
+// the client are logged with the shop, method, path, status, duration,
+// attempt and request_id attributes.
+type SlogLogger struct {
+	logger *slog.Logger
+}
+
+// NewSlogLogger returns a logger writing to l, or to slog.Default() when l is
+// nil.
+func NewSlogLogger(l *slog.Logger) *SlogLogger {
+	if l == nil {
+		l = slog.Default()
+	}
+	return &SlogLogger{logger: l}
+}
+
+// slogLevel maps the levels of LeveledLogger to the slog ones.
+func slogLevel(level int) slog.Level {
+	switch level {
+	case LevelError:
+		return slog.LevelError
+	case LevelWarn:
+		return slog.LevelWarn
+	case LevelInfo:
+		return slog.LevelInfo
+	default:
+		return slog.LevelDebug
+	}
+}
+
+// Enabled reports whether messages of the given level are emitted.
+func (l *SlogLogger) Enabled(level int) bool {
+	return l.logger.Enabled(context.Background(), slogLevel(level))
+}
+
+// LogAttrs logs a message with structured attributes.
+func (l *SlogLogger) LogAttrs(ctx context.Context, level int, msg string, attrs ...slog.Attr) {
+	l.logger.LogAttrs(ctx, slogLevel(level), msg, attrs...)
+}
+
+// Debugf logs a debug message using Printf conventions.
+func (l *SlogLogger) Debugf(format string, v ...interface{}) {
+	l.logf(LevelDebug, format, v...)
+}
+
+// Errorf logs an error message using Printf conventions.
+func (l *SlogLogger) Errorf(format string, v ...interface{}) {
+	l.logf(LevelError, format, v...)
+}
+
+// Infof logs an informational message using Printf conventions.
+func (l *SlogLogger) Infof(format string, v ...interface{}) {
+	l.logf(LevelInfo, format, v...)
+}
+
+// Warnf logs a warning message using Printf conventions.
+func (l *SlogLogger) Warnf(format string, v ...interface{}) {
+	l.logf(LevelWarn, format, v...)
+}
+
+func (l *SlogLogger) logf(level int, format string, v ...interface{}) {
+	if !l.Enabled(level) {
+		return
+	}
+	l.logger.Log(context.Background(), slogLevel(level), fmt.Sprintf(format, v...))
+}
@@ -2,12 +2,18 @@ package synergyshopify
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"os"
 	"strings"
 	"testing"
+
+	"github.com/jarcoal/httpmock"
 )
 
 func TestLeveledLogger(t *testing.T) {
@@ -121,3 +127,125 @@ func TestDoGetHeadersDebug(t *testing.T) {
 		t.Errorf("doGetHeadersDebug expected stdout \"%s\" received \"%s\"", resExpected, out.String())
 	}
 }
+
+func TestLeveledLoggerEnabled(t *testing.T) {
+	log := &LeveledLogger{Level: LevelInfo}
+
+	if !log.Enabled(LevelError) || !log.Enabled(LevelInfo) {
+		t.Errorf("leveled logger %d expected errors and infos to be enabled", log.Level)
+	}
+	if log.Enabled(LevelDebug) {
+		t.Errorf("leveled logger %d expected debug to be disabled", log.Level)
+	}
+}
+
+func TestLogBodyDebugDisabled(t *testing.T) {
+	testClient := NewClient(app, "fooshop", "abcd", WithLogger(&LeveledLogger{Level: LevelInfo}))
+
+	body := io.NopCloser(strings.NewReader(`{"foo": "bar"}`))
+	original := body
+	testClient.logBody(&body, "RESP: %s")
+
+	if body != original {
+		t.Errorf("logBody should not buffer the body when debug logging is off")
+	}
+
+	testClient = NewClient(app, "fooshop", "abcd", WithLogger(&LeveledLogger{Level: LevelDebug, stdoutOverride: io.Discard}))
+	testClient.logBody(&body, "RESP: %s")
+
+	if body == original {
+		t.Errorf("logBody should buffer the body when debug logging is on")
+	}
+
+	b, _ := io.ReadAll(body)
+	if string(b) != `{"foo": "bar"}` {
+		t.Errorf("logBody should leave the body readable, got %s", b)
+	}
+}
+
+func TestSlogLogger(t *testing.T) {
+	out := &bytes.Buffer{}
+	logger := NewSlogLogger(slog.New(slog.NewTextHandler(out, &slog.HandlerOptions{Level: slog.LevelInfo})))
+
+	if logger.Enabled(LevelDebug) {
+		t.Error("expected debug to be disabled")
+	}
+	if !logger.Enabled(LevelWarn) {
+		t.Error("expected warn to be enabled")
+	}
+
+	logger.Debugf("hidden %d", 1)
+	logger.Warnf("shown %d", 2)
+	logger.LogAttrs(context.Background(), LevelError, "with attrs", slog.String("shop", "fooshop"))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %q", out.String())
+	}
+	if !strings.Contains(lines[0], "level=WARN") || !strings.Contains(lines[0], `msg="shown 2"`) {
+		t.Errorf("unexpected warn line %q", lines[0])
+	}
+	if !strings.Contains(lines[1], "level=ERROR") || !strings.Contains(lines[1], "shop=fooshop") {
+		t.Errorf("unexpected error line %q", lines[1])
+	}
+}
+
+func TestLogAttemptAttributes(t *testing.T) {
+	setup()
+	defer teardown()
+
+	out := &bytes.Buffer{}
+	WithLogger(NewSlogLogger(slog.New(slog.NewJSONHandler(out, nil))))(client)
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/shop.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(404, `{"errors":"Not Found"}`)
+			resp.Header.Set("X-Request-Id", "req-1")
+			return resp, nil
+		})
+
+	if _, err := client.Shop.Get(nil); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &entry); err != nil {
+		t.Fatalf("expected a single JSON log line, got %q: %v", out.String(), err)
+	}
+
+	expected := map[string]interface{}{
+		"level":      "WARN",
+		"msg":        "shopify request",
+		"shop":       "fooshop.myshopify.com",
+		"method":     "GET",
+		"path":       fmt.Sprintf("/%s/shop.json", client.pathPrefix),
+		"status":     float64(404),
+		"attempt":    float64(1),
+		"request_id": "req-1",
+	}
+	for key, value := range expected {
+		if entry[key] != value {
+			t.Errorf("expected %s=%v, got %v", key, value, entry[key])
+		}
+	}
+	if _, ok := entry["duration"]; !ok {
+		t.Error("expected a duration attribute")
+	}
+}
+
+func TestLogAttemptLeveledLogger(t *testing.T) {
+	out := &bytes.Buffer{}
+	testClient := NewClient(app, "fooshop", "abcd", WithLogger(&LeveledLogger{Level: LevelWarn, stderrOverride: out}))
+
+	req, _ := testClient.NewRequest("GET", "shop.json", nil, nil)
+	testClient.logAttempt(req, &http.Response{StatusCode: 200, Header: http.Header{}}, nil, 1, 0)
+	if out.String() != "" {
+		t.Errorf("expected successful attempts to be logged at debug level, got %q", out.String())
+	}
+
+	testClient.logAttempt(req, &http.Response{StatusCode: 500, Header: http.Header{}}, nil, 2, 0)
+	expected := "[WARN] shopify request shop=fooshop.myshopify.com method=GET path=/shop.json status=500 duration=0s attempt=2\n"
+	if out.String() != expected {
+		t.Errorf("expected %q, got %q", expected, out.String())
+	}
+}
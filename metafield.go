@@ -10,6 +10,7 @@ import (
 // https://help.shopify.com/api/reference/metafield
 type MetafieldService interface {
 	List(interface{}) ([]Metafield, error)
+	ListWithPagination(interface{}) ([]Metafield, *Pagination, error)
 	Count(interface{}) (int, error)
 	Get(int64, interface{}) (*Metafield, error)
 	Create(Metafield) (*Metafield, error)
@@ -65,11 +66,25 @@ type MetafieldsResource struct {
 
 // List metafields
 func (s *MetafieldServiceOp) List(options interface{}) ([]Metafield, error) {
+	metafields, _, err := s.ListWithPagination(options)
+	if err != nil {
+		return nil, err
+	}
+	return metafields, nil
+}
+
+// ListWithPagination lists metafields and return pagination to retrieve next/previous results.
+func (s *MetafieldServiceOp) ListWithPagination(options interface{}) ([]Metafield, *Pagination, error) {
 	prefix := MetafieldPathPrefix(s.resource, s.resourceID)
 	path := fmt.Sprintf("%s.json", prefix)
 	resource := new(MetafieldsResource)
-	err := s.client.Get(path, resource, options, true)
-	return resource.Metafields, err
+
+	pagination, err := s.client.ListWithPagination(path, resource, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resource.Metafields, pagination, nil
 }
 
 // Count metafields
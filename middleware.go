@@ -0,0 +1,35 @@
+package synergyshopify
+
+import "net/http"
+
+// RoundTripFunc sends a request and returns its response, see Middleware.
+type RoundTripFunc func(req *http.Request) (*http.Response, error)
+
+// Middleware intercepts every request sent by a client, see WithMiddleware.
+// It receives the next step of the chain and returns a function calling it,
+// which can change the outgoing request and inspect the response or error,
+// e.g.
+//
+//	func tracing(next RoundTripFunc) RoundTripFunc {
+//		return func(req *http.Request) (*http.Response, error) {
+//			req.Header.Set("X-Trace-Id", newTraceID())
+//			return next(req)
+//		}
+//	}
+//
+// The request is fully resolved: its URL holds the shop domain and the path
+// with the api version, and it carries the access token header. Middlewares
+// run for every attempt, so a retried request goes through them again, but
+// not for responses served from the cache.
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// send sends req through the middlewares of the client, the first registered
+// one being the outermost.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	next := RoundTripFunc(c.Client.Do)
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		next = c.middleware[i](next)
+	}
+
+	return next(req)
+}
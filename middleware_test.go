@@ -0,0 +1,98 @@
+package synergyshopify
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestMiddlewareOrder(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var calls []string
+	record := func(name string) Middleware {
+		return func(next RoundTripFunc) RoundTripFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name+" "+req.Method+" "+req.URL.Host+req.URL.Path)
+				resp, err := next(req)
+				calls = append(calls, fmt.Sprintf("%s %d", name, resp.StatusCode))
+				return resp, err
+			}
+		}
+	}
+	WithMiddleware(record("first"), record("second"))(client)
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/shop.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"shop":{"id":1}}`))
+
+	if _, err := client.Shop.Get(nil); err != nil {
+		t.Fatalf("Shop.Get returned error: %v", err)
+	}
+
+	path := fmt.Sprintf("fooshop.myshopify.com/%s/shop.json", client.pathPrefix)
+	expected := []string{"first GET " + path, "second GET " + path, "second 200", "first 200"}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("middlewares called in order %v, expected %v", calls, expected)
+	}
+}
+
+func TestMiddlewareMutatesRequest(t *testing.T) {
+	setup()
+	defer teardown()
+
+	WithMiddleware(func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			req.Header.Set("X-Trace-Id", "trace")
+			return next(req)
+		}
+	})(client)
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/shop.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			if got := req.Header.Get("X-Trace-Id"); got != "trace" {
+				t.Errorf("expected X-Trace-Id trace, got %q", got)
+			}
+			return httpmock.NewStringResponse(200, `{"shop":{"id":1}}`), nil
+		})
+
+	if _, err := client.Shop.Get(nil); err != nil {
+		t.Fatalf("Shop.Get returned error: %v", err)
+	}
+}
+
+func TestMiddlewareSeesEveryAttempt(t *testing.T) {
+	setup()
+	defer teardown()
+
+	attempts := 0
+	var lastErr error
+	WithMiddleware(func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			attempts++
+			resp, err := next(req)
+			lastErr = err
+			return resp, err
+		}
+	})(client)
+
+	transportErr := errors.New("connection reset")
+	WithRetryPolicy(RetryPolicy{MaxAttempts: 3, RetryOnTransportError: true})(client)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/shop.json", client.pathPrefix),
+		httpmock.NewErrorResponder(transportErr))
+
+	if _, err := client.Shop.Get(nil); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if attempts != 3 {
+		t.Errorf("expected the middleware to see 3 attempts, got %d", attempts)
+	}
+	if !errors.Is(lastErr, transportErr) {
+		t.Errorf("expected the middleware to see %v, got %v", transportErr, lastErr)
+	}
+}
@@ -2,6 +2,7 @@ package synergyshopify
 
 import (
 	"bytes"
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/base64"
@@ -36,6 +37,16 @@ func (app App) AuthorizeUrl(shopName string, state string) string {
 }
 
 func (app App) GetAccessToken(shopName string, code string) (string, error) {
+	ctx := context.Background()
+	if app.Client != nil {
+		ctx = app.Client.context()
+	}
+	return app.GetAccessTokenContext(ctx, shopName, code)
+}
+
+// GetAccessTokenContext exchanges the authorization code for a permanent
+// access token like GetAccessToken, using the given context for the request.
+func (app App) GetAccessTokenContext(ctx context.Context, shopName string, code string) (string, error) {
 	type Token struct {
 		Token string `json:"access_token"`
 	}
@@ -55,7 +66,7 @@ func (app App) GetAccessToken(shopName string, code string) (string, error) {
 		client = NewClient(app, shopName, "")
 	}
 
-	req, err := client.NewRequest("POST", accessTokenRelPath, data, nil)
+	req, err := client.NewRequestWithContext(ctx, "POST", accessTokenRelPath, data, nil)
 	if err != nil {
 		return "", err
 	}
@@ -94,17 +105,10 @@ func (app App) VerifyAuthorizationURL(u *url.URL) (bool, error) {
 // Verifies a webhook http request, sent by Shopify.
 // The body of the request is still readable after invoking the method.
 func (app App) VerifyWebhookRequest(httpRequest *http.Request) bool {
-	shopifySha256 := httpRequest.Header.Get(shopifyChecksumHeader)
-	actualMac := []byte(shopifySha256)
-
-	mac := hmac.New(sha256.New, []byte(app.ApiSecret))
 	requestBody, _ := io.ReadAll(httpRequest.Body)
 	httpRequest.Body = io.NopCloser(bytes.NewBuffer(requestBody))
-	mac.Write(requestBody)
-	macSum := mac.Sum(nil)
-	expectedMac := []byte(base64.StdEncoding.EncodeToString(macSum))
 
-	return hmac.Equal(actualMac, expectedMac)
+	return verifyWebhookHMAC(app.ApiSecret, requestBody, httpRequest.Header.Get(shopifyChecksumHeader))
 }
 
 // Verifies a webhook http request, sent by Shopify.
@@ -128,7 +132,6 @@ func (app App) VerifyWebhookRequestVerbose(httpRequest *http.Request) (bool, err
 		return false, fmt.Errorf("received HMAC is not of length 32, it is of length %d", len(decodedReceivedHMAC))
 	}
 
-	mac := hmac.New(sha256.New, []byte(app.ApiSecret))
 	requestBody, err := io.ReadAll(httpRequest.Body)
 	if err != nil {
 		return false, err
@@ -139,16 +142,11 @@ func (app App) VerifyWebhookRequestVerbose(httpRequest *http.Request) (bool, err
 		return false, errors.New("request body is empty")
 	}
 
-	// Sha256 write doesn't actually return an error
-	mac.Write(requestBody)
-
-	computedHMAC := mac.Sum(nil)
-	HMACSame := hmac.Equal(decodedReceivedHMAC, computedHMAC)
-	if !HMACSame {
-		return HMACSame, fmt.Errorf("expected hash %x does not equal %x", computedHMAC, decodedReceivedHMAC)
+	if !verifyWebhookHMAC(app.ApiSecret, requestBody, shopifySha256) {
+		return false, fmt.Errorf("expected hash %x does not equal %x", webhookHMAC(app.ApiSecret, requestBody), decodedReceivedHMAC)
 	}
 
-	return HMACSame, nil
+	return true, nil
 }
 
 // Verifies an app proxy request, sent by Shopify.
@@ -181,3 +179,20 @@ func hmacSHA256(key, body, expected []byte) bool {
 
 	return hmac.Equal(dst, expected)
 }
+
+// verifyWebhookHMAC reports whether header, the X-Shopify-Hmac-Sha256 header
+// of a webhook, is the base64 encoded HMAC-SHA256 of its body with the secret.
+func verifyWebhookHMAC(secret string, body []byte, header string) bool {
+	decoded, err := base64.StdEncoding.DecodeString(header)
+	if err != nil || len(decoded) != sha256.Size {
+		return false
+	}
+	return hmac.Equal(decoded, webhookHMAC(secret, body))
+}
+
+// webhookHMAC returns the HMAC-SHA256 of a webhook body with the secret.
+func webhookHMAC(secret string, body []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return mac.Sum(nil)
+}
@@ -3,6 +3,7 @@ package synergyshopify
 import (
 	"fmt"
 	"net/http"
+	"time"
 )
 
 // Option is used to configure client with options
@@ -15,11 +16,13 @@ func WithVersion(apiVersion string) Option {
 		if len(apiVersion) > 0 && (apiVersionRegex.MatchString(apiVersion) || apiVersion == UnstableApiVersion) {
 			pathPrefix = fmt.Sprintf("admin/api/%s", apiVersion)
 		}
-		c.apiVersion = apiVersion
+		c.state.apiVersion = apiVersion
 		c.pathPrefix = pathPrefix
 	}
 }
 
+// WithRetry sets the number of attempts made for rate limited (429) and
+// unavailable (503) requests, see WithRetryPolicy for more control
 func WithRetry(retries int) Option {
 	return func(c *Client) {
 		c.retries = retries
@@ -38,3 +41,57 @@ func WithHTTPClient(client *http.Client) Option {
 		c.Client = client
 	}
 }
+
+// WithRateLimiter proactively limits the REST API calls of the client with
+// the given limiter. Pass the same limiter to every client of a shop to share
+// its bucket between them.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(c *Client) {
+		c.rateLimiter = limiter
+	}
+}
+
+// WithRetryPolicy sets the policy deciding which failed requests are retried
+// and how long to wait between attempts. It takes precedence over WithRetry.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = &policy
+	}
+}
+
+// WithCache caches the GET responses of the resources listed in ttls, keyed by
+// resource name, e.g. "shop" or "locations", for their time to live. Stale
+// responses are revalidated with If-None-Match and If-Modified-Since requests,
+// and POST, PUT and DELETE requests made by the client invalidate the cached
+// responses of the resource they write. A nil cache defaults to an in-memory
+// LRU cache and nil ttls to DefaultCacheTTLs.
+func WithCache(cache Cache, ttls map[string]time.Duration) Option {
+	return func(c *Client) {
+		if cache == nil {
+			cache = NewLRUCache(defaultCacheSize)
+		}
+		if ttls == nil {
+			ttls = DefaultCacheTTLs()
+		}
+		c.cache = &responseCache{storage: cache, ttls: ttls}
+	}
+}
+
+// WithMiddleware adds middlewares intercepting every request sent by the
+// client, in order: the first one sees the request first and the response
+// last. It can be used several times to append more middlewares.
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(c *Client) {
+		c.middleware = append(c.middleware, middleware...)
+	}
+}
+
+// WithRedactedFields sets the JSON keys and query parameters whose values are
+// masked in debug logs, matched case-insensitively, replacing
+// DefaultRedactedFields. Access tokens, client secrets and credential headers
+// are always masked.
+func WithRedactedFields(fields ...string) Option {
+	return func(c *Client) {
+		c.redactor = newRedactor(fields)
+	}
+}
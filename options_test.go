@@ -3,6 +3,7 @@ package synergyshopify
 import (
 	"fmt"
 	"net/http"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -72,3 +73,21 @@ func TestWithHTTPClient(t *testing.T) {
 		t.Errorf("WithVersion client.Client = %s, expected %s", c.Client.Timeout, expected)
 	}
 }
+
+func TestWithRateLimiter(t *testing.T) {
+	limiter := NewRateLimiter(0, 0)
+	c := NewClient(app, "fooshop", "abcd", WithRateLimiter(limiter))
+
+	if c.rateLimiter != limiter {
+		t.Errorf("WithRateLimiter expected limiter to match %v != %v", c.rateLimiter, limiter)
+	}
+}
+
+func TestWithRetryPolicy(t *testing.T) {
+	policy := DefaultRetryPolicy(5)
+	c := NewClient(app, "fooshop", "abcd", WithRetry(2), WithRetryPolicy(policy))
+
+	if !reflect.DeepEqual(c.retryPolicy(), policy) {
+		t.Errorf("WithRetryPolicy client.retryPolicy() = %+v, expected %+v", c.retryPolicy(), policy)
+	}
+}
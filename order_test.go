@@ -21,7 +21,7 @@ func TestOrderListError(t *testing.T) {
 	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders.json", client.pathPrefix),
 		httpmock.NewStringResponder(500, ""))
 
-	expectedErrMessage := "Unknown Error"
+	expectedErrMessage := fmt.Sprintf("Unknown Error (GET /%s/orders.json, api version %s, 1 attempt)", client.pathPrefix, testApiVersion)
 
 	orders, err := client.Order.List(nil)
 	if orders != nil {
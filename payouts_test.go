@@ -52,7 +52,7 @@ func TestPayoutsListError(t *testing.T) {
 	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/shopify_payments/payouts.json", client.pathPrefix),
 		httpmock.NewStringResponder(500, ""))
 
-	expectedErrMessage := "Unknown Error"
+	expectedErrMessage := fmt.Sprintf("Unknown Error (GET /%s/shopify_payments/payouts.json, api version %s, 1 attempt)", client.pathPrefix, testApiVersion)
 
 	payouts, err := client.Payouts.List(nil)
 	if payouts != nil {
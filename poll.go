@@ -0,0 +1,140 @@
+package synergyshopify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// wait between polls when the response has no Retry-After header
+	defaultPollInterval = time.Second
+
+	// max number of 303 See Other responses followed for a request
+	maxSeeOtherRedirects = 10
+)
+
+// Poll performs GET requests for the given path until done returns true for
+// the last response, which is decoded into resource. Between requests it waits
+// for the Retry-After header of the response, or one second without it.
+//
+// With a nil done, the path is polled while Shopify answers 202 Accepted, the
+// following requests going to the Location header of the response when set,
+// which must be on the host of the shop.
+// Otherwise done typically inspects resource, e.g. for a batch job:
+//
+//	job := new(DiscountCodeCreationResource)
+//	err := client.Poll(path, job, func(*Response) bool {
+//		return job.DiscountCodeCreation != nil && job.DiscountCodeCreation.Status == "completed"
+//	})
+//
+// Polls bypass the response cache.
+func (c *Client) Poll(path string, resource interface{}, done func(*Response) bool) error {
+	return c.PollContext(c.context(), path, resource, done)
+}
+
+// PollContext performs a Poll with the given context, which bounds the total
+// time spent polling.
+func (c *Client) PollContext(ctx context.Context, relPath string, resource interface{}, done func(*Response) bool) error {
+	if done == nil {
+		done = func(resp *Response) bool {
+			return resp.StatusCode != http.StatusAccepted
+		}
+	}
+
+	relPath = path.Join(c.pathPrefix, strings.TrimLeft(relPath, "/"))
+	for {
+		req, err := c.NewRequestWithContext(ctx, http.MethodGet, relPath, nil, nil)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Cache-Control", "no-cache")
+
+		body := new(rawBody)
+		resp, err := c.Do(req, body)
+		if err != nil {
+			return err
+		}
+
+		if len(body.data) > 0 && resource != nil {
+			if err := json.Unmarshal(body.data, resource); err != nil {
+				return c.requestError(req, resp, err)
+			}
+		}
+
+		if done(resp) {
+			return nil
+		}
+
+		if location := resp.Header.Get("Location"); resp.StatusCode == http.StatusAccepted && location != "" {
+			next, err := req.URL.Parse(location)
+			if err != nil {
+				return c.requestError(req, resp, err)
+			}
+			// the access token is only sent to the shop
+			if next.Host != req.URL.Host {
+				return c.requestError(req, resp, fmt.Errorf("refusing to poll Location %s of another host", location))
+			}
+			relPath = next.String()
+		}
+
+		if err := sleepContext(ctx, pollInterval(resp.Header)); err != nil {
+			return err
+		}
+	}
+}
+
+// pollInterval returns the wait before the next poll requested by the
+// Retry-After header.
+func pollInterval(header http.Header) time.Duration {
+	seconds, err := strconv.ParseFloat(header.Get("Retry-After"), 64)
+	if err != nil || seconds <= 0 {
+		return defaultPollInterval
+	}
+
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// rawBody keeps the response body as is, which may be empty for 202 responses.
+type rawBody struct {
+	data []byte
+}
+
+func (b *rawBody) decodeResponse(r io.Reader) error {
+	var err error
+	b.data, err = io.ReadAll(r)
+	return err
+}
+
+// seeOtherRequest returns the GET request for the Location header of a 303 See
+// Other response to req. The credentials are only sent along to the same host.
+func seeOtherRequest(req *http.Request, resp *http.Response) (*http.Request, error) {
+	location, err := req.URL.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		return nil, err
+	}
+
+	next, err := http.NewRequestWithContext(req.Context(), http.MethodGet, location.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	next.Header = req.Header.Clone()
+	next.Header.Del("Content-Type")
+	if location.Host != req.URL.Host {
+		next.Header.Del("X-Shopify-Access-Token")
+		next.Header.Del("Authorization")
+	}
+
+	return next, nil
+}
+
+// errTooManyRedirects is returned when a request keeps being redirected.
+var errTooManyRedirects = errors.New("stopped after too many 303 See Other redirects")
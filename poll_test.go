@@ -0,0 +1,198 @@
+package synergyshopify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestPollAccepted(t *testing.T) {
+	setup()
+	defer teardown()
+
+	jobURL := fmt.Sprintf("https://fooshop.myshopify.com/%s/jobs/1.json", client.pathPrefix)
+	statusURL := fmt.Sprintf("https://fooshop.myshopify.com/%s/jobs/1/status.json", client.pathPrefix)
+
+	httpmock.RegisterResponder("GET", jobURL, func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(202, "")
+		resp.Header.Set("Location", statusURL)
+		resp.Header.Set("Retry-After", "0.01")
+		return resp, nil
+	})
+
+	polls := 0
+	httpmock.RegisterResponder("GET", statusURL, func(req *http.Request) (*http.Response, error) {
+		polls++
+		if polls < 2 {
+			resp := httpmock.NewStringResponse(202, "")
+			resp.Header.Set("Retry-After", "0.01")
+			return resp, nil
+		}
+		return httpmock.NewStringResponse(200, `{"job":{"id":1,"status":"done"}}`), nil
+	})
+
+	resource := struct {
+		Job struct {
+			ID     int64  `json:"id"`
+			Status string `json:"status"`
+		} `json:"job"`
+	}{}
+	if err := client.Poll("jobs/1.json", &resource, nil); err != nil {
+		t.Fatalf("Client.Poll returned error: %v", err)
+	}
+
+	if resource.Job.Status != "done" {
+		t.Errorf("Client.Poll returned %+v, expected a done job", resource)
+	}
+	if polls != 2 {
+		t.Errorf("expected 2 polls of the Location header, got %d", polls)
+	}
+}
+
+func TestPollForeignLocation(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/jobs/1.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(202, "")
+			resp.Header.Set("Location", "https://attacker.example.com/jobs/1.json")
+			resp.Header.Set("Retry-After", "0.01")
+			return resp, nil
+		})
+	httpmock.RegisterResponder("GET", "https://attacker.example.com/jobs/1.json", func(req *http.Request) (*http.Response, error) {
+		t.Errorf("Client.Poll sent a request to another host with token %q", req.Header.Get("X-Shopify-Access-Token"))
+		return httpmock.NewStringResponse(200, `{}`), nil
+	})
+
+	err := client.Poll("jobs/1.json", nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "refusing to poll Location https://attacker.example.com/jobs/1.json") {
+		t.Errorf("Client.Poll returned %v, expected the foreign Location to be refused", err)
+	}
+}
+
+func TestPollContextCanceled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/jobs/1.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(202, "")
+			resp.Header.Set("Retry-After", "10")
+			return resp, nil
+		})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := client.PollContext(ctx, "jobs/1.json", nil, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Client.PollContext returned %v, expected %v", err, context.DeadlineExceeded)
+	}
+	if time.Since(start) > time.Second {
+		t.Error("Client.PollContext should stop waiting once the context is done")
+	}
+}
+
+func TestPollInterval(t *testing.T) {
+	cases := []struct {
+		retryAfter string
+		expected   time.Duration
+	}{
+		{"", defaultPollInterval},
+		{"2", 2 * time.Second},
+		{"0.5", 500 * time.Millisecond},
+		{"soon", defaultPollInterval},
+	}
+
+	for _, c := range cases {
+		header := http.Header{}
+		header.Set("Retry-After", c.retryAfter)
+		if actual := pollInterval(header); actual != c.expected {
+			t.Errorf("pollInterval(%q) returned %s, expected %s", c.retryAfter, actual, c.expected)
+		}
+	}
+}
+
+func TestDoFollowsSeeOther(t *testing.T) {
+	setup()
+	defer teardown()
+
+	// a client that doesn't follow redirects on its own
+	client.Client.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	httpmock.RegisterResponder("POST", "https://fooshop.myshopify.com/foo/1",
+		func(req *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(303, "")
+			resp.Header.Set("Location", "/foo/2")
+			return resp, nil
+		})
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/foo/2",
+		func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get("X-Shopify-Access-Token") != "abcd" {
+				t.Error("expected the access token to be sent to the same host")
+			}
+			return httpmock.NewStringResponse(200, `{"foo": "bar"}`), nil
+		})
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/foo/3",
+		func(req *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(303, "")
+			resp.Header.Set("Location", "https://other.example.com/foo")
+			return resp, nil
+		})
+	httpmock.RegisterResponder("GET", "https://other.example.com/foo",
+		func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get("X-Shopify-Access-Token") != "" {
+				t.Error("expected the access token not to be sent to another host")
+			}
+			return httpmock.NewStringResponse(200, `{"foo": "baz"}`), nil
+		})
+
+	for path, expected := range map[string]string{"foo/1": "bar", "foo/3": "baz"} {
+		method := "GET"
+		if path == "foo/1" {
+			method = "POST"
+		}
+
+		body := struct {
+			Foo string `json:"foo"`
+		}{}
+		req, _ := client.NewRequest(method, path, map[string]string{"foo": "bar"}, nil)
+		if err := client.ProcessRequest(req, &body); err != nil {
+			t.Fatalf("ProcessRequest(%s) returned error: %v", path, err)
+		}
+		if body.Foo != expected {
+			t.Errorf("ProcessRequest(%s) returned %q, expected %q", path, body.Foo, expected)
+		}
+	}
+}
+
+func TestDoSeeOtherLoop(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.Client.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/foo/1",
+		func(req *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(303, "")
+			resp.Header.Set("Location", "/foo/1")
+			return resp, nil
+		})
+
+	req, _ := client.NewRequest("GET", "foo/1", nil, nil)
+	if err := client.ProcessRequest(req, nil); !errors.Is(err, errTooManyRedirects) {
+		t.Errorf("ProcessRequest returned %v, expected %v", err, errTooManyRedirects)
+	}
+}
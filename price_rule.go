@@ -17,6 +17,7 @@ type PriceRuleService interface {
 	Create(PriceRule) (*PriceRule, error)
 	Update(PriceRule) (*PriceRule, error)
 	List() ([]PriceRule, error)
+	ListWithPagination(interface{}) ([]PriceRule, *Pagination, error)
 	Delete(int64) error
 }
 
@@ -161,10 +162,24 @@ func (s *PriceRuleServiceOp) Get(priceRuleID int64) (*PriceRule, error) {
 
 // List retrieves a list of price rules
 func (s *PriceRuleServiceOp) List() ([]PriceRule, error) {
+	priceRules, _, err := s.ListWithPagination(nil)
+	if err != nil {
+		return nil, err
+	}
+	return priceRules, nil
+}
+
+// ListWithPagination lists price rules and return pagination to retrieve next/previous results.
+func (s *PriceRuleServiceOp) ListWithPagination(options interface{}) ([]PriceRule, *Pagination, error) {
 	path := fmt.Sprintf("%s.json", priceRulesBasePath)
 	resource := new(PriceRulesResource)
-	err := s.client.Get(path, resource, nil, true)
-	return resource.PriceRules, err
+
+	pagination, err := s.client.ListWithPagination(path, resource, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resource.PriceRules, pagination, nil
 }
 
 // Create creates a price rule
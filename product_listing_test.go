@@ -45,7 +45,7 @@ func TestProductListingListError(t *testing.T) {
 	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/product_listings.json", client.pathPrefix),
 		httpmock.NewStringResponder(500, ""))
 
-	expectedErrMessage := "Unknown Error"
+	expectedErrMessage := fmt.Sprintf("Unknown Error (GET /%s/product_listings.json, api version %s, 1 attempt)", client.pathPrefix, testApiVersion)
 
 	products, err := client.ProductListing.List(nil)
 	if products != nil {
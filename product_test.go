@@ -1,6 +1,7 @@
 package synergyshopify
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -38,6 +39,22 @@ func TestProductList(t *testing.T) {
 	}
 }
 
+func TestProductListWithContext(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"products": [{"id":1},{"id":2}]}`))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := client.WithContext(ctx).Product.List(nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Product.List expected error %v, actual %v", context.Canceled, err)
+	}
+}
+
 func TestProductListFilterByIds(t *testing.T) {
 	setup()
 	defer teardown()
@@ -69,7 +86,7 @@ func TestProductListError(t *testing.T) {
 	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products.json", client.pathPrefix),
 		httpmock.NewStringResponder(500, ""))
 
-	expectedErrMessage := "Unknown Error"
+	expectedErrMessage := fmt.Sprintf("Unknown Error (GET /%s/products.json, api version %s, 1 attempt)", client.pathPrefix, testApiVersion)
 
 	products, err := client.Product.List(nil)
 	if products != nil {
@@ -0,0 +1,153 @@
+package synergyshopify
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultBucketSize is the size of the REST API leaky bucket of a
+	// standard shop
+	DefaultBucketSize = 40
+
+	// DefaultLeakRate is the number of requests per second leaking out of the
+	// REST API bucket of a standard shop
+	DefaultLeakRate = 2.0
+)
+
+// RateLimiter proactively limits the requests sent to the REST API by modeling
+// Shopify's leaky bucket for every shop. Callers are blocked before a request
+// would overflow the bucket instead of reacting to 429 responses.
+//
+// A RateLimiter is safe for concurrent use and the same limiter can be passed
+// to several clients of the same shop with WithRateLimiter, in which case they
+// share the bucket.
+// See: https://shopify.dev/docs/api/usage/rate-limits
+type RateLimiter struct {
+	bucketSize int
+	leakRate   float64
+
+	mu      sync.Mutex
+	buckets map[string]*leakyBucket
+}
+
+// leakyBucket holds the state of the bucket of a single shop
+type leakyBucket struct {
+	size      float64
+	fill      float64
+	updatedAt time.Time
+}
+
+// NewRateLimiter returns a limiter for buckets of the given size leaking
+// leakRate requests per second, e.g. NewRateLimiter(80, 4) for Shopify Plus
+// shops. Non-positive values default to DefaultBucketSize and DefaultLeakRate.
+// The bucket size is adjusted for each shop from the
+// X-Shopify-Shop-Api-Call-Limit header of its responses.
+func NewRateLimiter(bucketSize int, leakRate float64) *RateLimiter {
+	if bucketSize <= 0 {
+		bucketSize = DefaultBucketSize
+	}
+	if leakRate <= 0 {
+		leakRate = DefaultLeakRate
+	}
+
+	return &RateLimiter{
+		bucketSize: bucketSize,
+		leakRate:   leakRate,
+		buckets:    map[string]*leakyBucket{},
+	}
+}
+
+// bucket returns the bucket of the given shop with its fill leaked up to now.
+// The caller must hold the lock.
+func (l *RateLimiter) bucket(shop string) *leakyBucket {
+	shop = ShopFullName(shop)
+	now := time.Now()
+
+	b, ok := l.buckets[shop]
+	if !ok {
+		b = &leakyBucket{size: float64(l.bucketSize), updatedAt: now}
+		l.buckets[shop] = b
+	}
+
+	b.fill = math.Max(0, b.fill-l.leakRate*now.Sub(b.updatedAt).Seconds())
+	b.updatedAt = now
+
+	return b
+}
+
+// Reserve takes a slot in the bucket of the given shop and returns how long
+// the caller has to wait before sending its request.
+func (l *RateLimiter) Reserve(shop string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b := l.bucket(shop)
+	b.fill++
+
+	if b.fill <= b.size {
+		return 0
+	}
+
+	seconds := (b.fill - b.size) / l.leakRate
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// Cancel gives back a slot taken with Reserve that was not used.
+func (l *RateLimiter) Cancel(shop string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b := l.bucket(shop)
+	b.fill = math.Max(0, b.fill-1)
+}
+
+// Wait blocks until a request can be sent to the given shop without
+// overflowing its bucket, or until ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context, shop string) error {
+	wait := l.Reserve(shop)
+	if wait == 0 {
+		return nil
+	}
+
+	if err := sleepContext(ctx, wait); err != nil {
+		l.Cancel(shop)
+		return err
+	}
+
+	return nil
+}
+
+// Update synchronizes the bucket of the given shop with the
+// X-Shopify-Shop-Api-Call-Limit header of a response. A 429 response marks the
+// bucket as full.
+func (l *RateLimiter) Update(shop string, resp *http.Response) {
+	if resp == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b := l.bucket(shop)
+
+	if s := strings.Split(resp.Header.Get("X-Shopify-Shop-Api-Call-Limit"), "/"); len(s) == 2 {
+		count, err1 := strconv.Atoi(s[0])
+		size, err2 := strconv.Atoi(s[1])
+		if err1 == nil && err2 == nil && size > 0 {
+			b.size = float64(size)
+			// requests in flight are not part of the count yet, so never
+			// lower the local estimate
+			b.fill = math.Max(b.fill, float64(count))
+		}
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		b.fill = math.Max(b.fill, b.size)
+	}
+}
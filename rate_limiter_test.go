@@ -0,0 +1,153 @@
+package synergyshopify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestNewRateLimiterDefaults(t *testing.T) {
+	limiter := NewRateLimiter(0, -1)
+	if limiter.bucketSize != DefaultBucketSize {
+		t.Errorf("NewRateLimiter bucketSize = %d, expected %d", limiter.bucketSize, DefaultBucketSize)
+	}
+	if limiter.leakRate != DefaultLeakRate {
+		t.Errorf("NewRateLimiter leakRate = %v, expected %v", limiter.leakRate, DefaultLeakRate)
+	}
+}
+
+func TestRateLimiterReserve(t *testing.T) {
+	limiter := NewRateLimiter(2, 10)
+
+	for i := 0; i < 2; i++ {
+		if wait := limiter.Reserve("fooshop"); wait != 0 {
+			t.Errorf("RateLimiter.Reserve #%d expected no wait, actual %s", i, wait)
+		}
+	}
+
+	// the third request overflows the bucket and has to wait for a request to
+	// leak out, i.e. 1/10th of a second
+	wait := limiter.Reserve("fooshop.myshopify.com")
+	if wait <= 90*time.Millisecond || wait > 100*time.Millisecond {
+		t.Errorf("RateLimiter.Reserve expected about 100ms, actual %s", wait)
+	}
+
+	// other shops have their own bucket
+	if wait := limiter.Reserve("barshop"); wait != 0 {
+		t.Errorf("RateLimiter.Reserve for other shop expected no wait, actual %s", wait)
+	}
+}
+
+func TestRateLimiterWaitCanceled(t *testing.T) {
+	limiter := NewRateLimiter(1, 0.01)
+	limiter.Reserve("fooshop")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := limiter.Wait(ctx, "fooshop")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("RateLimiter.Wait expected %v, actual %v", context.DeadlineExceeded, err)
+	}
+
+	// the canceled reservation is given back
+	limiter.mu.Lock()
+	fill := limiter.bucket("fooshop").fill
+	limiter.mu.Unlock()
+	if fill > 1 {
+		t.Errorf("RateLimiter.Wait expected canceled slot to be released, fill %v", fill)
+	}
+}
+
+func TestRateLimiterUpdate(t *testing.T) {
+	limiter := NewRateLimiter(40, 2)
+
+	resp := httpmock.NewStringResponse(200, `{}`)
+	resp.Header.Set("X-Shopify-Shop-Api-Call-Limit", "79/80")
+	limiter.Update("fooshop", resp)
+
+	limiter.mu.Lock()
+	b := limiter.bucket("fooshop")
+	size, fill := b.size, b.fill
+	limiter.mu.Unlock()
+
+	if size != 80 {
+		t.Errorf("RateLimiter.Update bucket size = %v, expected 80", size)
+	}
+	if fill < 78 || fill > 79 {
+		t.Errorf("RateLimiter.Update bucket fill = %v, expected 79", fill)
+	}
+
+	limiter.Update("fooshop", httpmock.NewStringResponse(http.StatusTooManyRequests, `{}`))
+	if wait := limiter.Reserve("fooshop"); wait == 0 {
+		t.Errorf("RateLimiter.Reserve after 429 expected a wait")
+	}
+}
+
+func TestRateLimiterSharedByClients(t *testing.T) {
+	limiter := NewRateLimiter(2, 20)
+
+	clients := []*Client{
+		NewClient(app, "fooshop", "abcd", WithVersion(testApiVersion), WithRateLimiter(limiter)),
+		NewClient(app, "fooshop", "abcd", WithVersion(testApiVersion), WithRateLimiter(limiter)),
+	}
+
+	transport := httpmock.NewMockTransport()
+	transport.RegisterResponder("GET", "https://fooshop.myshopify.com/foo/1",
+		httpmock.NewStringResponder(200, `{}`))
+	for _, c := range clients {
+		c.Client.Transport = transport
+	}
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func(c *Client) {
+			defer wg.Done()
+			req, err := c.NewRequest("GET", "foo/1", nil, nil)
+			if err != nil {
+				t.Error("error creating request: ", err)
+				return
+			}
+			if err := c.ProcessRequest(req, nil); err != nil {
+				t.Errorf("ProcessRequest returned error: %v", err)
+			}
+		}(clients[i%2])
+	}
+	wg.Wait()
+
+	// 2 requests fit in the bucket, the 4 others leak out at 20 per second
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Errorf("requests were not limited, took %s", elapsed)
+	}
+
+	if calls := transport.GetTotalCallCount(); calls != 6 {
+		t.Errorf("expected 6 calls, actual %d", calls)
+	}
+}
+
+func TestRateLimiterSkipsGraphQL(t *testing.T) {
+	setup()
+	defer teardown()
+
+	limiter := NewRateLimiter(1, 0.01)
+	limiter.Reserve(client.baseURL.Host)
+	client.rateLimiter = limiter
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"data": {}}`))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if err := client.WithContext(ctx).GraphQL.Query("{ shop { name } }", nil, nil); err != nil {
+		t.Errorf("GraphQL.Query returned error: %v", err)
+	}
+}
@@ -0,0 +1,141 @@
+package synergyshopify
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// redactedValue replaces the secrets and personal data in debug logs
+const redactedValue = "[REDACTED]"
+
+// secretFields are the keys of the JSON bodies and query strings that are
+// always masked in debug logs.
+var secretFields = []string{"access_token", "client_secret"}
+
+// secretHeaders are the headers that are always masked in debug logs.
+var secretHeaders = []string{"X-Shopify-Access-Token", "Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// DefaultRedactedFields returns the keys of the personal data masked in debug
+// logs unless WithRedactedFields is used.
+func DefaultRedactedFields() []string {
+	return []string{
+		"email", "phone", "first_name", "last_name",
+		"address1", "address2", "city", "zip", "latitude", "longitude",
+		"company", "browser_ip", "note",
+	}
+}
+
+// redactor masks the secrets and personal data of the requests and responses
+// logged at debug level.
+type redactor struct {
+	fields  map[string]bool
+	pattern *regexp.Regexp
+}
+
+// newRedactor returns a redactor masking the secret fields and the given
+// personal data fields, matched case-insensitively.
+func newRedactor(fields []string) *redactor {
+	r := &redactor{fields: map[string]bool{}}
+
+	names := []string{}
+	for _, field := range append(append([]string{}, secretFields...), fields...) {
+		field = strings.ToLower(field)
+		if field == "" || r.fields[field] {
+			continue
+		}
+		r.fields[field] = true
+		names = append(names, regexp.QuoteMeta(field))
+	}
+
+	// key/value pairs of bodies that aren't valid JSON, e.g. form encoded or
+	// truncated ones
+	r.pattern = regexp.MustCompile(`(?i)("?\b(?:` + strings.Join(names, "|") + `)"?\s*[:=]\s*)("[^"]*"|[^&,\s}]+)`)
+
+	return r
+}
+
+// url returns u as a string without its password and secret query parameters.
+func (r *redactor) url(u *url.URL) string {
+	if u.RawQuery == "" {
+		return u.Redacted()
+	}
+
+	masked := *u
+	values := u.Query()
+	for key := range values {
+		if r.fields[strings.ToLower(key)] {
+			values.Set(key, redactedValue)
+		}
+	}
+	masked.RawQuery = values.Encode()
+
+	return masked.Redacted()
+}
+
+// header returns the given headers as a string with the secret ones masked.
+func (r *redactor) header(header http.Header) string {
+	masked := header.Clone()
+	for _, name := range secretHeaders {
+		if masked.Get(name) != "" {
+			masked.Set(name, redactedValue)
+		}
+	}
+
+	keys := make([]string, 0, len(masked))
+	for key := range masked {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, key+": "+strings.Join(masked[key], ", "))
+	}
+
+	return strings.Join(parts, "; ")
+}
+
+// body returns the given body with the values of the redacted fields masked.
+func (r *redactor) body(body []byte) []byte {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil || dec.More() {
+		return r.pattern.ReplaceAll(body, []byte("${1}"+redactedValue))
+	}
+
+	masked := &bytes.Buffer{}
+	enc := json.NewEncoder(masked)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(r.value(v)); err != nil {
+		return r.pattern.ReplaceAll(body, []byte("${1}"+redactedValue))
+	}
+
+	return bytes.TrimSuffix(masked.Bytes(), []byte("\n"))
+}
+
+// value masks the redacted fields of a decoded JSON value recursively.
+func (r *redactor) value(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if r.fields[strings.ToLower(key)] && value != nil {
+				v[key] = redactedValue
+				continue
+			}
+			v[key] = r.value(value)
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = r.value(value)
+		}
+	}
+
+	return v
+}
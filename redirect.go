@@ -11,6 +11,7 @@ const redirectsBasePath = "redirects"
 // See https://help.shopify.com/api/reference/online_store/redirect
 type RedirectService interface {
 	List(interface{}) ([]Redirect, error)
+	ListWithPagination(interface{}) ([]Redirect, *Pagination, error)
 	Count(interface{}) (int, error)
 	Get(int64, interface{}) (*Redirect, error)
 	Create(Redirect) (*Redirect, error)
@@ -43,10 +44,24 @@ type RedirectsResource struct {
 
 // List redirects
 func (s *RedirectServiceOp) List(options interface{}) ([]Redirect, error) {
+	redirects, _, err := s.ListWithPagination(options)
+	if err != nil {
+		return nil, err
+	}
+	return redirects, nil
+}
+
+// ListWithPagination lists redirects and return pagination to retrieve next/previous results.
+func (s *RedirectServiceOp) ListWithPagination(options interface{}) ([]Redirect, *Pagination, error) {
 	path := fmt.Sprintf("%s.json", redirectsBasePath)
 	resource := new(RedirectsResource)
-	err := s.client.Get(path, resource, options, true)
-	return resource.Redirects, err
+
+	pagination, err := s.client.ListWithPagination(path, resource, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resource.Redirects, pagination, nil
 }
 
 // Count redirects
@@ -0,0 +1,174 @@
+package synergyshopify
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy configures which failed requests are retried and how long the
+// client waits between attempts, see WithRetryPolicy.
+//
+// Requests with a non-idempotent method (POST, PATCH) may already have been
+// applied by Shopify when they fail, so they are only replayed when the caller
+// marked them safe with MarkIdempotent. Rate limited (429) requests are always
+// retried since Shopify rejects them before processing them.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// Values below 2 disable retries.
+	MaxAttempts int
+
+	// BaseDelay is the wait before the first retry, it doubles on every
+	// following retry up to MaxDelay. Rate limited requests wait for the
+	// Retry-After header instead.
+	BaseDelay time.Duration
+
+	// MaxDelay caps the wait between attempts, no cap when zero.
+	MaxDelay time.Duration
+
+	// Jitter randomizes every wait by up to the given fraction of it, e.g. 0.2
+	// for +/- 20%.
+	Jitter float64
+
+	// RetryableStatusCodes lists the response status codes that are retried
+	// besides 429.
+	RetryableStatusCodes []int
+
+	// RetryOnTransportError retries requests that failed without a response,
+	// e.g. because of a network error or a timeout. Permanent failures such as
+	// invalid certificates are never retried, see IsTransient.
+	RetryOnTransportError bool
+}
+
+// DefaultRetryPolicy returns a policy making up to maxAttempts attempts with an
+// exponential backoff, retrying network errors and 500, 502, 503 and 504
+// responses.
+func DefaultRetryPolicy(maxAttempts int) RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: maxAttempts,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    30 * time.Second,
+		Jitter:      0.2,
+		RetryableStatusCodes: []int{
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		RetryOnTransportError: true,
+	}
+}
+
+// retryPolicy returns the policy configured with WithRetryPolicy, or the one
+// equivalent to WithRetry: rate limits and 503s are retried right away.
+func (c *Client) retryPolicy() RetryPolicy {
+	if c.retry != nil {
+		return *c.retry
+	}
+
+	return RetryPolicy{
+		MaxAttempts:          c.retries,
+		RetryableStatusCodes: []int{http.StatusServiceUnavailable},
+	}
+}
+
+type idempotentContextKey struct{}
+
+// MarkIdempotent returns a copy of ctx marking the requests made with it as
+// safe to replay, which allows retrying failed POST and PATCH requests.
+func MarkIdempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentContextKey{}, true)
+}
+
+// isIdempotent reports whether req can be sent again without side effects.
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	marked, _ := req.Context().Value(idempotentContextKey{}).(bool)
+	return marked
+}
+
+// retryTransportError reports whether a request that failed with err after
+// the given number of attempts should be retried.
+func (p RetryPolicy) retryTransportError(req *http.Request, err error, attempts int) bool {
+	if attempts >= p.MaxAttempts || !p.RetryOnTransportError || req.Context().Err() != nil {
+		return false
+	}
+
+	if !IsTransient(err) {
+		return false
+	}
+
+	return isIdempotent(req)
+}
+
+// retryResponse reports whether a request that failed with respErr after the
+// given number of attempts should be retried, and how long to wait first.
+func (p RetryPolicy) retryResponse(req *http.Request, respErr error, attempts int) (time.Duration, bool) {
+	if attempts >= p.MaxAttempts {
+		return 0, false
+	}
+
+	var rateLimitErr RateLimitError
+	if errors.As(respErr, &rateLimitErr) {
+		return time.Duration(rateLimitErr.RetryAfter) * time.Second, true
+	}
+
+	if !isIdempotent(req) {
+		return 0, false
+	}
+
+	status := errorStatus(respErr)
+	for _, code := range p.RetryableStatusCodes {
+		if code == status {
+			return p.backoff(attempts), true
+		}
+	}
+
+	return 0, false
+}
+
+// backoff returns the wait before the next attempt after the given number of
+// attempts.
+func (p RetryPolicy) backoff(attempts int) time.Duration {
+	if p.BaseDelay <= 0 {
+		return 0
+	}
+
+	delay := float64(p.BaseDelay) * math.Pow(2, float64(attempts-1))
+	if p.MaxDelay > 0 {
+		delay = math.Min(delay, float64(p.MaxDelay))
+	}
+
+	if p.Jitter > 0 {
+		delay += delay * p.Jitter * (2*rand.Float64() - 1)
+	}
+
+	return time.Duration(delay)
+}
+
+// errorStatus returns the response status code carried by err, if any.
+func errorStatus(err error) int {
+	var responseErr ResponseError
+	if errors.As(err, &responseErr) {
+		return responseErr.Status
+	}
+
+	var rateLimitErr RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		return rateLimitErr.Status
+	}
+
+	var decodingErr ResponseDecodingError
+	if errors.As(err, &decodingErr) {
+		return decodingErr.Status
+	}
+
+	return 0
+}
@@ -0,0 +1,203 @@
+package synergyshopify
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func retryPolicyClient(policy RetryPolicy) *Client {
+	c := NewClient(app, "fooshop", "abcd", WithVersion(testApiVersion), WithRetryPolicy(policy))
+	httpmock.ActivateNonDefault(c.Client)
+	return c
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	cases := []struct {
+		attempts int
+		expected time.Duration
+	}{
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{3, 400 * time.Millisecond},
+		{4, 800 * time.Millisecond},
+		{5, time.Second},
+		{10, time.Second},
+	}
+
+	for _, c := range cases {
+		if actual := policy.backoff(c.attempts); actual != c.expected {
+			t.Errorf("RetryPolicy.backoff(%d) = %s, expected %s", c.attempts, actual, c.expected)
+		}
+	}
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		actual := policy.backoff(1)
+		if actual < 50*time.Millisecond || actual > 150*time.Millisecond {
+			t.Fatalf("RetryPolicy.backoff with jitter = %s, expected between 50ms and 150ms", actual)
+		}
+	}
+
+	if actual := (RetryPolicy{}).backoff(3); actual != 0 {
+		t.Errorf("RetryPolicy.backoff without delay = %s, expected 0", actual)
+	}
+}
+
+func TestRetryPolicyTransportError(t *testing.T) {
+	c := retryPolicyClient(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, RetryOnTransportError: true})
+	defer httpmock.DeactivateAndReset()
+
+	calls := 0
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/foo/1",
+		func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls == 1 {
+				return nil, errors.New("connection reset by peer")
+			}
+			return httpmock.NewStringResponse(200, `{}`), nil
+		})
+
+	req, _ := c.NewRequest("GET", "foo/1", nil, nil)
+	if err := c.ProcessRequest(req, nil); err != nil {
+		t.Errorf("ProcessRequest returned error: %v", err)
+	}
+
+	if calls != 2 {
+		t.Errorf("ProcessRequest expected 2 calls, actual %d", calls)
+	}
+}
+
+func TestRetryPolicyClientTimeout(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			time.Sleep(200 * time.Millisecond)
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	target, _ := url.Parse(server.URL)
+	toServer := func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			req.URL.Scheme, req.URL.Host = target.Scheme, target.Host
+			return next(req)
+		}
+	}
+	policy := DefaultRetryPolicy(3)
+	policy.BaseDelay = time.Millisecond
+	c := NewClient(app, "fooshop", "abcd", WithVersion(testApiVersion), WithRetryPolicy(policy),
+		WithHTTPClient(&http.Client{Timeout: 50 * time.Millisecond}), WithMiddleware(toServer))
+
+	if err := c.Get("foo/1", nil, nil, false); err != nil {
+		t.Errorf("Get returned error: %v", err)
+	}
+	if calls := atomic.LoadInt32(&calls); calls != 2 {
+		t.Errorf("expected the request to be retried after the client timeout, got %d calls", calls)
+	}
+
+	// the deadline of the caller isn't retried
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	atomic.StoreInt32(&calls, 0)
+	if err := c.GetContext(ctx, "foo/1", nil, nil, false); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetContext returned %v, expected %v", err, context.DeadlineExceeded)
+	}
+	if calls := atomic.LoadInt32(&calls); calls != 1 {
+		t.Errorf("expected a single attempt past the deadline of the context, got %d calls", calls)
+	}
+}
+
+func TestRetryPolicyStatusCodes(t *testing.T) {
+	c := retryPolicyClient(DefaultRetryPolicy(3))
+	defer httpmock.DeactivateAndReset()
+	c.retry.BaseDelay = time.Millisecond
+
+	cases := []struct {
+		description string
+		method      string
+		ctx         context.Context
+		status      int
+		calls       int
+	}{
+		{"GET 500 is retried", "GET", context.Background(), 500, 3},
+		{"GET 504 is retried", "GET", context.Background(), 504, 3},
+		{"GET 404 is not retried", "GET", context.Background(), 404, 1},
+		{"DELETE 502 is retried", "DELETE", context.Background(), 502, 3},
+		{"POST 500 is not replayed", "POST", context.Background(), 500, 1},
+		{"POST 429 is retried", "POST", context.Background(), 429, 3},
+		{"POST marked idempotent is replayed", "POST", MarkIdempotent(context.Background()), 500, 3},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			httpmock.Reset()
+			calls := 0
+			httpmock.RegisterResponder(tc.method, "https://fooshop.myshopify.com/foo/1",
+				func(req *http.Request) (*http.Response, error) {
+					calls++
+					resp := httpmock.NewStringResponse(tc.status, `{"errors": "failed"}`)
+					resp.Header.Set("Retry-After", "0.001")
+					return resp, nil
+				})
+
+			req, _ := c.NewRequestWithContext(tc.ctx, tc.method, "foo/1", nil, nil)
+			err := c.ProcessRequest(req, nil)
+			if err == nil {
+				t.Fatal("ProcessRequest expected an error")
+			}
+
+			if calls != tc.calls {
+				t.Errorf("ProcessRequest expected %d calls, actual %d", tc.calls, calls)
+			}
+
+			var requestErr RequestError
+			if tc.calls > 1 && (!errors.As(err, &requestErr) || requestErr.Attempts != tc.calls) {
+				t.Errorf("ProcessRequest expected error with %d attempts, actual %#v", tc.calls, err)
+			}
+
+			if errorStatus(err) != tc.status {
+				t.Errorf("ProcessRequest expected error with status %d, actual %#v", tc.status, err)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyReplaysBody(t *testing.T) {
+	c := retryPolicyClient(RetryPolicy{MaxAttempts: 2, RetryableStatusCodes: []int{http.StatusServiceUnavailable}})
+	defer httpmock.DeactivateAndReset()
+
+	var bodies []string
+	httpmock.RegisterResponder("POST", "https://fooshop.myshopify.com/foo/1",
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+			bodies = append(bodies, string(body))
+			if len(bodies) == 1 {
+				return httpmock.NewStringResponse(503, ``), nil
+			}
+			return httpmock.NewStringResponse(200, `{}`), nil
+		})
+
+	data := map[string]string{"foo": "bar"}
+	req, _ := c.NewRequestWithContext(MarkIdempotent(context.Background()), "POST", "foo/1", data, nil)
+	if err := c.ProcessRequest(req, nil); err != nil {
+		t.Errorf("ProcessRequest returned error: %v", err)
+	}
+
+	expected := []string{`{"foo":"bar"}`, `{"foo":"bar"}`}
+	if !reflect.DeepEqual(bodies, expected) {
+		t.Errorf("ProcessRequest sent bodies %v, expected %v", bodies, expected)
+	}
+}
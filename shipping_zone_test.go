@@ -50,7 +50,7 @@ func TestShippingZoneListError(t *testing.T) {
 	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/shipping_zones.json", client.pathPrefix),
 		httpmock.NewStringResponder(500, ""))
 
-	expectedErrMessage := "Unknown Error"
+	expectedErrMessage := fmt.Sprintf("Unknown Error (GET /%s/shipping_zones.json, api version %s, 1 attempt)", client.pathPrefix, testApiVersion)
 
 	shippingZones, err := client.ShippingZone.List()
 	if shippingZones != nil {
@@ -0,0 +1,334 @@
+//go:build ignore
+
+// gen writes services_gen.go, the fakes of the service interfaces of the
+// synergyshopify package. Run it with go generate after changing a service.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	sourceDir  = ".."
+	outputFile = "services_gen.go"
+	pkgName    = "shopify"
+	pkgPath    = "github.com/binodsynergytechs/synergyshopify"
+)
+
+type method struct {
+	name     string
+	params   []string
+	results  []string
+	ellipsis bool
+}
+
+type service struct {
+	name    string
+	methods []method
+}
+
+func main() {
+	fset := token.NewFileSet()
+	files, err := filepath.Glob(filepath.Join(sourceDir, "*.go"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var parsed []*ast.File
+	for _, name := range files {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, name, nil, parser.SkipObjectResolution)
+		if err != nil {
+			log.Fatal(err)
+		}
+		parsed = append(parsed, f)
+	}
+
+	g := &generator{
+		fset:       fset,
+		types:      map[string]bool{},
+		interfaces: map[string]*ast.InterfaceType{},
+		imports:    map[string]string{},
+		used:       map[string]bool{pkgName: true},
+	}
+	g.collect(parsed)
+
+	var names []string
+	for name := range g.interfaces {
+		if strings.HasSuffix(name, "Service") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var services []service
+	for _, name := range names {
+		services = append(services, service{name: name, methods: g.methods(name)})
+	}
+
+	src := g.render(services, g.clientFields(parsed))
+	formatted, err := format.Source(src)
+	if err != nil {
+		log.Fatalf("formatting generated code: %v\n%s", err, src)
+	}
+	if err := os.WriteFile(outputFile, formatted, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+type generator struct {
+	fset       *token.FileSet
+	types      map[string]bool
+	interfaces map[string]*ast.InterfaceType
+	imports    map[string]string
+	used       map[string]bool
+}
+
+// collect indexes the types and imports of the package.
+func (g *generator) collect(files []*ast.File) {
+	for _, f := range files {
+		for _, spec := range f.Imports {
+			path, _ := strconv.Unquote(spec.Path.Value)
+			name := filepath.Base(path)
+			if spec.Name != nil {
+				name = spec.Name.Name
+			}
+			g.imports[name] = path
+		}
+
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				ts := spec.(*ast.TypeSpec)
+				g.types[ts.Name.Name] = true
+				if it, ok := ts.Type.(*ast.InterfaceType); ok {
+					g.interfaces[ts.Name.Name] = it
+				}
+			}
+		}
+	}
+}
+
+// methods returns the methods of an interface, including the ones of the
+// interfaces it embeds.
+func (g *generator) methods(name string) []method {
+	var methods []method
+	for _, field := range g.interfaces[name].Methods.List {
+		fn, ok := field.Type.(*ast.FuncType)
+		if !ok {
+			embedded := field.Type.(*ast.Ident).Name
+			methods = append(methods, g.methods(embedded)...)
+			continue
+		}
+
+		m := method{name: field.Names[0].Name}
+		for _, p := range fn.Params.List {
+			typ := p.Type
+			if e, ok := typ.(*ast.Ellipsis); ok {
+				m.ellipsis = true
+				typ = &ast.ArrayType{Elt: e.Elt}
+			}
+			for i := 0; i < max(1, len(p.Names)); i++ {
+				m.params = append(m.params, g.expr(typ))
+			}
+		}
+		if fn.Results != nil {
+			for _, r := range fn.Results.List {
+				for i := 0; i < max(1, len(r.Names)); i++ {
+					m.results = append(m.results, g.expr(r.Type))
+				}
+			}
+		}
+		methods = append(methods, m)
+	}
+	return methods
+}
+
+// clientFields returns the service fields of the Client struct, by name.
+func (g *generator) clientFields(files []*ast.File) [][2]string {
+	var fields [][2]string
+	for _, f := range files {
+		ast.Inspect(f, func(n ast.Node) bool {
+			ts, ok := n.(*ast.TypeSpec)
+			if !ok || ts.Name.Name != "Client" {
+				return true
+			}
+			for _, field := range ts.Type.(*ast.StructType).Fields.List {
+				ident, ok := field.Type.(*ast.Ident)
+				if !ok || g.interfaces[ident.Name] == nil || !strings.HasSuffix(ident.Name, "Service") {
+					continue
+				}
+				for _, name := range field.Names {
+					fields = append(fields, [2]string{name.Name, ident.Name})
+				}
+			}
+			return false
+		})
+	}
+	return fields
+}
+
+// expr prints a type, qualifying the types of the package.
+func (g *generator) expr(e ast.Expr) string {
+	ast.Inspect(e, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			if x, ok := n.X.(*ast.Ident); ok {
+				g.used[x.Name] = true
+			}
+			return false
+		case *ast.Ident:
+			if g.types[n.Name] && ast.IsExported(n.Name) {
+				n.Name = pkgName + "." + n.Name
+			}
+		}
+		return true
+	})
+
+	var buf bytes.Buffer
+	printer.Fprint(&buf, g.fset, e)
+	return buf.String()
+}
+
+func (g *generator) render(services []service, fields [][2]string) []byte {
+	var buf bytes.Buffer
+	p := func(format string, args ...interface{}) { fmt.Fprintf(&buf, format+"\n", args...) }
+
+	p("// Code generated by gen.go; DO NOT EDIT.")
+	p("")
+	p("package shopifymock")
+	p("")
+	p("import (")
+	var names []string
+	for name := range g.used {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		switch name {
+		case pkgName:
+			p("%s %q", pkgName, pkgPath)
+		default:
+			p("%q", g.imports[name])
+		}
+	}
+	p(")")
+
+	for _, s := range services {
+		p("")
+		p("// %s is a fake of synergyshopify.%s.", s.name, s.name)
+		p("type %s struct {", s.name)
+		p("CallRecorder")
+		p("")
+		for _, m := range s.methods {
+			p("%sFunc func(%s) %s", m.name, m.paramList(false), m.resultList())
+		}
+		p("}")
+
+		p("")
+		p("var _ %s.%s = (*%s)(nil)", pkgName, s.name, s.name)
+
+		for _, m := range s.methods {
+			args := make([]string, len(m.params))
+			for i := range m.params {
+				args[i] = fmt.Sprintf("a%d", i)
+			}
+			callArgs := strings.Join(args, ", ")
+			if m.ellipsis {
+				callArgs += "..."
+			}
+
+			p("")
+			p("func (m *%s) %s(%s) %s {", s.name, m.name, m.paramList(true), m.resultList())
+			p("m.record(%q%s)", m.name, prefixed(args))
+			p("if m.%sFunc != nil {", m.name)
+			if len(m.results) > 0 {
+				p("return m.%sFunc(%s)", m.name, callArgs)
+			} else {
+				p("m.%sFunc(%s)", m.name, callArgs)
+				p("return")
+			}
+			p("}")
+			if len(m.results) > 0 {
+				zeros := make([]string, len(m.results))
+				for i, r := range m.results {
+					p("var r%d %s", i, r)
+					zeros[i] = fmt.Sprintf("r%d", i)
+				}
+				p("return %s", strings.Join(zeros, ", "))
+			}
+			p("}")
+		}
+	}
+
+	p("")
+	p("// Services holds a fake of every service of a synergyshopify.Client.")
+	p("type Services struct {")
+	for _, f := range fields {
+		p("%s *%s", f[0], f[1])
+	}
+	p("}")
+	p("")
+	p("// NewServices returns a fake of every service of a client.")
+	p("func NewServices() *Services {")
+	p("return &Services{")
+	for _, f := range fields {
+		p("%s: new(%s),", f[0], f[1])
+	}
+	p("}")
+	p("}")
+	p("")
+	p("// apply points the service fields of a client at the fakes.")
+	p("func (s *Services) apply(c *%s.Client) {", pkgName)
+	for _, f := range fields {
+		p("c.%s = s.%s", f[0], f[0])
+	}
+	p("}")
+
+	return buf.Bytes()
+}
+
+func (m method) paramList(named bool) string {
+	params := make([]string, len(m.params))
+	for i, typ := range m.params {
+		if m.ellipsis && i == len(m.params)-1 {
+			typ = "..." + strings.TrimPrefix(typ, "[]")
+		}
+		if named {
+			typ = fmt.Sprintf("a%d %s", i, typ)
+		}
+		params[i] = typ
+	}
+	return strings.Join(params, ", ")
+}
+
+func (m method) resultList() string {
+	if len(m.results) < 2 {
+		return strings.Join(m.results, "")
+	}
+	return "(" + strings.Join(m.results, ", ") + ")"
+}
+
+func prefixed(args []string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	return ", " + strings.Join(args, ", ")
+}
@@ -0,0 +1,95 @@
+// Package shopifymock provides fakes of the service interfaces of
+// synergyshopify, to test code depending on them without a shop:
+//
+//	services := shopifymock.NewServices()
+//	services.Product.GetFunc = func(id int64, _ interface{}) (*synergyshopify.Product, error) {
+//		return &synergyshopify.Product{ID: id, Title: "Shirt"}, nil
+//	}
+//
+//	client := services.Client()
+//	// ... code under test using client.Product
+//
+//	calls := services.Product.CallsTo("Get")
+//
+// Every fake records its calls and returns zero values for the methods
+// without a Func override. The fakes are generated from the interfaces by
+// gen.go.
+package shopifymock
+
+//go:generate go run gen.go
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+
+	shopify "github.com/binodsynergytechs/synergyshopify"
+)
+
+// ErrUnexpectedRequest is returned for the requests sent by the clients of
+// Services.Client, e.g. through Client.Get, which no fake handles.
+var ErrUnexpectedRequest = errors.New("shopifymock: unexpected request")
+
+// Call is a call of a fake method with its arguments.
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+// CallRecorder records the calls of a fake. It is safe for concurrent use.
+type CallRecorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+func (r *CallRecorder) record(method string, args ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = append(r.calls, Call{Method: method, Args: args})
+}
+
+// Calls returns the calls of the fake, in order.
+func (r *CallRecorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Call(nil), r.calls...)
+}
+
+// CallsTo returns the calls of the given method, in order.
+func (r *CallRecorder) CallsTo(method string) []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var calls []Call
+	for _, call := range r.calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// ResetCalls forgets the calls recorded so far.
+func (r *CallRecorder) ResetCalls() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = nil
+}
+
+// Client returns a client whose service fields are the fakes. Requests sent
+// by the client itself fail with ErrUnexpectedRequest.
+func (s *Services) Client() *shopify.Client {
+	c := shopify.NewClient(shopify.App{}, "fakeshop", "token",
+		shopify.WithHTTPClient(&http.Client{Transport: unexpectedTransport{}}))
+	s.apply(c)
+	return c
+}
+
+type unexpectedTransport struct{}
+
+func (unexpectedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return nil, ErrUnexpectedRequest
+}
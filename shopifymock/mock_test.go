@@ -0,0 +1,76 @@
+package shopifymock
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	shopify "github.com/binodsynergytechs/synergyshopify"
+)
+
+func TestServicesClient(t *testing.T) {
+	services := NewServices()
+	services.Product.GetFunc = func(id int64, _ interface{}) (*shopify.Product, error) {
+		return &shopify.Product{ID: id, Title: "Shirt"}, nil
+	}
+	services.Order.CreateFulfillmentFunc = func(orderID int64, f shopify.Fulfillment) (*shopify.Fulfillment, error) {
+		return nil, shopify.ErrNotFound
+	}
+
+	client := services.Client()
+
+	product, err := client.Product.Get(1, nil)
+	if err != nil || product.Title != "Shirt" {
+		t.Errorf("Product.Get returned %+v, %v", product, err)
+	}
+
+	if _, err := client.Order.CreateFulfillment(2, shopify.Fulfillment{}); !errors.Is(err, shopify.ErrNotFound) {
+		t.Errorf("expected the override of an embedded interface method to be called, got %v", err)
+	}
+
+	// methods without override return zero values
+	count, err := client.Product.CountMetafields(1, nil)
+	if count != 0 || err != nil {
+		t.Errorf("Product.CountMetafields returned %d, %v", count, err)
+	}
+
+	calls := services.Product.Calls()
+	if len(calls) != 2 || calls[0].Method != "Get" || calls[0].Args[0] != int64(1) || calls[1].Method != "CountMetafields" {
+		t.Errorf("unexpected calls %+v", calls)
+	}
+	if len(services.Order.CallsTo("CreateFulfillment")) != 1 {
+		t.Error("expected the CreateFulfillment call to be recorded")
+	}
+
+	services.Product.ResetCalls()
+	if len(services.Product.Calls()) != 0 {
+		t.Error("expected ResetCalls to forget the calls")
+	}
+
+	if _, err := client.Shop.Get(nil); err != nil {
+		t.Errorf("Shop.Get returned %v", err)
+	}
+	if err := client.Get("shop.json", nil, nil, true); !errors.Is(err, ErrUnexpectedRequest) {
+		t.Errorf("expected direct requests to fail with %v, got %v", ErrUnexpectedRequest, err)
+	}
+}
+
+func TestServicesClientWithContext(t *testing.T) {
+	services := NewServices()
+	services.Shop.GetFunc = func(_ interface{}) (*shopify.Shop, error) {
+		return &shopify.Shop{Name: "Fake"}, nil
+	}
+
+	client := services.Client().WithContext(context.Background())
+
+	shop, err := client.Shop.Get(nil)
+	if err != nil || shop.Name != "Fake" {
+		t.Errorf("Shop.Get returned %+v, %v", shop, err)
+	}
+	if len(services.Shop.Calls()) != 1 {
+		t.Error("expected the fake to be kept by WithContext")
+	}
+	if err := client.Get("shop.json", nil, nil, true); !errors.Is(err, ErrUnexpectedRequest) {
+		t.Errorf("expected direct requests to fail with %v, got %v", ErrUnexpectedRequest, err)
+	}
+}
@@ -0,0 +1,3785 @@
+// Code generated by gen.go; DO NOT EDIT.
+
+package shopifymock
+
+import (
+	"context"
+	shopify "github.com/binodsynergytechs/synergyshopify"
+)
+
+// AbandonedCheckoutService is a fake of synergyshopify.AbandonedCheckoutService.
+type AbandonedCheckoutService struct {
+	CallRecorder
+
+	ListFunc func(interface{}) ([]shopify.AbandonedCheckout, error)
+}
+
+var _ shopify.AbandonedCheckoutService = (*AbandonedCheckoutService)(nil)
+
+func (m *AbandonedCheckoutService) List(a0 interface{}) ([]shopify.AbandonedCheckout, error) {
+	m.record("List", a0)
+	if m.ListFunc != nil {
+		return m.ListFunc(a0)
+	}
+	var r0 []shopify.AbandonedCheckout
+	var r1 error
+	return r0, r1
+}
+
+// AccessScopesService is a fake of synergyshopify.AccessScopesService.
+type AccessScopesService struct {
+	CallRecorder
+
+	ListFunc func(interface{}) ([]shopify.AccessScope, error)
+}
+
+var _ shopify.AccessScopesService = (*AccessScopesService)(nil)
+
+func (m *AccessScopesService) List(a0 interface{}) ([]shopify.AccessScope, error) {
+	m.record("List", a0)
+	if m.ListFunc != nil {
+		return m.ListFunc(a0)
+	}
+	var r0 []shopify.AccessScope
+	var r1 error
+	return r0, r1
+}
+
+// ApplicationChargeService is a fake of synergyshopify.ApplicationChargeService.
+type ApplicationChargeService struct {
+	CallRecorder
+
+	CreateFunc   func(shopify.ApplicationCharge) (*shopify.ApplicationCharge, error)
+	GetFunc      func(int64, interface{}) (*shopify.ApplicationCharge, error)
+	ListFunc     func(interface{}) ([]shopify.ApplicationCharge, error)
+	ActivateFunc func(shopify.ApplicationCharge) (*shopify.ApplicationCharge, error)
+}
+
+var _ shopify.ApplicationChargeService = (*ApplicationChargeService)(nil)
+
+func (m *ApplicationChargeService) Create(a0 shopify.ApplicationCharge) (*shopify.ApplicationCharge, error) {
+	m.record("Create", a0)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(a0)
+	}
+	var r0 *shopify.ApplicationCharge
+	var r1 error
+	return r0, r1
+}
+
+func (m *ApplicationChargeService) Get(a0 int64, a1 interface{}) (*shopify.ApplicationCharge, error) {
+	m.record("Get", a0, a1)
+	if m.GetFunc != nil {
+		return m.GetFunc(a0, a1)
+	}
+	var r0 *shopify.ApplicationCharge
+	var r1 error
+	return r0, r1
+}
+
+func (m *ApplicationChargeService) List(a0 interface{}) ([]shopify.ApplicationCharge, error) {
+	m.record("List", a0)
+	if m.ListFunc != nil {
+		return m.ListFunc(a0)
+	}
+	var r0 []shopify.ApplicationCharge
+	var r1 error
+	return r0, r1
+}
+
+func (m *ApplicationChargeService) Activate(a0 shopify.ApplicationCharge) (*shopify.ApplicationCharge, error) {
+	m.record("Activate", a0)
+	if m.ActivateFunc != nil {
+		return m.ActivateFunc(a0)
+	}
+	var r0 *shopify.ApplicationCharge
+	var r1 error
+	return r0, r1
+}
+
+// AssetService is a fake of synergyshopify.AssetService.
+type AssetService struct {
+	CallRecorder
+
+	ListFunc   func(int64, interface{}) ([]shopify.Asset, error)
+	GetFunc    func(int64, string) (*shopify.Asset, error)
+	UpdateFunc func(int64, shopify.Asset) (*shopify.Asset, error)
+	DeleteFunc func(int64, string) error
+}
+
+var _ shopify.AssetService = (*AssetService)(nil)
+
+func (m *AssetService) List(a0 int64, a1 interface{}) ([]shopify.Asset, error) {
+	m.record("List", a0, a1)
+	if m.ListFunc != nil {
+		return m.ListFunc(a0, a1)
+	}
+	var r0 []shopify.Asset
+	var r1 error
+	return r0, r1
+}
+
+func (m *AssetService) Get(a0 int64, a1 string) (*shopify.Asset, error) {
+	m.record("Get", a0, a1)
+	if m.GetFunc != nil {
+		return m.GetFunc(a0, a1)
+	}
+	var r0 *shopify.Asset
+	var r1 error
+	return r0, r1
+}
+
+func (m *AssetService) Update(a0 int64, a1 shopify.Asset) (*shopify.Asset, error) {
+	m.record("Update", a0, a1)
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(a0, a1)
+	}
+	var r0 *shopify.Asset
+	var r1 error
+	return r0, r1
+}
+
+func (m *AssetService) Delete(a0 int64, a1 string) error {
+	m.record("Delete", a0, a1)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(a0, a1)
+	}
+	var r0 error
+	return r0
+}
+
+// BlogService is a fake of synergyshopify.BlogService.
+type BlogService struct {
+	CallRecorder
+
+	ListFunc   func(interface{}) ([]shopify.Blog, error)
+	CountFunc  func(interface{}) (int, error)
+	GetFunc    func(int64, interface{}) (*shopify.Blog, error)
+	CreateFunc func(shopify.Blog) (*shopify.Blog, error)
+	UpdateFunc func(shopify.Blog) (*shopify.Blog, error)
+	DeleteFunc func(int64) error
+}
+
+var _ shopify.BlogService = (*BlogService)(nil)
+
+func (m *BlogService) List(a0 interface{}) ([]shopify.Blog, error) {
+	m.record("List", a0)
+	if m.ListFunc != nil {
+		return m.ListFunc(a0)
+	}
+	var r0 []shopify.Blog
+	var r1 error
+	return r0, r1
+}
+
+func (m *BlogService) Count(a0 interface{}) (int, error) {
+	m.record("Count", a0)
+	if m.CountFunc != nil {
+		return m.CountFunc(a0)
+	}
+	var r0 int
+	var r1 error
+	return r0, r1
+}
+
+func (m *BlogService) Get(a0 int64, a1 interface{}) (*shopify.Blog, error) {
+	m.record("Get", a0, a1)
+	if m.GetFunc != nil {
+		return m.GetFunc(a0, a1)
+	}
+	var r0 *shopify.Blog
+	var r1 error
+	return r0, r1
+}
+
+func (m *BlogService) Create(a0 shopify.Blog) (*shopify.Blog, error) {
+	m.record("Create", a0)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(a0)
+	}
+	var r0 *shopify.Blog
+	var r1 error
+	return r0, r1
+}
+
+func (m *BlogService) Update(a0 shopify.Blog) (*shopify.Blog, error) {
+	m.record("Update", a0)
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(a0)
+	}
+	var r0 *shopify.Blog
+	var r1 error
+	return r0, r1
+}
+
+func (m *BlogService) Delete(a0 int64) error {
+	m.record("Delete", a0)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(a0)
+	}
+	var r0 error
+	return r0
+}
+
+// BulkOperationService is a fake of synergyshopify.BulkOperationService.
+type BulkOperationService struct {
+	CallRecorder
+
+	RunQueryFunc    func(string) (*shopify.BulkOperation, error)
+	RunMutationFunc func(string, string) (*shopify.BulkOperation, error)
+	CurrentFunc     func(string) (*shopify.BulkOperation, error)
+	WaitFunc        func(string) (*shopify.BulkOperation, error)
+	CancelFunc      func(string) (*shopify.BulkOperation, error)
+}
+
+var _ shopify.BulkOperationService = (*BulkOperationService)(nil)
+
+func (m *BulkOperationService) RunQuery(a0 string) (*shopify.BulkOperation, error) {
+	m.record("RunQuery", a0)
+	if m.RunQueryFunc != nil {
+		return m.RunQueryFunc(a0)
+	}
+	var r0 *shopify.BulkOperation
+	var r1 error
+	return r0, r1
+}
+
+func (m *BulkOperationService) RunMutation(a0 string, a1 string) (*shopify.BulkOperation, error) {
+	m.record("RunMutation", a0, a1)
+	if m.RunMutationFunc != nil {
+		return m.RunMutationFunc(a0, a1)
+	}
+	var r0 *shopify.BulkOperation
+	var r1 error
+	return r0, r1
+}
+
+func (m *BulkOperationService) Current(a0 string) (*shopify.BulkOperation, error) {
+	m.record("Current", a0)
+	if m.CurrentFunc != nil {
+		return m.CurrentFunc(a0)
+	}
+	var r0 *shopify.BulkOperation
+	var r1 error
+	return r0, r1
+}
+
+func (m *BulkOperationService) Wait(a0 string) (*shopify.BulkOperation, error) {
+	m.record("Wait", a0)
+	if m.WaitFunc != nil {
+		return m.WaitFunc(a0)
+	}
+	var r0 *shopify.BulkOperation
+	var r1 error
+	return r0, r1
+}
+
+func (m *BulkOperationService) Cancel(a0 string) (*shopify.BulkOperation, error) {
+	m.record("Cancel", a0)
+	if m.CancelFunc != nil {
+		return m.CancelFunc(a0)
+	}
+	var r0 *shopify.BulkOperation
+	var r1 error
+	return r0, r1
+}
+
+// CarrierServiceService is a fake of synergyshopify.CarrierServiceService.
+type CarrierServiceService struct {
+	CallRecorder
+
+	ListFunc   func() ([]shopify.CarrierService, error)
+	GetFunc    func(int64) (*shopify.CarrierService, error)
+	CreateFunc func(shopify.CarrierService) (*shopify.CarrierService, error)
+	UpdateFunc func(shopify.CarrierService) (*shopify.CarrierService, error)
+	DeleteFunc func(int64) error
+}
+
+var _ shopify.CarrierServiceService = (*CarrierServiceService)(nil)
+
+func (m *CarrierServiceService) List() ([]shopify.CarrierService, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc()
+	}
+	var r0 []shopify.CarrierService
+	var r1 error
+	return r0, r1
+}
+
+func (m *CarrierServiceService) Get(a0 int64) (*shopify.CarrierService, error) {
+	m.record("Get", a0)
+	if m.GetFunc != nil {
+		return m.GetFunc(a0)
+	}
+	var r0 *shopify.CarrierService
+	var r1 error
+	return r0, r1
+}
+
+func (m *CarrierServiceService) Create(a0 shopify.CarrierService) (*shopify.CarrierService, error) {
+	m.record("Create", a0)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(a0)
+	}
+	var r0 *shopify.CarrierService
+	var r1 error
+	return r0, r1
+}
+
+func (m *CarrierServiceService) Update(a0 shopify.CarrierService) (*shopify.CarrierService, error) {
+	m.record("Update", a0)
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(a0)
+	}
+	var r0 *shopify.CarrierService
+	var r1 error
+	return r0, r1
+}
+
+func (m *CarrierServiceService) Delete(a0 int64) error {
+	m.record("Delete", a0)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(a0)
+	}
+	var r0 error
+	return r0
+}
+
+// CollectService is a fake of synergyshopify.CollectService.
+type CollectService struct {
+	CallRecorder
+
+	ListFunc   func(interface{}) ([]shopify.Collect, error)
+	CountFunc  func(interface{}) (int, error)
+	GetFunc    func(int64, interface{}) (*shopify.Collect, error)
+	CreateFunc func(shopify.Collect) (*shopify.Collect, error)
+	DeleteFunc func(int64) error
+}
+
+var _ shopify.CollectService = (*CollectService)(nil)
+
+func (m *CollectService) List(a0 interface{}) ([]shopify.Collect, error) {
+	m.record("List", a0)
+	if m.ListFunc != nil {
+		return m.ListFunc(a0)
+	}
+	var r0 []shopify.Collect
+	var r1 error
+	return r0, r1
+}
+
+func (m *CollectService) Count(a0 interface{}) (int, error) {
+	m.record("Count", a0)
+	if m.CountFunc != nil {
+		return m.CountFunc(a0)
+	}
+	var r0 int
+	var r1 error
+	return r0, r1
+}
+
+func (m *CollectService) Get(a0 int64, a1 interface{}) (*shopify.Collect, error) {
+	m.record("Get", a0, a1)
+	if m.GetFunc != nil {
+		return m.GetFunc(a0, a1)
+	}
+	var r0 *shopify.Collect
+	var r1 error
+	return r0, r1
+}
+
+func (m *CollectService) Create(a0 shopify.Collect) (*shopify.Collect, error) {
+	m.record("Create", a0)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(a0)
+	}
+	var r0 *shopify.Collect
+	var r1 error
+	return r0, r1
+}
+
+func (m *CollectService) Delete(a0 int64) error {
+	m.record("Delete", a0)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(a0)
+	}
+	var r0 error
+	return r0
+}
+
+// CollectionService is a fake of synergyshopify.CollectionService.
+type CollectionService struct {
+	CallRecorder
+
+	GetFunc                        func(int64, interface{}) (*shopify.Collection, error)
+	ListProductsFunc               func(int64, interface{}) ([]shopify.Product, error)
+	ListProductsWithPaginationFunc func(int64, interface{}) ([]shopify.Product, *shopify.Pagination, error)
+}
+
+var _ shopify.CollectionService = (*CollectionService)(nil)
+
+func (m *CollectionService) Get(a0 int64, a1 interface{}) (*shopify.Collection, error) {
+	m.record("Get", a0, a1)
+	if m.GetFunc != nil {
+		return m.GetFunc(a0, a1)
+	}
+	var r0 *shopify.Collection
+	var r1 error
+	return r0, r1
+}
+
+func (m *CollectionService) ListProducts(a0 int64, a1 interface{}) ([]shopify.Product, error) {
+	m.record("ListProducts", a0, a1)
+	if m.ListProductsFunc != nil {
+		return m.ListProductsFunc(a0, a1)
+	}
+	var r0 []shopify.Product
+	var r1 error
+	return r0, r1
+}
+
+func (m *CollectionService) ListProductsWithPagination(a0 int64, a1 interface{}) ([]shopify.Product, *shopify.Pagination, error) {
+	m.record("ListProductsWithPagination", a0, a1)
+	if m.ListProductsWithPaginationFunc != nil {
+		return m.ListProductsWithPaginationFunc(a0, a1)
+	}
+	var r0 []shopify.Product
+	var r1 *shopify.Pagination
+	var r2 error
+	return r0, r1, r2
+}
+
+// CustomCollectionService is a fake of synergyshopify.CustomCollectionService.
+type CustomCollectionService struct {
+	CallRecorder
+
+	ListFunc                         func(interface{}) ([]shopify.CustomCollection, error)
+	CountFunc                        func(interface{}) (int, error)
+	GetFunc                          func(int64, interface{}) (*shopify.CustomCollection, error)
+	CreateFunc                       func(shopify.CustomCollection) (*shopify.CustomCollection, error)
+	UpdateFunc                       func(shopify.CustomCollection) (*shopify.CustomCollection, error)
+	DeleteFunc                       func(int64) error
+	ListCollectionWithPaginationFunc func(interface{}) ([]shopify.CustomCollection, *shopify.Pagination, error)
+	ListWithPaginationsFunc          func(interface{}) ([]shopify.CustomCollection, *shopify.Pagination, error)
+	ListMetafieldsFunc               func(int64, interface{}) ([]shopify.Metafield, error)
+	CountMetafieldsFunc              func(int64, interface{}) (int, error)
+	GetMetafieldFunc                 func(int64, int64, interface{}) (*shopify.Metafield, error)
+	CreateMetafieldFunc              func(int64, shopify.Metafield) (*shopify.Metafield, error)
+	UpdateMetafieldFunc              func(int64, shopify.Metafield) (*shopify.Metafield, error)
+	DeleteMetafieldFunc              func(int64, int64) error
+}
+
+var _ shopify.CustomCollectionService = (*CustomCollectionService)(nil)
+
+func (m *CustomCollectionService) List(a0 interface{}) ([]shopify.CustomCollection, error) {
+	m.record("List", a0)
+	if m.ListFunc != nil {
+		return m.ListFunc(a0)
+	}
+	var r0 []shopify.CustomCollection
+	var r1 error
+	return r0, r1
+}
+
+func (m *CustomCollectionService) Count(a0 interface{}) (int, error) {
+	m.record("Count", a0)
+	if m.CountFunc != nil {
+		return m.CountFunc(a0)
+	}
+	var r0 int
+	var r1 error
+	return r0, r1
+}
+
+func (m *CustomCollectionService) Get(a0 int64, a1 interface{}) (*shopify.CustomCollection, error) {
+	m.record("Get", a0, a1)
+	if m.GetFunc != nil {
+		return m.GetFunc(a0, a1)
+	}
+	var r0 *shopify.CustomCollection
+	var r1 error
+	return r0, r1
+}
+
+func (m *CustomCollectionService) Create(a0 shopify.CustomCollection) (*shopify.CustomCollection, error) {
+	m.record("Create", a0)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(a0)
+	}
+	var r0 *shopify.CustomCollection
+	var r1 error
+	return r0, r1
+}
+
+func (m *CustomCollectionService) Update(a0 shopify.CustomCollection) (*shopify.CustomCollection, error) {
+	m.record("Update", a0)
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(a0)
+	}
+	var r0 *shopify.CustomCollection
+	var r1 error
+	return r0, r1
+}
+
+func (m *CustomCollectionService) Delete(a0 int64) error {
+	m.record("Delete", a0)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(a0)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *CustomCollectionService) ListCollectionWithPagination(a0 interface{}) ([]shopify.CustomCollection, *shopify.Pagination, error) {
+	m.record("ListCollectionWithPagination", a0)
+	if m.ListCollectionWithPaginationFunc != nil {
+		return m.ListCollectionWithPaginationFunc(a0)
+	}
+	var r0 []shopify.CustomCollection
+	var r1 *shopify.Pagination
+	var r2 error
+	return r0, r1, r2
+}
+
+func (m *CustomCollectionService) ListWithPaginations(a0 interface{}) ([]shopify.CustomCollection, *shopify.Pagination, error) {
+	m.record("ListWithPaginations", a0)
+	if m.ListWithPaginationsFunc != nil {
+		return m.ListWithPaginationsFunc(a0)
+	}
+	var r0 []shopify.CustomCollection
+	var r1 *shopify.Pagination
+	var r2 error
+	return r0, r1, r2
+}
+
+func (m *CustomCollectionService) ListMetafields(a0 int64, a1 interface{}) ([]shopify.Metafield, error) {
+	m.record("ListMetafields", a0, a1)
+	if m.ListMetafieldsFunc != nil {
+		return m.ListMetafieldsFunc(a0, a1)
+	}
+	var r0 []shopify.Metafield
+	var r1 error
+	return r0, r1
+}
+
+func (m *CustomCollectionService) CountMetafields(a0 int64, a1 interface{}) (int, error) {
+	m.record("CountMetafields", a0, a1)
+	if m.CountMetafieldsFunc != nil {
+		return m.CountMetafieldsFunc(a0, a1)
+	}
+	var r0 int
+	var r1 error
+	return r0, r1
+}
+
+func (m *CustomCollectionService) GetMetafield(a0 int64, a1 int64, a2 interface{}) (*shopify.Metafield, error) {
+	m.record("GetMetafield", a0, a1, a2)
+	if m.GetMetafieldFunc != nil {
+		return m.GetMetafieldFunc(a0, a1, a2)
+	}
+	var r0 *shopify.Metafield
+	var r1 error
+	return r0, r1
+}
+
+func (m *CustomCollectionService) CreateMetafield(a0 int64, a1 shopify.Metafield) (*shopify.Metafield, error) {
+	m.record("CreateMetafield", a0, a1)
+	if m.CreateMetafieldFunc != nil {
+		return m.CreateMetafieldFunc(a0, a1)
+	}
+	var r0 *shopify.Metafield
+	var r1 error
+	return r0, r1
+}
+
+func (m *CustomCollectionService) UpdateMetafield(a0 int64, a1 shopify.Metafield) (*shopify.Metafield, error) {
+	m.record("UpdateMetafield", a0, a1)
+	if m.UpdateMetafieldFunc != nil {
+		return m.UpdateMetafieldFunc(a0, a1)
+	}
+	var r0 *shopify.Metafield
+	var r1 error
+	return r0, r1
+}
+
+func (m *CustomCollectionService) DeleteMetafield(a0 int64, a1 int64) error {
+	m.record("DeleteMetafield", a0, a1)
+	if m.DeleteMetafieldFunc != nil {
+		return m.DeleteMetafieldFunc(a0, a1)
+	}
+	var r0 error
+	return r0
+}
+
+// CustomerAddressService is a fake of synergyshopify.CustomerAddressService.
+type CustomerAddressService struct {
+	CallRecorder
+
+	ListFunc   func(int64, interface{}) ([]shopify.CustomerAddress, error)
+	GetFunc    func(int64, int64, interface{}) (*shopify.CustomerAddress, error)
+	CreateFunc func(int64, shopify.CustomerAddress) (*shopify.CustomerAddress, error)
+	UpdateFunc func(int64, shopify.CustomerAddress) (*shopify.CustomerAddress, error)
+	DeleteFunc func(int64, int64) error
+}
+
+var _ shopify.CustomerAddressService = (*CustomerAddressService)(nil)
+
+func (m *CustomerAddressService) List(a0 int64, a1 interface{}) ([]shopify.CustomerAddress, error) {
+	m.record("List", a0, a1)
+	if m.ListFunc != nil {
+		return m.ListFunc(a0, a1)
+	}
+	var r0 []shopify.CustomerAddress
+	var r1 error
+	return r0, r1
+}
+
+func (m *CustomerAddressService) Get(a0 int64, a1 int64, a2 interface{}) (*shopify.CustomerAddress, error) {
+	m.record("Get", a0, a1, a2)
+	if m.GetFunc != nil {
+		return m.GetFunc(a0, a1, a2)
+	}
+	var r0 *shopify.CustomerAddress
+	var r1 error
+	return r0, r1
+}
+
+func (m *CustomerAddressService) Create(a0 int64, a1 shopify.CustomerAddress) (*shopify.CustomerAddress, error) {
+	m.record("Create", a0, a1)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(a0, a1)
+	}
+	var r0 *shopify.CustomerAddress
+	var r1 error
+	return r0, r1
+}
+
+func (m *CustomerAddressService) Update(a0 int64, a1 shopify.CustomerAddress) (*shopify.CustomerAddress, error) {
+	m.record("Update", a0, a1)
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(a0, a1)
+	}
+	var r0 *shopify.CustomerAddress
+	var r1 error
+	return r0, r1
+}
+
+func (m *CustomerAddressService) Delete(a0 int64, a1 int64) error {
+	m.record("Delete", a0, a1)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(a0, a1)
+	}
+	var r0 error
+	return r0
+}
+
+// CustomerService is a fake of synergyshopify.CustomerService.
+type CustomerService struct {
+	CallRecorder
+
+	ListFunc               func(interface{}) ([]shopify.Customer, error)
+	ListWithPaginationFunc func(interface{}) ([]shopify.Customer, *shopify.Pagination, error)
+	CountFunc              func(interface{}) (int, error)
+	GetFunc                func(int64, interface{}) (*shopify.Customer, error)
+	SearchFunc             func(interface{}) ([]shopify.Customer, error)
+	CreateFunc             func(shopify.Customer) (*shopify.Customer, error)
+	UpdateFunc             func(shopify.Customer) (*shopify.Customer, error)
+	DeleteFunc             func(int64) error
+	ListOrdersFunc         func(int64, interface{}) ([]shopify.Order, error)
+	ListTagsFunc           func(interface{}) ([]string, error)
+	ListMetafieldsFunc     func(int64, interface{}) ([]shopify.Metafield, error)
+	CountMetafieldsFunc    func(int64, interface{}) (int, error)
+	GetMetafieldFunc       func(int64, int64, interface{}) (*shopify.Metafield, error)
+	CreateMetafieldFunc    func(int64, shopify.Metafield) (*shopify.Metafield, error)
+	UpdateMetafieldFunc    func(int64, shopify.Metafield) (*shopify.Metafield, error)
+	DeleteMetafieldFunc    func(int64, int64) error
+}
+
+var _ shopify.CustomerService = (*CustomerService)(nil)
+
+func (m *CustomerService) List(a0 interface{}) ([]shopify.Customer, error) {
+	m.record("List", a0)
+	if m.ListFunc != nil {
+		return m.ListFunc(a0)
+	}
+	var r0 []shopify.Customer
+	var r1 error
+	return r0, r1
+}
+
+func (m *CustomerService) ListWithPagination(a0 interface{}) ([]shopify.Customer, *shopify.Pagination, error) {
+	m.record("ListWithPagination", a0)
+	if m.ListWithPaginationFunc != nil {
+		return m.ListWithPaginationFunc(a0)
+	}
+	var r0 []shopify.Customer
+	var r1 *shopify.Pagination
+	var r2 error
+	return r0, r1, r2
+}
+
+func (m *CustomerService) Count(a0 interface{}) (int, error) {
+	m.record("Count", a0)
+	if m.CountFunc != nil {
+		return m.CountFunc(a0)
+	}
+	var r0 int
+	var r1 error
+	return r0, r1
+}
+
+func (m *CustomerService) Get(a0 int64, a1 interface{}) (*shopify.Customer, error) {
+	m.record("Get", a0, a1)
+	if m.GetFunc != nil {
+		return m.GetFunc(a0, a1)
+	}
+	var r0 *shopify.Customer
+	var r1 error
+	return r0, r1
+}
+
+func (m *CustomerService) Search(a0 interface{}) ([]shopify.Customer, error) {
+	m.record("Search", a0)
+	if m.SearchFunc != nil {
+		return m.SearchFunc(a0)
+	}
+	var r0 []shopify.Customer
+	var r1 error
+	return r0, r1
+}
+
+func (m *CustomerService) Create(a0 shopify.Customer) (*shopify.Customer, error) {
+	m.record("Create", a0)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(a0)
+	}
+	var r0 *shopify.Customer
+	var r1 error
+	return r0, r1
+}
+
+func (m *CustomerService) Update(a0 shopify.Customer) (*shopify.Customer, error) {
+	m.record("Update", a0)
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(a0)
+	}
+	var r0 *shopify.Customer
+	var r1 error
+	return r0, r1
+}
+
+func (m *CustomerService) Delete(a0 int64) error {
+	m.record("Delete", a0)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(a0)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *CustomerService) ListOrders(a0 int64, a1 interface{}) ([]shopify.Order, error) {
+	m.record("ListOrders", a0, a1)
+	if m.ListOrdersFunc != nil {
+		return m.ListOrdersFunc(a0, a1)
+	}
+	var r0 []shopify.Order
+	var r1 error
+	return r0, r1
+}
+
+func (m *CustomerService) ListTags(a0 interface{}) ([]string, error) {
+	m.record("ListTags", a0)
+	if m.ListTagsFunc != nil {
+		return m.ListTagsFunc(a0)
+	}
+	var r0 []string
+	var r1 error
+	return r0, r1
+}
+
+func (m *CustomerService) ListMetafields(a0 int64, a1 interface{}) ([]shopify.Metafield, error) {
+	m.record("ListMetafields", a0, a1)
+	if m.ListMetafieldsFunc != nil {
+		return m.ListMetafieldsFunc(a0, a1)
+	}
+	var r0 []shopify.Metafield
+	var r1 error
+	return r0, r1
+}
+
+func (m *CustomerService) CountMetafields(a0 int64, a1 interface{}) (int, error) {
+	m.record("CountMetafields", a0, a1)
+	if m.CountMetafieldsFunc != nil {
+		return m.CountMetafieldsFunc(a0, a1)
+	}
+	var r0 int
+	var r1 error
+	return r0, r1
+}
+
+func (m *CustomerService) GetMetafield(a0 int64, a1 int64, a2 interface{}) (*shopify.Metafield, error) {
+	m.record("GetMetafield", a0, a1, a2)
+	if m.GetMetafieldFunc != nil {
+		return m.GetMetafieldFunc(a0, a1, a2)
+	}
+	var r0 *shopify.Metafield
+	var r1 error
+	return r0, r1
+}
+
+func (m *CustomerService) CreateMetafield(a0 int64, a1 shopify.Metafield) (*shopify.Metafield, error) {
+	m.record("CreateMetafield", a0, a1)
+	if m.CreateMetafieldFunc != nil {
+		return m.CreateMetafieldFunc(a0, a1)
+	}
+	var r0 *shopify.Metafield
+	var r1 error
+	return r0, r1
+}
+
+func (m *CustomerService) UpdateMetafield(a0 int64, a1 shopify.Metafield) (*shopify.Metafield, error) {
+	m.record("UpdateMetafield", a0, a1)
+	if m.UpdateMetafieldFunc != nil {
+		return m.UpdateMetafieldFunc(a0, a1)
+	}
+	var r0 *shopify.Metafield
+	var r1 error
+	return r0, r1
+}
+
+func (m *CustomerService) DeleteMetafield(a0 int64, a1 int64) error {
+	m.record("DeleteMetafield", a0, a1)
+	if m.DeleteMetafieldFunc != nil {
+		return m.DeleteMetafieldFunc(a0, a1)
+	}
+	var r0 error
+	return r0
+}
+
+// DiscountCodeService is a fake of synergyshopify.DiscountCodeService.
+type DiscountCodeService struct {
+	CallRecorder
+
+	CreateFunc      func(int64, shopify.PriceRuleDiscountCode) (*shopify.PriceRuleDiscountCode, error)
+	UpdateFunc      func(int64, shopify.PriceRuleDiscountCode) (*shopify.PriceRuleDiscountCode, error)
+	ListFunc        func(int64) ([]shopify.PriceRuleDiscountCode, error)
+	GetFunc         func(int64, int64) (*shopify.PriceRuleDiscountCode, error)
+	DeleteFunc      func(int64, int64) error
+	LookupFunc      func(string) (*shopify.PriceRuleDiscountCode, error)
+	CreateBatchFunc func(int64, []shopify.PriceRuleDiscountCode) (*shopify.DiscountCodeCreation, error)
+	GetBatchFunc    func(int64, int64) (*shopify.DiscountCodeCreation, error)
+	WaitBatchFunc   func(int64, int64) (*shopify.DiscountCodeCreation, error)
+}
+
+var _ shopify.DiscountCodeService = (*DiscountCodeService)(nil)
+
+func (m *DiscountCodeService) Create(a0 int64, a1 shopify.PriceRuleDiscountCode) (*shopify.PriceRuleDiscountCode, error) {
+	m.record("Create", a0, a1)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(a0, a1)
+	}
+	var r0 *shopify.PriceRuleDiscountCode
+	var r1 error
+	return r0, r1
+}
+
+func (m *DiscountCodeService) Update(a0 int64, a1 shopify.PriceRuleDiscountCode) (*shopify.PriceRuleDiscountCode, error) {
+	m.record("Update", a0, a1)
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(a0, a1)
+	}
+	var r0 *shopify.PriceRuleDiscountCode
+	var r1 error
+	return r0, r1
+}
+
+func (m *DiscountCodeService) List(a0 int64) ([]shopify.PriceRuleDiscountCode, error) {
+	m.record("List", a0)
+	if m.ListFunc != nil {
+		return m.ListFunc(a0)
+	}
+	var r0 []shopify.PriceRuleDiscountCode
+	var r1 error
+	return r0, r1
+}
+
+func (m *DiscountCodeService) Get(a0 int64, a1 int64) (*shopify.PriceRuleDiscountCode, error) {
+	m.record("Get", a0, a1)
+	if m.GetFunc != nil {
+		return m.GetFunc(a0, a1)
+	}
+	var r0 *shopify.PriceRuleDiscountCode
+	var r1 error
+	return r0, r1
+}
+
+func (m *DiscountCodeService) Delete(a0 int64, a1 int64) error {
+	m.record("Delete", a0, a1)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(a0, a1)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *DiscountCodeService) Lookup(a0 string) (*shopify.PriceRuleDiscountCode, error) {
+	m.record("Lookup", a0)
+	if m.LookupFunc != nil {
+		return m.LookupFunc(a0)
+	}
+	var r0 *shopify.PriceRuleDiscountCode
+	var r1 error
+	return r0, r1
+}
+
+func (m *DiscountCodeService) CreateBatch(a0 int64, a1 []shopify.PriceRuleDiscountCode) (*shopify.DiscountCodeCreation, error) {
+	m.record("CreateBatch", a0, a1)
+	if m.CreateBatchFunc != nil {
+		return m.CreateBatchFunc(a0, a1)
+	}
+	var r0 *shopify.DiscountCodeCreation
+	var r1 error
+	return r0, r1
+}
+
+func (m *DiscountCodeService) GetBatch(a0 int64, a1 int64) (*shopify.DiscountCodeCreation, error) {
+	m.record("GetBatch", a0, a1)
+	if m.GetBatchFunc != nil {
+		return m.GetBatchFunc(a0, a1)
+	}
+	var r0 *shopify.DiscountCodeCreation
+	var r1 error
+	return r0, r1
+}
+
+func (m *DiscountCodeService) WaitBatch(a0 int64, a1 int64) (*shopify.DiscountCodeCreation, error) {
+	m.record("WaitBatch", a0, a1)
+	if m.WaitBatchFunc != nil {
+		return m.WaitBatchFunc(a0, a1)
+	}
+	var r0 *shopify.DiscountCodeCreation
+	var r1 error
+	return r0, r1
+}
+
+// DraftOrderService is a fake of synergyshopify.DraftOrderService.
+type DraftOrderService struct {
+	CallRecorder
+
+	ListFunc               func(interface{}) ([]shopify.DraftOrder, error)
+	ListWithPaginationFunc func(interface{}) ([]shopify.DraftOrder, *shopify.Pagination, error)
+	CountFunc              func(interface{}) (int, error)
+	GetFunc                func(int64, interface{}) (*shopify.DraftOrder, error)
+	CreateFunc             func(shopify.DraftOrder) (*shopify.DraftOrder, error)
+	UpdateFunc             func(shopify.DraftOrder) (*shopify.DraftOrder, error)
+	DeleteFunc             func(int64) error
+	InvoiceFunc            func(int64, shopify.DraftOrderInvoice) (*shopify.DraftOrderInvoice, error)
+	CompleteFunc           func(int64, bool) (*shopify.DraftOrder, error)
+	ListMetafieldsFunc     func(int64, interface{}) ([]shopify.Metafield, error)
+	CountMetafieldsFunc    func(int64, interface{}) (int, error)
+	GetMetafieldFunc       func(int64, int64, interface{}) (*shopify.Metafield, error)
+	CreateMetafieldFunc    func(int64, shopify.Metafield) (*shopify.Metafield, error)
+	UpdateMetafieldFunc    func(int64, shopify.Metafield) (*shopify.Metafield, error)
+	DeleteMetafieldFunc    func(int64, int64) error
+}
+
+var _ shopify.DraftOrderService = (*DraftOrderService)(nil)
+
+func (m *DraftOrderService) List(a0 interface{}) ([]shopify.DraftOrder, error) {
+	m.record("List", a0)
+	if m.ListFunc != nil {
+		return m.ListFunc(a0)
+	}
+	var r0 []shopify.DraftOrder
+	var r1 error
+	return r0, r1
+}
+
+func (m *DraftOrderService) ListWithPagination(a0 interface{}) ([]shopify.DraftOrder, *shopify.Pagination, error) {
+	m.record("ListWithPagination", a0)
+	if m.ListWithPaginationFunc != nil {
+		return m.ListWithPaginationFunc(a0)
+	}
+	var r0 []shopify.DraftOrder
+	var r1 *shopify.Pagination
+	var r2 error
+	return r0, r1, r2
+}
+
+func (m *DraftOrderService) Count(a0 interface{}) (int, error) {
+	m.record("Count", a0)
+	if m.CountFunc != nil {
+		return m.CountFunc(a0)
+	}
+	var r0 int
+	var r1 error
+	return r0, r1
+}
+
+func (m *DraftOrderService) Get(a0 int64, a1 interface{}) (*shopify.DraftOrder, error) {
+	m.record("Get", a0, a1)
+	if m.GetFunc != nil {
+		return m.GetFunc(a0, a1)
+	}
+	var r0 *shopify.DraftOrder
+	var r1 error
+	return r0, r1
+}
+
+func (m *DraftOrderService) Create(a0 shopify.DraftOrder) (*shopify.DraftOrder, error) {
+	m.record("Create", a0)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(a0)
+	}
+	var r0 *shopify.DraftOrder
+	var r1 error
+	return r0, r1
+}
+
+func (m *DraftOrderService) Update(a0 shopify.DraftOrder) (*shopify.DraftOrder, error) {
+	m.record("Update", a0)
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(a0)
+	}
+	var r0 *shopify.DraftOrder
+	var r1 error
+	return r0, r1
+}
+
+func (m *DraftOrderService) Delete(a0 int64) error {
+	m.record("Delete", a0)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(a0)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *DraftOrderService) Invoice(a0 int64, a1 shopify.DraftOrderInvoice) (*shopify.DraftOrderInvoice, error) {
+	m.record("Invoice", a0, a1)
+	if m.InvoiceFunc != nil {
+		return m.InvoiceFunc(a0, a1)
+	}
+	var r0 *shopify.DraftOrderInvoice
+	var r1 error
+	return r0, r1
+}
+
+func (m *DraftOrderService) Complete(a0 int64, a1 bool) (*shopify.DraftOrder, error) {
+	m.record("Complete", a0, a1)
+	if m.CompleteFunc != nil {
+		return m.CompleteFunc(a0, a1)
+	}
+	var r0 *shopify.DraftOrder
+	var r1 error
+	return r0, r1
+}
+
+func (m *DraftOrderService) ListMetafields(a0 int64, a1 interface{}) ([]shopify.Metafield, error) {
+	m.record("ListMetafields", a0, a1)
+	if m.ListMetafieldsFunc != nil {
+		return m.ListMetafieldsFunc(a0, a1)
+	}
+	var r0 []shopify.Metafield
+	var r1 error
+	return r0, r1
+}
+
+func (m *DraftOrderService) CountMetafields(a0 int64, a1 interface{}) (int, error) {
+	m.record("CountMetafields", a0, a1)
+	if m.CountMetafieldsFunc != nil {
+		return m.CountMetafieldsFunc(a0, a1)
+	}
+	var r0 int
+	var r1 error
+	return r0, r1
+}
+
+func (m *DraftOrderService) GetMetafield(a0 int64, a1 int64, a2 interface{}) (*shopify.Metafield, error) {
+	m.record("GetMetafield", a0, a1, a2)
+	if m.GetMetafieldFunc != nil {
+		return m.GetMetafieldFunc(a0, a1, a2)
+	}
+	var r0 *shopify.Metafield
+	var r1 error
+	return r0, r1
+}
+
+func (m *DraftOrderService) CreateMetafield(a0 int64, a1 shopify.Metafield) (*shopify.Metafield, error) {
+	m.record("CreateMetafield", a0, a1)
+	if m.CreateMetafieldFunc != nil {
+		return m.CreateMetafieldFunc(a0, a1)
+	}
+	var r0 *shopify.Metafield
+	var r1 error
+	return r0, r1
+}
+
+func (m *DraftOrderService) UpdateMetafield(a0 int64, a1 shopify.Metafield) (*shopify.Metafield, error) {
+	m.record("UpdateMetafield", a0, a1)
+	if m.UpdateMetafieldFunc != nil {
+		return m.UpdateMetafieldFunc(a0, a1)
+	}
+	var r0 *shopify.Metafield
+	var r1 error
+	return r0, r1
+}
+
+func (m *DraftOrderService) DeleteMetafield(a0 int64, a1 int64) error {
+	m.record("DeleteMetafield", a0, a1)
+	if m.DeleteMetafieldFunc != nil {
+		return m.DeleteMetafieldFunc(a0, a1)
+	}
+	var r0 error
+	return r0
+}
+
+// FulfillmentOrderService is a fake of synergyshopify.FulfillmentOrderService.
+type FulfillmentOrderService struct {
+	CallRecorder
+
+	GetFunc    func(interface{}) (*shopify.FulfillmentOrder, error)
+	CreateFunc func(shopify.FulfillmentRequest) (*shopify.FulfillmentOrder, error)
+}
+
+var _ shopify.FulfillmentOrderService = (*FulfillmentOrderService)(nil)
+
+func (m *FulfillmentOrderService) Get(a0 interface{}) (*shopify.FulfillmentOrder, error) {
+	m.record("Get", a0)
+	if m.GetFunc != nil {
+		return m.GetFunc(a0)
+	}
+	var r0 *shopify.FulfillmentOrder
+	var r1 error
+	return r0, r1
+}
+
+func (m *FulfillmentOrderService) Create(a0 shopify.FulfillmentRequest) (*shopify.FulfillmentOrder, error) {
+	m.record("Create", a0)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(a0)
+	}
+	var r0 *shopify.FulfillmentOrder
+	var r1 error
+	return r0, r1
+}
+
+// FulfillmentService is a fake of synergyshopify.FulfillmentService.
+type FulfillmentService struct {
+	CallRecorder
+
+	ListFunc       func(interface{}) ([]shopify.Fulfillment, error)
+	CountFunc      func(interface{}) (int, error)
+	GetFunc        func(int64, interface{}) (*shopify.Fulfillment, error)
+	CreateFunc     func(shopify.Fulfillment) (*shopify.Fulfillment, error)
+	UpdateFunc     func(shopify.Fulfillment) (*shopify.Fulfillment, error)
+	CompleteFunc   func(int64) (*shopify.Fulfillment, error)
+	TransitionFunc func(int64) (*shopify.Fulfillment, error)
+	CancelFunc     func(int64) (*shopify.Fulfillment, error)
+}
+
+var _ shopify.FulfillmentService = (*FulfillmentService)(nil)
+
+func (m *FulfillmentService) List(a0 interface{}) ([]shopify.Fulfillment, error) {
+	m.record("List", a0)
+	if m.ListFunc != nil {
+		return m.ListFunc(a0)
+	}
+	var r0 []shopify.Fulfillment
+	var r1 error
+	return r0, r1
+}
+
+func (m *FulfillmentService) Count(a0 interface{}) (int, error) {
+	m.record("Count", a0)
+	if m.CountFunc != nil {
+		return m.CountFunc(a0)
+	}
+	var r0 int
+	var r1 error
+	return r0, r1
+}
+
+func (m *FulfillmentService) Get(a0 int64, a1 interface{}) (*shopify.Fulfillment, error) {
+	m.record("Get", a0, a1)
+	if m.GetFunc != nil {
+		return m.GetFunc(a0, a1)
+	}
+	var r0 *shopify.Fulfillment
+	var r1 error
+	return r0, r1
+}
+
+func (m *FulfillmentService) Create(a0 shopify.Fulfillment) (*shopify.Fulfillment, error) {
+	m.record("Create", a0)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(a0)
+	}
+	var r0 *shopify.Fulfillment
+	var r1 error
+	return r0, r1
+}
+
+func (m *FulfillmentService) Update(a0 shopify.Fulfillment) (*shopify.Fulfillment, error) {
+	m.record("Update", a0)
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(a0)
+	}
+	var r0 *shopify.Fulfillment
+	var r1 error
+	return r0, r1
+}
+
+func (m *FulfillmentService) Complete(a0 int64) (*shopify.Fulfillment, error) {
+	m.record("Complete", a0)
+	if m.CompleteFunc != nil {
+		return m.CompleteFunc(a0)
+	}
+	var r0 *shopify.Fulfillment
+	var r1 error
+	return r0, r1
+}
+
+func (m *FulfillmentService) Transition(a0 int64) (*shopify.Fulfillment, error) {
+	m.record("Transition", a0)
+	if m.TransitionFunc != nil {
+		return m.TransitionFunc(a0)
+	}
+	var r0 *shopify.Fulfillment
+	var r1 error
+	return r0, r1
+}
+
+func (m *FulfillmentService) Cancel(a0 int64) (*shopify.Fulfillment, error) {
+	m.record("Cancel", a0)
+	if m.CancelFunc != nil {
+		return m.CancelFunc(a0)
+	}
+	var r0 *shopify.Fulfillment
+	var r1 error
+	return r0, r1
+}
+
+// FulfillmentServiceService is a fake of synergyshopify.FulfillmentServiceService.
+type FulfillmentServiceService struct {
+	CallRecorder
+
+	ListFunc   func(interface{}) ([]shopify.FulfillmentServiceData, error)
+	GetFunc    func(int64, interface{}) (*shopify.FulfillmentServiceData, error)
+	CreateFunc func(shopify.FulfillmentServiceData) (*shopify.FulfillmentServiceData, error)
+	UpdateFunc func(shopify.FulfillmentServiceData) (*shopify.FulfillmentServiceData, error)
+	DeleteFunc func(int64) error
+}
+
+var _ shopify.FulfillmentServiceService = (*FulfillmentServiceService)(nil)
+
+func (m *FulfillmentServiceService) List(a0 interface{}) ([]shopify.FulfillmentServiceData, error) {
+	m.record("List", a0)
+	if m.ListFunc != nil {
+		return m.ListFunc(a0)
+	}
+	var r0 []shopify.FulfillmentServiceData
+	var r1 error
+	return r0, r1
+}
+
+func (m *FulfillmentServiceService) Get(a0 int64, a1 interface{}) (*shopify.FulfillmentServiceData, error) {
+	m.record("Get", a0, a1)
+	if m.GetFunc != nil {
+		return m.GetFunc(a0, a1)
+	}
+	var r0 *shopify.FulfillmentServiceData
+	var r1 error
+	return r0, r1
+}
+
+func (m *FulfillmentServiceService) Create(a0 shopify.FulfillmentServiceData) (*shopify.FulfillmentServiceData, error) {
+	m.record("Create", a0)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(a0)
+	}
+	var r0 *shopify.FulfillmentServiceData
+	var r1 error
+	return r0, r1
+}
+
+func (m *FulfillmentServiceService) Update(a0 shopify.FulfillmentServiceData) (*shopify.FulfillmentServiceData, error) {
+	m.record("Update", a0)
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(a0)
+	}
+	var r0 *shopify.FulfillmentServiceData
+	var r1 error
+	return r0, r1
+}
+
+func (m *FulfillmentServiceService) Delete(a0 int64) error {
+	m.record("Delete", a0)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(a0)
+	}
+	var r0 error
+	return r0
+}
+
+// FulfillmentsService is a fake of synergyshopify.FulfillmentsService.
+type FulfillmentsService struct {
+	CallRecorder
+
+	ListFulfillmentsFunc          func(int64, interface{}) ([]shopify.Fulfillment, error)
+	CountFulfillmentsFunc         func(int64, interface{}) (int, error)
+	GetFulfillmentFunc            func(int64, int64, interface{}) (*shopify.Fulfillment, error)
+	CreateFulfillmentFunc         func(int64, shopify.Fulfillment) (*shopify.Fulfillment, error)
+	UpdateFulfillmentFunc         func(int64, shopify.Fulfillment) (*shopify.Fulfillment, error)
+	CompleteFulfillmentFunc       func(int64, int64) (*shopify.Fulfillment, error)
+	TransitionFulfillmentFunc     func(int64, int64) (*shopify.Fulfillment, error)
+	CancelFulfillmentFunc         func(int64, int64) (*shopify.Fulfillment, error)
+	GetFulfillmentOrderFunc       func(int64, interface{}) ([]shopify.FulfillmentOrder, error)
+	CreateFulfillmentOrderFunc    func(shopify.FulfillmentRequest) (shopify.FulfillmentOrder, error)
+	UpdateFulfillmentTrackingFunc func(int64, shopify.FulfillmentRequest) (shopify.FulfillmentOrder, error)
+}
+
+var _ shopify.FulfillmentsService = (*FulfillmentsService)(nil)
+
+func (m *FulfillmentsService) ListFulfillments(a0 int64, a1 interface{}) ([]shopify.Fulfillment, error) {
+	m.record("ListFulfillments", a0, a1)
+	if m.ListFulfillmentsFunc != nil {
+		return m.ListFulfillmentsFunc(a0, a1)
+	}
+	var r0 []shopify.Fulfillment
+	var r1 error
+	return r0, r1
+}
+
+func (m *FulfillmentsService) CountFulfillments(a0 int64, a1 interface{}) (int, error) {
+	m.record("CountFulfillments", a0, a1)
+	if m.CountFulfillmentsFunc != nil {
+		return m.CountFulfillmentsFunc(a0, a1)
+	}
+	var r0 int
+	var r1 error
+	return r0, r1
+}
+
+func (m *FulfillmentsService) GetFulfillment(a0 int64, a1 int64, a2 interface{}) (*shopify.Fulfillment, error) {
+	m.record("GetFulfillment", a0, a1, a2)
+	if m.GetFulfillmentFunc != nil {
+		return m.GetFulfillmentFunc(a0, a1, a2)
+	}
+	var r0 *shopify.Fulfillment
+	var r1 error
+	return r0, r1
+}
+
+func (m *FulfillmentsService) CreateFulfillment(a0 int64, a1 shopify.Fulfillment) (*shopify.Fulfillment, error) {
+	m.record("CreateFulfillment", a0, a1)
+	if m.CreateFulfillmentFunc != nil {
+		return m.CreateFulfillmentFunc(a0, a1)
+	}
+	var r0 *shopify.Fulfillment
+	var r1 error
+	return r0, r1
+}
+
+func (m *FulfillmentsService) UpdateFulfillment(a0 int64, a1 shopify.Fulfillment) (*shopify.Fulfillment, error) {
+	m.record("UpdateFulfillment", a0, a1)
+	if m.UpdateFulfillmentFunc != nil {
+		return m.UpdateFulfillmentFunc(a0, a1)
+	}
+	var r0 *shopify.Fulfillment
+	var r1 error
+	return r0, r1
+}
+
+func (m *FulfillmentsService) CompleteFulfillment(a0 int64, a1 int64) (*shopify.Fulfillment, error) {
+	m.record("CompleteFulfillment", a0, a1)
+	if m.CompleteFulfillmentFunc != nil {
+		return m.CompleteFulfillmentFunc(a0, a1)
+	}
+	var r0 *shopify.Fulfillment
+	var r1 error
+	return r0, r1
+}
+
+func (m *FulfillmentsService) TransitionFulfillment(a0 int64, a1 int64) (*shopify.Fulfillment, error) {
+	m.record("TransitionFulfillment", a0, a1)
+	if m.TransitionFulfillmentFunc != nil {
+		return m.TransitionFulfillmentFunc(a0, a1)
+	}
+	var r0 *shopify.Fulfillment
+	var r1 error
+	return r0, r1
+}
+
+func (m *FulfillmentsService) CancelFulfillment(a0 int64, a1 int64) (*shopify.Fulfillment, error) {
+	m.record("CancelFulfillment", a0, a1)
+	if m.CancelFulfillmentFunc != nil {
+		return m.CancelFulfillmentFunc(a0, a1)
+	}
+	var r0 *shopify.Fulfillment
+	var r1 error
+	return r0, r1
+}
+
+func (m *FulfillmentsService) GetFulfillmentOrder(a0 int64, a1 interface{}) ([]shopify.FulfillmentOrder, error) {
+	m.record("GetFulfillmentOrder", a0, a1)
+	if m.GetFulfillmentOrderFunc != nil {
+		return m.GetFulfillmentOrderFunc(a0, a1)
+	}
+	var r0 []shopify.FulfillmentOrder
+	var r1 error
+	return r0, r1
+}
+
+func (m *FulfillmentsService) CreateFulfillmentOrder(a0 shopify.FulfillmentRequest) (shopify.FulfillmentOrder, error) {
+	m.record("CreateFulfillmentOrder", a0)
+	if m.CreateFulfillmentOrderFunc != nil {
+		return m.CreateFulfillmentOrderFunc(a0)
+	}
+	var r0 shopify.FulfillmentOrder
+	var r1 error
+	return r0, r1
+}
+
+func (m *FulfillmentsService) UpdateFulfillmentTracking(a0 int64, a1 shopify.FulfillmentRequest) (shopify.FulfillmentOrder, error) {
+	m.record("UpdateFulfillmentTracking", a0, a1)
+	if m.UpdateFulfillmentTrackingFunc != nil {
+		return m.UpdateFulfillmentTrackingFunc(a0, a1)
+	}
+	var r0 shopify.FulfillmentOrder
+	var r1 error
+	return r0, r1
+}
+
+// GiftCardService is a fake of synergyshopify.GiftCardService.
+type GiftCardService struct {
+	CallRecorder
+
+	GetFunc                func(int64) (*shopify.GiftCard, error)
+	CreateFunc             func(shopify.GiftCard) (*shopify.GiftCard, error)
+	UpdateFunc             func(shopify.GiftCard) (*shopify.GiftCard, error)
+	ListFunc               func() ([]shopify.GiftCard, error)
+	ListWithPaginationFunc func(interface{}) ([]shopify.GiftCard, *shopify.Pagination, error)
+	DisableFunc            func(int64) (*shopify.GiftCard, error)
+	CountFunc              func(interface{}) (int, error)
+}
+
+var _ shopify.GiftCardService = (*GiftCardService)(nil)
+
+func (m *GiftCardService) Get(a0 int64) (*shopify.GiftCard, error) {
+	m.record("Get", a0)
+	if m.GetFunc != nil {
+		return m.GetFunc(a0)
+	}
+	var r0 *shopify.GiftCard
+	var r1 error
+	return r0, r1
+}
+
+func (m *GiftCardService) Create(a0 shopify.GiftCard) (*shopify.GiftCard, error) {
+	m.record("Create", a0)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(a0)
+	}
+	var r0 *shopify.GiftCard
+	var r1 error
+	return r0, r1
+}
+
+func (m *GiftCardService) Update(a0 shopify.GiftCard) (*shopify.GiftCard, error) {
+	m.record("Update", a0)
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(a0)
+	}
+	var r0 *shopify.GiftCard
+	var r1 error
+	return r0, r1
+}
+
+func (m *GiftCardService) List() ([]shopify.GiftCard, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc()
+	}
+	var r0 []shopify.GiftCard
+	var r1 error
+	return r0, r1
+}
+
+func (m *GiftCardService) ListWithPagination(a0 interface{}) ([]shopify.GiftCard, *shopify.Pagination, error) {
+	m.record("ListWithPagination", a0)
+	if m.ListWithPaginationFunc != nil {
+		return m.ListWithPaginationFunc(a0)
+	}
+	var r0 []shopify.GiftCard
+	var r1 *shopify.Pagination
+	var r2 error
+	return r0, r1, r2
+}
+
+func (m *GiftCardService) Disable(a0 int64) (*shopify.GiftCard, error) {
+	m.record("Disable", a0)
+	if m.DisableFunc != nil {
+		return m.DisableFunc(a0)
+	}
+	var r0 *shopify.GiftCard
+	var r1 error
+	return r0, r1
+}
+
+func (m *GiftCardService) Count(a0 interface{}) (int, error) {
+	m.record("Count", a0)
+	if m.CountFunc != nil {
+		return m.CountFunc(a0)
+	}
+	var r0 int
+	var r1 error
+	return r0, r1
+}
+
+// GraphQLService is a fake of synergyshopify.GraphQLService.
+type GraphQLService struct {
+	CallRecorder
+
+	QueryFunc        func(string, interface{}, interface{}) error
+	QueryContextFunc func(context.Context, string, interface{}, interface{}) error
+}
+
+var _ shopify.GraphQLService = (*GraphQLService)(nil)
+
+func (m *GraphQLService) Query(a0 string, a1 interface{}, a2 interface{}) error {
+	m.record("Query", a0, a1, a2)
+	if m.QueryFunc != nil {
+		return m.QueryFunc(a0, a1, a2)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *GraphQLService) QueryContext(a0 context.Context, a1 string, a2 interface{}, a3 interface{}) error {
+	m.record("QueryContext", a0, a1, a2, a3)
+	if m.QueryContextFunc != nil {
+		return m.QueryContextFunc(a0, a1, a2, a3)
+	}
+	var r0 error
+	return r0
+}
+
+// ImageService is a fake of synergyshopify.ImageService.
+type ImageService struct {
+	CallRecorder
+
+	ListFunc   func(int64, interface{}) ([]shopify.Image, error)
+	CountFunc  func(int64, interface{}) (int, error)
+	GetFunc    func(int64, int64, interface{}) (*shopify.Image, error)
+	CreateFunc func(int64, shopify.Image) (*shopify.Image, error)
+	UpdateFunc func(int64, shopify.Image) (*shopify.Image, error)
+	DeleteFunc func(int64, int64) error
+}
+
+var _ shopify.ImageService = (*ImageService)(nil)
+
+func (m *ImageService) List(a0 int64, a1 interface{}) ([]shopify.Image, error) {
+	m.record("List", a0, a1)
+	if m.ListFunc != nil {
+		return m.ListFunc(a0, a1)
+	}
+	var r0 []shopify.Image
+	var r1 error
+	return r0, r1
+}
+
+func (m *ImageService) Count(a0 int64, a1 interface{}) (int, error) {
+	m.record("Count", a0, a1)
+	if m.CountFunc != nil {
+		return m.CountFunc(a0, a1)
+	}
+	var r0 int
+	var r1 error
+	return r0, r1
+}
+
+func (m *ImageService) Get(a0 int64, a1 int64, a2 interface{}) (*shopify.Image, error) {
+	m.record("Get", a0, a1, a2)
+	if m.GetFunc != nil {
+		return m.GetFunc(a0, a1, a2)
+	}
+	var r0 *shopify.Image
+	var r1 error
+	return r0, r1
+}
+
+func (m *ImageService) Create(a0 int64, a1 shopify.Image) (*shopify.Image, error) {
+	m.record("Create", a0, a1)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(a0, a1)
+	}
+	var r0 *shopify.Image
+	var r1 error
+	return r0, r1
+}
+
+func (m *ImageService) Update(a0 int64, a1 shopify.Image) (*shopify.Image, error) {
+	m.record("Update", a0, a1)
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(a0, a1)
+	}
+	var r0 *shopify.Image
+	var r1 error
+	return r0, r1
+}
+
+func (m *ImageService) Delete(a0 int64, a1 int64) error {
+	m.record("Delete", a0, a1)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(a0, a1)
+	}
+	var r0 error
+	return r0
+}
+
+// InventoryItemService is a fake of synergyshopify.InventoryItemService.
+type InventoryItemService struct {
+	CallRecorder
+
+	ListFunc   func(interface{}) ([]shopify.InventoryItem, error)
+	GetFunc    func(int64, interface{}) (*shopify.InventoryItem, error)
+	UpdateFunc func(shopify.InventoryItem) (*shopify.InventoryItem, error)
+}
+
+var _ shopify.InventoryItemService = (*InventoryItemService)(nil)
+
+func (m *InventoryItemService) List(a0 interface{}) ([]shopify.InventoryItem, error) {
+	m.record("List", a0)
+	if m.ListFunc != nil {
+		return m.ListFunc(a0)
+	}
+	var r0 []shopify.InventoryItem
+	var r1 error
+	return r0, r1
+}
+
+func (m *InventoryItemService) Get(a0 int64, a1 interface{}) (*shopify.InventoryItem, error) {
+	m.record("Get", a0, a1)
+	if m.GetFunc != nil {
+		return m.GetFunc(a0, a1)
+	}
+	var r0 *shopify.InventoryItem
+	var r1 error
+	return r0, r1
+}
+
+func (m *InventoryItemService) Update(a0 shopify.InventoryItem) (*shopify.InventoryItem, error) {
+	m.record("Update", a0)
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(a0)
+	}
+	var r0 *shopify.InventoryItem
+	var r1 error
+	return r0, r1
+}
+
+// InventoryLevelService is a fake of synergyshopify.InventoryLevelService.
+type InventoryLevelService struct {
+	CallRecorder
+
+	ListFunc               func(interface{}) ([]shopify.InventoryLevel, error)
+	ListWithPaginationFunc func(interface{}) ([]shopify.InventoryLevel, *shopify.Pagination, error)
+	AdjustFunc             func(interface{}) (*shopify.InventoryLevel, error)
+	DeleteFunc             func(int64, int64) error
+	ConnectFunc            func(shopify.InventoryLevel) (*shopify.InventoryLevel, error)
+	SetFunc                func(shopify.InventoryLevel) (*shopify.InventoryLevel, error)
+}
+
+var _ shopify.InventoryLevelService = (*InventoryLevelService)(nil)
+
+func (m *InventoryLevelService) List(a0 interface{}) ([]shopify.InventoryLevel, error) {
+	m.record("List", a0)
+	if m.ListFunc != nil {
+		return m.ListFunc(a0)
+	}
+	var r0 []shopify.InventoryLevel
+	var r1 error
+	return r0, r1
+}
+
+func (m *InventoryLevelService) ListWithPagination(a0 interface{}) ([]shopify.InventoryLevel, *shopify.Pagination, error) {
+	m.record("ListWithPagination", a0)
+	if m.ListWithPaginationFunc != nil {
+		return m.ListWithPaginationFunc(a0)
+	}
+	var r0 []shopify.InventoryLevel
+	var r1 *shopify.Pagination
+	var r2 error
+	return r0, r1, r2
+}
+
+func (m *InventoryLevelService) Adjust(a0 interface{}) (*shopify.InventoryLevel, error) {
+	m.record("Adjust", a0)
+	if m.AdjustFunc != nil {
+		return m.AdjustFunc(a0)
+	}
+	var r0 *shopify.InventoryLevel
+	var r1 error
+	return r0, r1
+}
+
+func (m *InventoryLevelService) Delete(a0 int64, a1 int64) error {
+	m.record("Delete", a0, a1)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(a0, a1)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *InventoryLevelService) Connect(a0 shopify.InventoryLevel) (*shopify.InventoryLevel, error) {
+	m.record("Connect", a0)
+	if m.ConnectFunc != nil {
+		return m.ConnectFunc(a0)
+	}
+	var r0 *shopify.InventoryLevel
+	var r1 error
+	return r0, r1
+}
+
+func (m *InventoryLevelService) Set(a0 shopify.InventoryLevel) (*shopify.InventoryLevel, error) {
+	m.record("Set", a0)
+	if m.SetFunc != nil {
+		return m.SetFunc(a0)
+	}
+	var r0 *shopify.InventoryLevel
+	var r1 error
+	return r0, r1
+}
+
+// LocationService is a fake of synergyshopify.LocationService.
+type LocationService struct {
+	CallRecorder
+
+	ListFunc  func(interface{}) ([]shopify.Location, error)
+	GetFunc   func(int64, interface{}) (*shopify.Location, error)
+	CountFunc func(interface{}) (int, error)
+}
+
+var _ shopify.LocationService = (*LocationService)(nil)
+
+func (m *LocationService) List(a0 interface{}) ([]shopify.Location, error) {
+	m.record("List", a0)
+	if m.ListFunc != nil {
+		return m.ListFunc(a0)
+	}
+	var r0 []shopify.Location
+	var r1 error
+	return r0, r1
+}
+
+func (m *LocationService) Get(a0 int64, a1 interface{}) (*shopify.Location, error) {
+	m.record("Get", a0, a1)
+	if m.GetFunc != nil {
+		return m.GetFunc(a0, a1)
+	}
+	var r0 *shopify.Location
+	var r1 error
+	return r0, r1
+}
+
+func (m *LocationService) Count(a0 interface{}) (int, error) {
+	m.record("Count", a0)
+	if m.CountFunc != nil {
+		return m.CountFunc(a0)
+	}
+	var r0 int
+	var r1 error
+	return r0, r1
+}
+
+// MetafieldService is a fake of synergyshopify.MetafieldService.
+type MetafieldService struct {
+	CallRecorder
+
+	ListFunc               func(interface{}) ([]shopify.Metafield, error)
+	ListWithPaginationFunc func(interface{}) ([]shopify.Metafield, *shopify.Pagination, error)
+	CountFunc              func(interface{}) (int, error)
+	GetFunc                func(int64, interface{}) (*shopify.Metafield, error)
+	CreateFunc             func(shopify.Metafield) (*shopify.Metafield, error)
+	UpdateFunc             func(shopify.Metafield) (*shopify.Metafield, error)
+	DeleteFunc             func(int64) error
+}
+
+var _ shopify.MetafieldService = (*MetafieldService)(nil)
+
+func (m *MetafieldService) List(a0 interface{}) ([]shopify.Metafield, error) {
+	m.record("List", a0)
+	if m.ListFunc != nil {
+		return m.ListFunc(a0)
+	}
+	var r0 []shopify.Metafield
+	var r1 error
+	return r0, r1
+}
+
+func (m *MetafieldService) ListWithPagination(a0 interface{}) ([]shopify.Metafield, *shopify.Pagination, error) {
+	m.record("ListWithPagination", a0)
+	if m.ListWithPaginationFunc != nil {
+		return m.ListWithPaginationFunc(a0)
+	}
+	var r0 []shopify.Metafield
+	var r1 *shopify.Pagination
+	var r2 error
+	return r0, r1, r2
+}
+
+func (m *MetafieldService) Count(a0 interface{}) (int, error) {
+	m.record("Count", a0)
+	if m.CountFunc != nil {
+		return m.CountFunc(a0)
+	}
+	var r0 int
+	var r1 error
+	return r0, r1
+}
+
+func (m *MetafieldService) Get(a0 int64, a1 interface{}) (*shopify.Metafield, error) {
+	m.record("Get", a0, a1)
+	if m.GetFunc != nil {
+		return m.GetFunc(a0, a1)
+	}
+	var r0 *shopify.Metafield
+	var r1 error
+	return r0, r1
+}
+
+func (m *MetafieldService) Create(a0 shopify.Metafield) (*shopify.Metafield, error) {
+	m.record("Create", a0)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(a0)
+	}
+	var r0 *shopify.Metafield
+	var r1 error
+	return r0, r1
+}
+
+func (m *MetafieldService) Update(a0 shopify.Metafield) (*shopify.Metafield, error) {
+	m.record("Update", a0)
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(a0)
+	}
+	var r0 *shopify.Metafield
+	var r1 error
+	return r0, r1
+}
+
+func (m *MetafieldService) Delete(a0 int64) error {
+	m.record("Delete", a0)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(a0)
+	}
+	var r0 error
+	return r0
+}
+
+// MetafieldsService is a fake of synergyshopify.MetafieldsService.
+type MetafieldsService struct {
+	CallRecorder
+
+	ListMetafieldsFunc  func(int64, interface{}) ([]shopify.Metafield, error)
+	CountMetafieldsFunc func(int64, interface{}) (int, error)
+	GetMetafieldFunc    func(int64, int64, interface{}) (*shopify.Metafield, error)
+	CreateMetafieldFunc func(int64, shopify.Metafield) (*shopify.Metafield, error)
+	UpdateMetafieldFunc func(int64, shopify.Metafield) (*shopify.Metafield, error)
+	DeleteMetafieldFunc func(int64, int64) error
+}
+
+var _ shopify.MetafieldsService = (*MetafieldsService)(nil)
+
+func (m *MetafieldsService) ListMetafields(a0 int64, a1 interface{}) ([]shopify.Metafield, error) {
+	m.record("ListMetafields", a0, a1)
+	if m.ListMetafieldsFunc != nil {
+		return m.ListMetafieldsFunc(a0, a1)
+	}
+	var r0 []shopify.Metafield
+	var r1 error
+	return r0, r1
+}
+
+func (m *MetafieldsService) CountMetafields(a0 int64, a1 interface{}) (int, error) {
+	m.record("CountMetafields", a0, a1)
+	if m.CountMetafieldsFunc != nil {
+		return m.CountMetafieldsFunc(a0, a1)
+	}
+	var r0 int
+	var r1 error
+	return r0, r1
+}
+
+func (m *MetafieldsService) GetMetafield(a0 int64, a1 int64, a2 interface{}) (*shopify.Metafield, error) {
+	m.record("GetMetafield", a0, a1, a2)
+	if m.GetMetafieldFunc != nil {
+		return m.GetMetafieldFunc(a0, a1, a2)
+	}
+	var r0 *shopify.Metafield
+	var r1 error
+	return r0, r1
+}
+
+func (m *MetafieldsService) CreateMetafield(a0 int64, a1 shopify.Metafield) (*shopify.Metafield, error) {
+	m.record("CreateMetafield", a0, a1)
+	if m.CreateMetafieldFunc != nil {
+		return m.CreateMetafieldFunc(a0, a1)
+	}
+	var r0 *shopify.Metafield
+	var r1 error
+	return r0, r1
+}
+
+func (m *MetafieldsService) UpdateMetafield(a0 int64, a1 shopify.Metafield) (*shopify.Metafield, error) {
+	m.record("UpdateMetafield", a0, a1)
+	if m.UpdateMetafieldFunc != nil {
+		return m.UpdateMetafieldFunc(a0, a1)
+	}
+	var r0 *shopify.Metafield
+	var r1 error
+	return r0, r1
+}
+
+func (m *MetafieldsService) DeleteMetafield(a0 int64, a1 int64) error {
+	m.record("DeleteMetafield", a0, a1)
+	if m.DeleteMetafieldFunc != nil {
+		return m.DeleteMetafieldFunc(a0, a1)
+	}
+	var r0 error
+	return r0
+}
+
+// OrderRiskService is a fake of synergyshopify.OrderRiskService.
+type OrderRiskService struct {
+	CallRecorder
+
+	ListFunc   func(int64, interface{}) ([]shopify.OrderRisk, error)
+	GetFunc    func(int64, int64, interface{}) (*shopify.OrderRisk, error)
+	CreateFunc func(shopify.OrderRisk, int64) (*shopify.OrderRisk, error)
+	UpdateFunc func(int64, shopify.OrderRisk) (*shopify.OrderRisk, error)
+	DeleteFunc func(int64, int64) error
+}
+
+var _ shopify.OrderRiskService = (*OrderRiskService)(nil)
+
+func (m *OrderRiskService) List(a0 int64, a1 interface{}) ([]shopify.OrderRisk, error) {
+	m.record("List", a0, a1)
+	if m.ListFunc != nil {
+		return m.ListFunc(a0, a1)
+	}
+	var r0 []shopify.OrderRisk
+	var r1 error
+	return r0, r1
+}
+
+func (m *OrderRiskService) Get(a0 int64, a1 int64, a2 interface{}) (*shopify.OrderRisk, error) {
+	m.record("Get", a0, a1, a2)
+	if m.GetFunc != nil {
+		return m.GetFunc(a0, a1, a2)
+	}
+	var r0 *shopify.OrderRisk
+	var r1 error
+	return r0, r1
+}
+
+func (m *OrderRiskService) Create(a0 shopify.OrderRisk, a1 int64) (*shopify.OrderRisk, error) {
+	m.record("Create", a0, a1)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(a0, a1)
+	}
+	var r0 *shopify.OrderRisk
+	var r1 error
+	return r0, r1
+}
+
+func (m *OrderRiskService) Update(a0 int64, a1 shopify.OrderRisk) (*shopify.OrderRisk, error) {
+	m.record("Update", a0, a1)
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(a0, a1)
+	}
+	var r0 *shopify.OrderRisk
+	var r1 error
+	return r0, r1
+}
+
+func (m *OrderRiskService) Delete(a0 int64, a1 int64) error {
+	m.record("Delete", a0, a1)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(a0, a1)
+	}
+	var r0 error
+	return r0
+}
+
+// OrderService is a fake of synergyshopify.OrderService.
+type OrderService struct {
+	CallRecorder
+
+	ListFunc                      func(interface{}) ([]shopify.Order, error)
+	ListWithPaginationFunc        func(interface{}) ([]shopify.Order, *shopify.Pagination, error)
+	CountFunc                     func(interface{}) (int, error)
+	GetFunc                       func(int64, interface{}) (*shopify.Order, error)
+	CreateFunc                    func(shopify.Order) (*shopify.Order, error)
+	UpdateFunc                    func(shopify.Order) (*shopify.Order, error)
+	UpdateTagsFunc                func(int64, string) (*shopify.Order, error)
+	CancelFunc                    func(int64, interface{}) (*shopify.Order, error)
+	CloseFunc                     func(int64) (*shopify.Order, error)
+	OpenFunc                      func(int64) (*shopify.Order, error)
+	DeleteFunc                    func(int64) error
+	ListMetafieldsFunc            func(int64, interface{}) ([]shopify.Metafield, error)
+	CountMetafieldsFunc           func(int64, interface{}) (int, error)
+	GetMetafieldFunc              func(int64, int64, interface{}) (*shopify.Metafield, error)
+	CreateMetafieldFunc           func(int64, shopify.Metafield) (*shopify.Metafield, error)
+	UpdateMetafieldFunc           func(int64, shopify.Metafield) (*shopify.Metafield, error)
+	DeleteMetafieldFunc           func(int64, int64) error
+	ListFulfillmentsFunc          func(int64, interface{}) ([]shopify.Fulfillment, error)
+	CountFulfillmentsFunc         func(int64, interface{}) (int, error)
+	GetFulfillmentFunc            func(int64, int64, interface{}) (*shopify.Fulfillment, error)
+	CreateFulfillmentFunc         func(int64, shopify.Fulfillment) (*shopify.Fulfillment, error)
+	UpdateFulfillmentFunc         func(int64, shopify.Fulfillment) (*shopify.Fulfillment, error)
+	CompleteFulfillmentFunc       func(int64, int64) (*shopify.Fulfillment, error)
+	TransitionFulfillmentFunc     func(int64, int64) (*shopify.Fulfillment, error)
+	CancelFulfillmentFunc         func(int64, int64) (*shopify.Fulfillment, error)
+	GetFulfillmentOrderFunc       func(int64, interface{}) ([]shopify.FulfillmentOrder, error)
+	CreateFulfillmentOrderFunc    func(shopify.FulfillmentRequest) (shopify.FulfillmentOrder, error)
+	UpdateFulfillmentTrackingFunc func(int64, shopify.FulfillmentRequest) (shopify.FulfillmentOrder, error)
+}
+
+var _ shopify.OrderService = (*OrderService)(nil)
+
+func (m *OrderService) List(a0 interface{}) ([]shopify.Order, error) {
+	m.record("List", a0)
+	if m.ListFunc != nil {
+		return m.ListFunc(a0)
+	}
+	var r0 []shopify.Order
+	var r1 error
+	return r0, r1
+}
+
+func (m *OrderService) ListWithPagination(a0 interface{}) ([]shopify.Order, *shopify.Pagination, error) {
+	m.record("ListWithPagination", a0)
+	if m.ListWithPaginationFunc != nil {
+		return m.ListWithPaginationFunc(a0)
+	}
+	var r0 []shopify.Order
+	var r1 *shopify.Pagination
+	var r2 error
+	return r0, r1, r2
+}
+
+func (m *OrderService) Count(a0 interface{}) (int, error) {
+	m.record("Count", a0)
+	if m.CountFunc != nil {
+		return m.CountFunc(a0)
+	}
+	var r0 int
+	var r1 error
+	return r0, r1
+}
+
+func (m *OrderService) Get(a0 int64, a1 interface{}) (*shopify.Order, error) {
+	m.record("Get", a0, a1)
+	if m.GetFunc != nil {
+		return m.GetFunc(a0, a1)
+	}
+	var r0 *shopify.Order
+	var r1 error
+	return r0, r1
+}
+
+func (m *OrderService) Create(a0 shopify.Order) (*shopify.Order, error) {
+	m.record("Create", a0)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(a0)
+	}
+	var r0 *shopify.Order
+	var r1 error
+	return r0, r1
+}
+
+func (m *OrderService) Update(a0 shopify.Order) (*shopify.Order, error) {
+	m.record("Update", a0)
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(a0)
+	}
+	var r0 *shopify.Order
+	var r1 error
+	return r0, r1
+}
+
+func (m *OrderService) UpdateTags(a0 int64, a1 string) (*shopify.Order, error) {
+	m.record("UpdateTags", a0, a1)
+	if m.UpdateTagsFunc != nil {
+		return m.UpdateTagsFunc(a0, a1)
+	}
+	var r0 *shopify.Order
+	var r1 error
+	return r0, r1
+}
+
+func (m *OrderService) Cancel(a0 int64, a1 interface{}) (*shopify.Order, error) {
+	m.record("Cancel", a0, a1)
+	if m.CancelFunc != nil {
+		return m.CancelFunc(a0, a1)
+	}
+	var r0 *shopify.Order
+	var r1 error
+	return r0, r1
+}
+
+func (m *OrderService) Close(a0 int64) (*shopify.Order, error) {
+	m.record("Close", a0)
+	if m.CloseFunc != nil {
+		return m.CloseFunc(a0)
+	}
+	var r0 *shopify.Order
+	var r1 error
+	return r0, r1
+}
+
+func (m *OrderService) Open(a0 int64) (*shopify.Order, error) {
+	m.record("Open", a0)
+	if m.OpenFunc != nil {
+		return m.OpenFunc(a0)
+	}
+	var r0 *shopify.Order
+	var r1 error
+	return r0, r1
+}
+
+func (m *OrderService) Delete(a0 int64) error {
+	m.record("Delete", a0)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(a0)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *OrderService) ListMetafields(a0 int64, a1 interface{}) ([]shopify.Metafield, error) {
+	m.record("ListMetafields", a0, a1)
+	if m.ListMetafieldsFunc != nil {
+		return m.ListMetafieldsFunc(a0, a1)
+	}
+	var r0 []shopify.Metafield
+	var r1 error
+	return r0, r1
+}
+
+func (m *OrderService) CountMetafields(a0 int64, a1 interface{}) (int, error) {
+	m.record("CountMetafields", a0, a1)
+	if m.CountMetafieldsFunc != nil {
+		return m.CountMetafieldsFunc(a0, a1)
+	}
+	var r0 int
+	var r1 error
+	return r0, r1
+}
+
+func (m *OrderService) GetMetafield(a0 int64, a1 int64, a2 interface{}) (*shopify.Metafield, error) {
+	m.record("GetMetafield", a0, a1, a2)
+	if m.GetMetafieldFunc != nil {
+		return m.GetMetafieldFunc(a0, a1, a2)
+	}
+	var r0 *shopify.Metafield
+	var r1 error
+	return r0, r1
+}
+
+func (m *OrderService) CreateMetafield(a0 int64, a1 shopify.Metafield) (*shopify.Metafield, error) {
+	m.record("CreateMetafield", a0, a1)
+	if m.CreateMetafieldFunc != nil {
+		return m.CreateMetafieldFunc(a0, a1)
+	}
+	var r0 *shopify.Metafield
+	var r1 error
+	return r0, r1
+}
+
+func (m *OrderService) UpdateMetafield(a0 int64, a1 shopify.Metafield) (*shopify.Metafield, error) {
+	m.record("UpdateMetafield", a0, a1)
+	if m.UpdateMetafieldFunc != nil {
+		return m.UpdateMetafieldFunc(a0, a1)
+	}
+	var r0 *shopify.Metafield
+	var r1 error
+	return r0, r1
+}
+
+func (m *OrderService) DeleteMetafield(a0 int64, a1 int64) error {
+	m.record("DeleteMetafield", a0, a1)
+	if m.DeleteMetafieldFunc != nil {
+		return m.DeleteMetafieldFunc(a0, a1)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *OrderService) ListFulfillments(a0 int64, a1 interface{}) ([]shopify.Fulfillment, error) {
+	m.record("ListFulfillments", a0, a1)
+	if m.ListFulfillmentsFunc != nil {
+		return m.ListFulfillmentsFunc(a0, a1)
+	}
+	var r0 []shopify.Fulfillment
+	var r1 error
+	return r0, r1
+}
+
+func (m *OrderService) CountFulfillments(a0 int64, a1 interface{}) (int, error) {
+	m.record("CountFulfillments", a0, a1)
+	if m.CountFulfillmentsFunc != nil {
+		return m.CountFulfillmentsFunc(a0, a1)
+	}
+	var r0 int
+	var r1 error
+	return r0, r1
+}
+
+func (m *OrderService) GetFulfillment(a0 int64, a1 int64, a2 interface{}) (*shopify.Fulfillment, error) {
+	m.record("GetFulfillment", a0, a1, a2)
+	if m.GetFulfillmentFunc != nil {
+		return m.GetFulfillmentFunc(a0, a1, a2)
+	}
+	var r0 *shopify.Fulfillment
+	var r1 error
+	return r0, r1
+}
+
+func (m *OrderService) CreateFulfillment(a0 int64, a1 shopify.Fulfillment) (*shopify.Fulfillment, error) {
+	m.record("CreateFulfillment", a0, a1)
+	if m.CreateFulfillmentFunc != nil {
+		return m.CreateFulfillmentFunc(a0, a1)
+	}
+	var r0 *shopify.Fulfillment
+	var r1 error
+	return r0, r1
+}
+
+func (m *OrderService) UpdateFulfillment(a0 int64, a1 shopify.Fulfillment) (*shopify.Fulfillment, error) {
+	m.record("UpdateFulfillment", a0, a1)
+	if m.UpdateFulfillmentFunc != nil {
+		return m.UpdateFulfillmentFunc(a0, a1)
+	}
+	var r0 *shopify.Fulfillment
+	var r1 error
+	return r0, r1
+}
+
+func (m *OrderService) CompleteFulfillment(a0 int64, a1 int64) (*shopify.Fulfillment, error) {
+	m.record("CompleteFulfillment", a0, a1)
+	if m.CompleteFulfillmentFunc != nil {
+		return m.CompleteFulfillmentFunc(a0, a1)
+	}
+	var r0 *shopify.Fulfillment
+	var r1 error
+	return r0, r1
+}
+
+func (m *OrderService) TransitionFulfillment(a0 int64, a1 int64) (*shopify.Fulfillment, error) {
+	m.record("TransitionFulfillment", a0, a1)
+	if m.TransitionFulfillmentFunc != nil {
+		return m.TransitionFulfillmentFunc(a0, a1)
+	}
+	var r0 *shopify.Fulfillment
+	var r1 error
+	return r0, r1
+}
+
+func (m *OrderService) CancelFulfillment(a0 int64, a1 int64) (*shopify.Fulfillment, error) {
+	m.record("CancelFulfillment", a0, a1)
+	if m.CancelFulfillmentFunc != nil {
+		return m.CancelFulfillmentFunc(a0, a1)
+	}
+	var r0 *shopify.Fulfillment
+	var r1 error
+	return r0, r1
+}
+
+func (m *OrderService) GetFulfillmentOrder(a0 int64, a1 interface{}) ([]shopify.FulfillmentOrder, error) {
+	m.record("GetFulfillmentOrder", a0, a1)
+	if m.GetFulfillmentOrderFunc != nil {
+		return m.GetFulfillmentOrderFunc(a0, a1)
+	}
+	var r0 []shopify.FulfillmentOrder
+	var r1 error
+	return r0, r1
+}
+
+func (m *OrderService) CreateFulfillmentOrder(a0 shopify.FulfillmentRequest) (shopify.FulfillmentOrder, error) {
+	m.record("CreateFulfillmentOrder", a0)
+	if m.CreateFulfillmentOrderFunc != nil {
+		return m.CreateFulfillmentOrderFunc(a0)
+	}
+	var r0 shopify.FulfillmentOrder
+	var r1 error
+	return r0, r1
+}
+
+func (m *OrderService) UpdateFulfillmentTracking(a0 int64, a1 shopify.FulfillmentRequest) (shopify.FulfillmentOrder, error) {
+	m.record("UpdateFulfillmentTracking", a0, a1)
+	if m.UpdateFulfillmentTrackingFunc != nil {
+		return m.UpdateFulfillmentTrackingFunc(a0, a1)
+	}
+	var r0 shopify.FulfillmentOrder
+	var r1 error
+	return r0, r1
+}
+
+// PageService is a fake of synergyshopify.PageService.
+type PageService struct {
+	CallRecorder
+
+	ListFunc            func(interface{}) ([]shopify.Page, error)
+	CountFunc           func(interface{}) (int, error)
+	GetFunc             func(int64, interface{}) (*shopify.Page, error)
+	CreateFunc          func(shopify.Page) (*shopify.Page, error)
+	UpdateFunc          func(shopify.Page) (*shopify.Page, error)
+	DeleteFunc          func(int64) error
+	ListMetafieldsFunc  func(int64, interface{}) ([]shopify.Metafield, error)
+	CountMetafieldsFunc func(int64, interface{}) (int, error)
+	GetMetafieldFunc    func(int64, int64, interface{}) (*shopify.Metafield, error)
+	CreateMetafieldFunc func(int64, shopify.Metafield) (*shopify.Metafield, error)
+	UpdateMetafieldFunc func(int64, shopify.Metafield) (*shopify.Metafield, error)
+	DeleteMetafieldFunc func(int64, int64) error
+}
+
+var _ shopify.PageService = (*PageService)(nil)
+
+func (m *PageService) List(a0 interface{}) ([]shopify.Page, error) {
+	m.record("List", a0)
+	if m.ListFunc != nil {
+		return m.ListFunc(a0)
+	}
+	var r0 []shopify.Page
+	var r1 error
+	return r0, r1
+}
+
+func (m *PageService) Count(a0 interface{}) (int, error) {
+	m.record("Count", a0)
+	if m.CountFunc != nil {
+		return m.CountFunc(a0)
+	}
+	var r0 int
+	var r1 error
+	return r0, r1
+}
+
+func (m *PageService) Get(a0 int64, a1 interface{}) (*shopify.Page, error) {
+	m.record("Get", a0, a1)
+	if m.GetFunc != nil {
+		return m.GetFunc(a0, a1)
+	}
+	var r0 *shopify.Page
+	var r1 error
+	return r0, r1
+}
+
+func (m *PageService) Create(a0 shopify.Page) (*shopify.Page, error) {
+	m.record("Create", a0)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(a0)
+	}
+	var r0 *shopify.Page
+	var r1 error
+	return r0, r1
+}
+
+func (m *PageService) Update(a0 shopify.Page) (*shopify.Page, error) {
+	m.record("Update", a0)
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(a0)
+	}
+	var r0 *shopify.Page
+	var r1 error
+	return r0, r1
+}
+
+func (m *PageService) Delete(a0 int64) error {
+	m.record("Delete", a0)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(a0)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *PageService) ListMetafields(a0 int64, a1 interface{}) ([]shopify.Metafield, error) {
+	m.record("ListMetafields", a0, a1)
+	if m.ListMetafieldsFunc != nil {
+		return m.ListMetafieldsFunc(a0, a1)
+	}
+	var r0 []shopify.Metafield
+	var r1 error
+	return r0, r1
+}
+
+func (m *PageService) CountMetafields(a0 int64, a1 interface{}) (int, error) {
+	m.record("CountMetafields", a0, a1)
+	if m.CountMetafieldsFunc != nil {
+		return m.CountMetafieldsFunc(a0, a1)
+	}
+	var r0 int
+	var r1 error
+	return r0, r1
+}
+
+func (m *PageService) GetMetafield(a0 int64, a1 int64, a2 interface{}) (*shopify.Metafield, error) {
+	m.record("GetMetafield", a0, a1, a2)
+	if m.GetMetafieldFunc != nil {
+		return m.GetMetafieldFunc(a0, a1, a2)
+	}
+	var r0 *shopify.Metafield
+	var r1 error
+	return r0, r1
+}
+
+func (m *PageService) CreateMetafield(a0 int64, a1 shopify.Metafield) (*shopify.Metafield, error) {
+	m.record("CreateMetafield", a0, a1)
+	if m.CreateMetafieldFunc != nil {
+		return m.CreateMetafieldFunc(a0, a1)
+	}
+	var r0 *shopify.Metafield
+	var r1 error
+	return r0, r1
+}
+
+func (m *PageService) UpdateMetafield(a0 int64, a1 shopify.Metafield) (*shopify.Metafield, error) {
+	m.record("UpdateMetafield", a0, a1)
+	if m.UpdateMetafieldFunc != nil {
+		return m.UpdateMetafieldFunc(a0, a1)
+	}
+	var r0 *shopify.Metafield
+	var r1 error
+	return r0, r1
+}
+
+func (m *PageService) DeleteMetafield(a0 int64, a1 int64) error {
+	m.record("DeleteMetafield", a0, a1)
+	if m.DeleteMetafieldFunc != nil {
+		return m.DeleteMetafieldFunc(a0, a1)
+	}
+	var r0 error
+	return r0
+}
+
+// PayoutsService is a fake of synergyshopify.PayoutsService.
+type PayoutsService struct {
+	CallRecorder
+
+	ListFunc               func(interface{}) ([]shopify.Payout, error)
+	ListWithPaginationFunc func(interface{}) ([]shopify.Payout, *shopify.Pagination, error)
+	GetFunc                func(int64, interface{}) (*shopify.Payout, error)
+}
+
+var _ shopify.PayoutsService = (*PayoutsService)(nil)
+
+func (m *PayoutsService) List(a0 interface{}) ([]shopify.Payout, error) {
+	m.record("List", a0)
+	if m.ListFunc != nil {
+		return m.ListFunc(a0)
+	}
+	var r0 []shopify.Payout
+	var r1 error
+	return r0, r1
+}
+
+func (m *PayoutsService) ListWithPagination(a0 interface{}) ([]shopify.Payout, *shopify.Pagination, error) {
+	m.record("ListWithPagination", a0)
+	if m.ListWithPaginationFunc != nil {
+		return m.ListWithPaginationFunc(a0)
+	}
+	var r0 []shopify.Payout
+	var r1 *shopify.Pagination
+	var r2 error
+	return r0, r1, r2
+}
+
+func (m *PayoutsService) Get(a0 int64, a1 interface{}) (*shopify.Payout, error) {
+	m.record("Get", a0, a1)
+	if m.GetFunc != nil {
+		return m.GetFunc(a0, a1)
+	}
+	var r0 *shopify.Payout
+	var r1 error
+	return r0, r1
+}
+
+// PriceRuleService is a fake of synergyshopify.PriceRuleService.
+type PriceRuleService struct {
+	CallRecorder
+
+	GetFunc                func(int64) (*shopify.PriceRule, error)
+	CreateFunc             func(shopify.PriceRule) (*shopify.PriceRule, error)
+	UpdateFunc             func(shopify.PriceRule) (*shopify.PriceRule, error)
+	ListFunc               func() ([]shopify.PriceRule, error)
+	ListWithPaginationFunc func(interface{}) ([]shopify.PriceRule, *shopify.Pagination, error)
+	DeleteFunc             func(int64) error
+}
+
+var _ shopify.PriceRuleService = (*PriceRuleService)(nil)
+
+func (m *PriceRuleService) Get(a0 int64) (*shopify.PriceRule, error) {
+	m.record("Get", a0)
+	if m.GetFunc != nil {
+		return m.GetFunc(a0)
+	}
+	var r0 *shopify.PriceRule
+	var r1 error
+	return r0, r1
+}
+
+func (m *PriceRuleService) Create(a0 shopify.PriceRule) (*shopify.PriceRule, error) {
+	m.record("Create", a0)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(a0)
+	}
+	var r0 *shopify.PriceRule
+	var r1 error
+	return r0, r1
+}
+
+func (m *PriceRuleService) Update(a0 shopify.PriceRule) (*shopify.PriceRule, error) {
+	m.record("Update", a0)
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(a0)
+	}
+	var r0 *shopify.PriceRule
+	var r1 error
+	return r0, r1
+}
+
+func (m *PriceRuleService) List() ([]shopify.PriceRule, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc()
+	}
+	var r0 []shopify.PriceRule
+	var r1 error
+	return r0, r1
+}
+
+func (m *PriceRuleService) ListWithPagination(a0 interface{}) ([]shopify.PriceRule, *shopify.Pagination, error) {
+	m.record("ListWithPagination", a0)
+	if m.ListWithPaginationFunc != nil {
+		return m.ListWithPaginationFunc(a0)
+	}
+	var r0 []shopify.PriceRule
+	var r1 *shopify.Pagination
+	var r2 error
+	return r0, r1, r2
+}
+
+func (m *PriceRuleService) Delete(a0 int64) error {
+	m.record("Delete", a0)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(a0)
+	}
+	var r0 error
+	return r0
+}
+
+// ProductListingService is a fake of synergyshopify.ProductListingService.
+type ProductListingService struct {
+	CallRecorder
+
+	ListFunc               func(interface{}) ([]shopify.ProductListing, error)
+	ListWithPaginationFunc func(interface{}) ([]shopify.ProductListing, *shopify.Pagination, error)
+	CountFunc              func(interface{}) (int, error)
+	GetFunc                func(int64, interface{}) (*shopify.ProductListing, error)
+	GetProductIDsFunc      func(interface{}) ([]int64, error)
+	PublishFunc            func(int64) (*shopify.ProductListing, error)
+	DeleteFunc             func(int64) error
+}
+
+var _ shopify.ProductListingService = (*ProductListingService)(nil)
+
+func (m *ProductListingService) List(a0 interface{}) ([]shopify.ProductListing, error) {
+	m.record("List", a0)
+	if m.ListFunc != nil {
+		return m.ListFunc(a0)
+	}
+	var r0 []shopify.ProductListing
+	var r1 error
+	return r0, r1
+}
+
+func (m *ProductListingService) ListWithPagination(a0 interface{}) ([]shopify.ProductListing, *shopify.Pagination, error) {
+	m.record("ListWithPagination", a0)
+	if m.ListWithPaginationFunc != nil {
+		return m.ListWithPaginationFunc(a0)
+	}
+	var r0 []shopify.ProductListing
+	var r1 *shopify.Pagination
+	var r2 error
+	return r0, r1, r2
+}
+
+func (m *ProductListingService) Count(a0 interface{}) (int, error) {
+	m.record("Count", a0)
+	if m.CountFunc != nil {
+		return m.CountFunc(a0)
+	}
+	var r0 int
+	var r1 error
+	return r0, r1
+}
+
+func (m *ProductListingService) Get(a0 int64, a1 interface{}) (*shopify.ProductListing, error) {
+	m.record("Get", a0, a1)
+	if m.GetFunc != nil {
+		return m.GetFunc(a0, a1)
+	}
+	var r0 *shopify.ProductListing
+	var r1 error
+	return r0, r1
+}
+
+func (m *ProductListingService) GetProductIDs(a0 interface{}) ([]int64, error) {
+	m.record("GetProductIDs", a0)
+	if m.GetProductIDsFunc != nil {
+		return m.GetProductIDsFunc(a0)
+	}
+	var r0 []int64
+	var r1 error
+	return r0, r1
+}
+
+func (m *ProductListingService) Publish(a0 int64) (*shopify.ProductListing, error) {
+	m.record("Publish", a0)
+	if m.PublishFunc != nil {
+		return m.PublishFunc(a0)
+	}
+	var r0 *shopify.ProductListing
+	var r1 error
+	return r0, r1
+}
+
+func (m *ProductListingService) Delete(a0 int64) error {
+	m.record("Delete", a0)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(a0)
+	}
+	var r0 error
+	return r0
+}
+
+// ProductService is a fake of synergyshopify.ProductService.
+type ProductService struct {
+	CallRecorder
+
+	ListFunc               func(interface{}) ([]shopify.Product, error)
+	ListWithPaginationFunc func(interface{}) ([]shopify.Product, *shopify.Pagination, error)
+	CountFunc              func(interface{}) (int, error)
+	GetFunc                func(int64, interface{}) (*shopify.Product, error)
+	CreateFunc             func(shopify.Product) (*shopify.Product, error)
+	UpdateFunc             func(shopify.Product) (*shopify.Product, error)
+	DeleteFunc             func(int64) error
+	ListMetafieldsFunc     func(int64, interface{}) ([]shopify.Metafield, error)
+	CountMetafieldsFunc    func(int64, interface{}) (int, error)
+	GetMetafieldFunc       func(int64, int64, interface{}) (*shopify.Metafield, error)
+	CreateMetafieldFunc    func(int64, shopify.Metafield) (*shopify.Metafield, error)
+	UpdateMetafieldFunc    func(int64, shopify.Metafield) (*shopify.Metafield, error)
+	DeleteMetafieldFunc    func(int64, int64) error
+}
+
+var _ shopify.ProductService = (*ProductService)(nil)
+
+func (m *ProductService) List(a0 interface{}) ([]shopify.Product, error) {
+	m.record("List", a0)
+	if m.ListFunc != nil {
+		return m.ListFunc(a0)
+	}
+	var r0 []shopify.Product
+	var r1 error
+	return r0, r1
+}
+
+func (m *ProductService) ListWithPagination(a0 interface{}) ([]shopify.Product, *shopify.Pagination, error) {
+	m.record("ListWithPagination", a0)
+	if m.ListWithPaginationFunc != nil {
+		return m.ListWithPaginationFunc(a0)
+	}
+	var r0 []shopify.Product
+	var r1 *shopify.Pagination
+	var r2 error
+	return r0, r1, r2
+}
+
+func (m *ProductService) Count(a0 interface{}) (int, error) {
+	m.record("Count", a0)
+	if m.CountFunc != nil {
+		return m.CountFunc(a0)
+	}
+	var r0 int
+	var r1 error
+	return r0, r1
+}
+
+func (m *ProductService) Get(a0 int64, a1 interface{}) (*shopify.Product, error) {
+	m.record("Get", a0, a1)
+	if m.GetFunc != nil {
+		return m.GetFunc(a0, a1)
+	}
+	var r0 *shopify.Product
+	var r1 error
+	return r0, r1
+}
+
+func (m *ProductService) Create(a0 shopify.Product) (*shopify.Product, error) {
+	m.record("Create", a0)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(a0)
+	}
+	var r0 *shopify.Product
+	var r1 error
+	return r0, r1
+}
+
+func (m *ProductService) Update(a0 shopify.Product) (*shopify.Product, error) {
+	m.record("Update", a0)
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(a0)
+	}
+	var r0 *shopify.Product
+	var r1 error
+	return r0, r1
+}
+
+func (m *ProductService) Delete(a0 int64) error {
+	m.record("Delete", a0)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(a0)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *ProductService) ListMetafields(a0 int64, a1 interface{}) ([]shopify.Metafield, error) {
+	m.record("ListMetafields", a0, a1)
+	if m.ListMetafieldsFunc != nil {
+		return m.ListMetafieldsFunc(a0, a1)
+	}
+	var r0 []shopify.Metafield
+	var r1 error
+	return r0, r1
+}
+
+func (m *ProductService) CountMetafields(a0 int64, a1 interface{}) (int, error) {
+	m.record("CountMetafields", a0, a1)
+	if m.CountMetafieldsFunc != nil {
+		return m.CountMetafieldsFunc(a0, a1)
+	}
+	var r0 int
+	var r1 error
+	return r0, r1
+}
+
+func (m *ProductService) GetMetafield(a0 int64, a1 int64, a2 interface{}) (*shopify.Metafield, error) {
+	m.record("GetMetafield", a0, a1, a2)
+	if m.GetMetafieldFunc != nil {
+		return m.GetMetafieldFunc(a0, a1, a2)
+	}
+	var r0 *shopify.Metafield
+	var r1 error
+	return r0, r1
+}
+
+func (m *ProductService) CreateMetafield(a0 int64, a1 shopify.Metafield) (*shopify.Metafield, error) {
+	m.record("CreateMetafield", a0, a1)
+	if m.CreateMetafieldFunc != nil {
+		return m.CreateMetafieldFunc(a0, a1)
+	}
+	var r0 *shopify.Metafield
+	var r1 error
+	return r0, r1
+}
+
+func (m *ProductService) UpdateMetafield(a0 int64, a1 shopify.Metafield) (*shopify.Metafield, error) {
+	m.record("UpdateMetafield", a0, a1)
+	if m.UpdateMetafieldFunc != nil {
+		return m.UpdateMetafieldFunc(a0, a1)
+	}
+	var r0 *shopify.Metafield
+	var r1 error
+	return r0, r1
+}
+
+func (m *ProductService) DeleteMetafield(a0 int64, a1 int64) error {
+	m.record("DeleteMetafield", a0, a1)
+	if m.DeleteMetafieldFunc != nil {
+		return m.DeleteMetafieldFunc(a0, a1)
+	}
+	var r0 error
+	return r0
+}
+
+// RecurringApplicationChargeService is a fake of synergyshopify.RecurringApplicationChargeService.
+type RecurringApplicationChargeService struct {
+	CallRecorder
+
+	CreateFunc   func(shopify.RecurringApplicationCharge) (*shopify.RecurringApplicationCharge, error)
+	GetFunc      func(int64, interface{}) (*shopify.RecurringApplicationCharge, error)
+	ListFunc     func(interface{}) ([]shopify.RecurringApplicationCharge, error)
+	ActivateFunc func(shopify.RecurringApplicationCharge) (*shopify.RecurringApplicationCharge, error)
+	DeleteFunc   func(int64) error
+	UpdateFunc   func(int64, int64) (*shopify.RecurringApplicationCharge, error)
+}
+
+var _ shopify.RecurringApplicationChargeService = (*RecurringApplicationChargeService)(nil)
+
+func (m *RecurringApplicationChargeService) Create(a0 shopify.RecurringApplicationCharge) (*shopify.RecurringApplicationCharge, error) {
+	m.record("Create", a0)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(a0)
+	}
+	var r0 *shopify.RecurringApplicationCharge
+	var r1 error
+	return r0, r1
+}
+
+func (m *RecurringApplicationChargeService) Get(a0 int64, a1 interface{}) (*shopify.RecurringApplicationCharge, error) {
+	m.record("Get", a0, a1)
+	if m.GetFunc != nil {
+		return m.GetFunc(a0, a1)
+	}
+	var r0 *shopify.RecurringApplicationCharge
+	var r1 error
+	return r0, r1
+}
+
+func (m *RecurringApplicationChargeService) List(a0 interface{}) ([]shopify.RecurringApplicationCharge, error) {
+	m.record("List", a0)
+	if m.ListFunc != nil {
+		return m.ListFunc(a0)
+	}
+	var r0 []shopify.RecurringApplicationCharge
+	var r1 error
+	return r0, r1
+}
+
+func (m *RecurringApplicationChargeService) Activate(a0 shopify.RecurringApplicationCharge) (*shopify.RecurringApplicationCharge, error) {
+	m.record("Activate", a0)
+	if m.ActivateFunc != nil {
+		return m.ActivateFunc(a0)
+	}
+	var r0 *shopify.RecurringApplicationCharge
+	var r1 error
+	return r0, r1
+}
+
+func (m *RecurringApplicationChargeService) Delete(a0 int64) error {
+	m.record("Delete", a0)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(a0)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *RecurringApplicationChargeService) Update(a0 int64, a1 int64) (*shopify.RecurringApplicationCharge, error) {
+	m.record("Update", a0, a1)
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(a0, a1)
+	}
+	var r0 *shopify.RecurringApplicationCharge
+	var r1 error
+	return r0, r1
+}
+
+// RedirectService is a fake of synergyshopify.RedirectService.
+type RedirectService struct {
+	CallRecorder
+
+	ListFunc               func(interface{}) ([]shopify.Redirect, error)
+	ListWithPaginationFunc func(interface{}) ([]shopify.Redirect, *shopify.Pagination, error)
+	CountFunc              func(interface{}) (int, error)
+	GetFunc                func(int64, interface{}) (*shopify.Redirect, error)
+	CreateFunc             func(shopify.Redirect) (*shopify.Redirect, error)
+	UpdateFunc             func(shopify.Redirect) (*shopify.Redirect, error)
+	DeleteFunc             func(int64) error
+}
+
+var _ shopify.RedirectService = (*RedirectService)(nil)
+
+func (m *RedirectService) List(a0 interface{}) ([]shopify.Redirect, error) {
+	m.record("List", a0)
+	if m.ListFunc != nil {
+		return m.ListFunc(a0)
+	}
+	var r0 []shopify.Redirect
+	var r1 error
+	return r0, r1
+}
+
+func (m *RedirectService) ListWithPagination(a0 interface{}) ([]shopify.Redirect, *shopify.Pagination, error) {
+	m.record("ListWithPagination", a0)
+	if m.ListWithPaginationFunc != nil {
+		return m.ListWithPaginationFunc(a0)
+	}
+	var r0 []shopify.Redirect
+	var r1 *shopify.Pagination
+	var r2 error
+	return r0, r1, r2
+}
+
+func (m *RedirectService) Count(a0 interface{}) (int, error) {
+	m.record("Count", a0)
+	if m.CountFunc != nil {
+		return m.CountFunc(a0)
+	}
+	var r0 int
+	var r1 error
+	return r0, r1
+}
+
+func (m *RedirectService) Get(a0 int64, a1 interface{}) (*shopify.Redirect, error) {
+	m.record("Get", a0, a1)
+	if m.GetFunc != nil {
+		return m.GetFunc(a0, a1)
+	}
+	var r0 *shopify.Redirect
+	var r1 error
+	return r0, r1
+}
+
+func (m *RedirectService) Create(a0 shopify.Redirect) (*shopify.Redirect, error) {
+	m.record("Create", a0)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(a0)
+	}
+	var r0 *shopify.Redirect
+	var r1 error
+	return r0, r1
+}
+
+func (m *RedirectService) Update(a0 shopify.Redirect) (*shopify.Redirect, error) {
+	m.record("Update", a0)
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(a0)
+	}
+	var r0 *shopify.Redirect
+	var r1 error
+	return r0, r1
+}
+
+func (m *RedirectService) Delete(a0 int64) error {
+	m.record("Delete", a0)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(a0)
+	}
+	var r0 error
+	return r0
+}
+
+// ScriptTagService is a fake of synergyshopify.ScriptTagService.
+type ScriptTagService struct {
+	CallRecorder
+
+	ListFunc   func(interface{}) ([]shopify.ScriptTag, error)
+	CountFunc  func(interface{}) (int, error)
+	GetFunc    func(int64, interface{}) (*shopify.ScriptTag, error)
+	CreateFunc func(shopify.ScriptTag) (*shopify.ScriptTag, error)
+	UpdateFunc func(shopify.ScriptTag) (*shopify.ScriptTag, error)
+	DeleteFunc func(int64) error
+}
+
+var _ shopify.ScriptTagService = (*ScriptTagService)(nil)
+
+func (m *ScriptTagService) List(a0 interface{}) ([]shopify.ScriptTag, error) {
+	m.record("List", a0)
+	if m.ListFunc != nil {
+		return m.ListFunc(a0)
+	}
+	var r0 []shopify.ScriptTag
+	var r1 error
+	return r0, r1
+}
+
+func (m *ScriptTagService) Count(a0 interface{}) (int, error) {
+	m.record("Count", a0)
+	if m.CountFunc != nil {
+		return m.CountFunc(a0)
+	}
+	var r0 int
+	var r1 error
+	return r0, r1
+}
+
+func (m *ScriptTagService) Get(a0 int64, a1 interface{}) (*shopify.ScriptTag, error) {
+	m.record("Get", a0, a1)
+	if m.GetFunc != nil {
+		return m.GetFunc(a0, a1)
+	}
+	var r0 *shopify.ScriptTag
+	var r1 error
+	return r0, r1
+}
+
+func (m *ScriptTagService) Create(a0 shopify.ScriptTag) (*shopify.ScriptTag, error) {
+	m.record("Create", a0)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(a0)
+	}
+	var r0 *shopify.ScriptTag
+	var r1 error
+	return r0, r1
+}
+
+func (m *ScriptTagService) Update(a0 shopify.ScriptTag) (*shopify.ScriptTag, error) {
+	m.record("Update", a0)
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(a0)
+	}
+	var r0 *shopify.ScriptTag
+	var r1 error
+	return r0, r1
+}
+
+func (m *ScriptTagService) Delete(a0 int64) error {
+	m.record("Delete", a0)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(a0)
+	}
+	var r0 error
+	return r0
+}
+
+// ShippingZoneService is a fake of synergyshopify.ShippingZoneService.
+type ShippingZoneService struct {
+	CallRecorder
+
+	ListFunc func() ([]shopify.ShippingZone, error)
+}
+
+var _ shopify.ShippingZoneService = (*ShippingZoneService)(nil)
+
+func (m *ShippingZoneService) List() ([]shopify.ShippingZone, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc()
+	}
+	var r0 []shopify.ShippingZone
+	var r1 error
+	return r0, r1
+}
+
+// ShopService is a fake of synergyshopify.ShopService.
+type ShopService struct {
+	CallRecorder
+
+	GetFunc func(interface{}) (*shopify.Shop, error)
+}
+
+var _ shopify.ShopService = (*ShopService)(nil)
+
+func (m *ShopService) Get(a0 interface{}) (*shopify.Shop, error) {
+	m.record("Get", a0)
+	if m.GetFunc != nil {
+		return m.GetFunc(a0)
+	}
+	var r0 *shopify.Shop
+	var r1 error
+	return r0, r1
+}
+
+// SmartCollectionService is a fake of synergyshopify.SmartCollectionService.
+type SmartCollectionService struct {
+	CallRecorder
+
+	ListFunc            func(interface{}) ([]shopify.SmartCollection, error)
+	CountFunc           func(interface{}) (int, error)
+	GetFunc             func(int64, interface{}) (*shopify.SmartCollection, error)
+	CreateFunc          func(shopify.SmartCollection) (*shopify.SmartCollection, error)
+	UpdateFunc          func(shopify.SmartCollection) (*shopify.SmartCollection, error)
+	DeleteFunc          func(int64) error
+	ListMetafieldsFunc  func(int64, interface{}) ([]shopify.Metafield, error)
+	CountMetafieldsFunc func(int64, interface{}) (int, error)
+	GetMetafieldFunc    func(int64, int64, interface{}) (*shopify.Metafield, error)
+	CreateMetafieldFunc func(int64, shopify.Metafield) (*shopify.Metafield, error)
+	UpdateMetafieldFunc func(int64, shopify.Metafield) (*shopify.Metafield, error)
+	DeleteMetafieldFunc func(int64, int64) error
+}
+
+var _ shopify.SmartCollectionService = (*SmartCollectionService)(nil)
+
+func (m *SmartCollectionService) List(a0 interface{}) ([]shopify.SmartCollection, error) {
+	m.record("List", a0)
+	if m.ListFunc != nil {
+		return m.ListFunc(a0)
+	}
+	var r0 []shopify.SmartCollection
+	var r1 error
+	return r0, r1
+}
+
+func (m *SmartCollectionService) Count(a0 interface{}) (int, error) {
+	m.record("Count", a0)
+	if m.CountFunc != nil {
+		return m.CountFunc(a0)
+	}
+	var r0 int
+	var r1 error
+	return r0, r1
+}
+
+func (m *SmartCollectionService) Get(a0 int64, a1 interface{}) (*shopify.SmartCollection, error) {
+	m.record("Get", a0, a1)
+	if m.GetFunc != nil {
+		return m.GetFunc(a0, a1)
+	}
+	var r0 *shopify.SmartCollection
+	var r1 error
+	return r0, r1
+}
+
+func (m *SmartCollectionService) Create(a0 shopify.SmartCollection) (*shopify.SmartCollection, error) {
+	m.record("Create", a0)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(a0)
+	}
+	var r0 *shopify.SmartCollection
+	var r1 error
+	return r0, r1
+}
+
+func (m *SmartCollectionService) Update(a0 shopify.SmartCollection) (*shopify.SmartCollection, error) {
+	m.record("Update", a0)
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(a0)
+	}
+	var r0 *shopify.SmartCollection
+	var r1 error
+	return r0, r1
+}
+
+func (m *SmartCollectionService) Delete(a0 int64) error {
+	m.record("Delete", a0)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(a0)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *SmartCollectionService) ListMetafields(a0 int64, a1 interface{}) ([]shopify.Metafield, error) {
+	m.record("ListMetafields", a0, a1)
+	if m.ListMetafieldsFunc != nil {
+		return m.ListMetafieldsFunc(a0, a1)
+	}
+	var r0 []shopify.Metafield
+	var r1 error
+	return r0, r1
+}
+
+func (m *SmartCollectionService) CountMetafields(a0 int64, a1 interface{}) (int, error) {
+	m.record("CountMetafields", a0, a1)
+	if m.CountMetafieldsFunc != nil {
+		return m.CountMetafieldsFunc(a0, a1)
+	}
+	var r0 int
+	var r1 error
+	return r0, r1
+}
+
+func (m *SmartCollectionService) GetMetafield(a0 int64, a1 int64, a2 interface{}) (*shopify.Metafield, error) {
+	m.record("GetMetafield", a0, a1, a2)
+	if m.GetMetafieldFunc != nil {
+		return m.GetMetafieldFunc(a0, a1, a2)
+	}
+	var r0 *shopify.Metafield
+	var r1 error
+	return r0, r1
+}
+
+func (m *SmartCollectionService) CreateMetafield(a0 int64, a1 shopify.Metafield) (*shopify.Metafield, error) {
+	m.record("CreateMetafield", a0, a1)
+	if m.CreateMetafieldFunc != nil {
+		return m.CreateMetafieldFunc(a0, a1)
+	}
+	var r0 *shopify.Metafield
+	var r1 error
+	return r0, r1
+}
+
+func (m *SmartCollectionService) UpdateMetafield(a0 int64, a1 shopify.Metafield) (*shopify.Metafield, error) {
+	m.record("UpdateMetafield", a0, a1)
+	if m.UpdateMetafieldFunc != nil {
+		return m.UpdateMetafieldFunc(a0, a1)
+	}
+	var r0 *shopify.Metafield
+	var r1 error
+	return r0, r1
+}
+
+func (m *SmartCollectionService) DeleteMetafield(a0 int64, a1 int64) error {
+	m.record("DeleteMetafield", a0, a1)
+	if m.DeleteMetafieldFunc != nil {
+		return m.DeleteMetafieldFunc(a0, a1)
+	}
+	var r0 error
+	return r0
+}
+
+// StorefrontAccessTokenService is a fake of synergyshopify.StorefrontAccessTokenService.
+type StorefrontAccessTokenService struct {
+	CallRecorder
+
+	ListFunc   func(interface{}) ([]shopify.StorefrontAccessToken, error)
+	CreateFunc func(shopify.StorefrontAccessToken) (*shopify.StorefrontAccessToken, error)
+	DeleteFunc func(int64) error
+}
+
+var _ shopify.StorefrontAccessTokenService = (*StorefrontAccessTokenService)(nil)
+
+func (m *StorefrontAccessTokenService) List(a0 interface{}) ([]shopify.StorefrontAccessToken, error) {
+	m.record("List", a0)
+	if m.ListFunc != nil {
+		return m.ListFunc(a0)
+	}
+	var r0 []shopify.StorefrontAccessToken
+	var r1 error
+	return r0, r1
+}
+
+func (m *StorefrontAccessTokenService) Create(a0 shopify.StorefrontAccessToken) (*shopify.StorefrontAccessToken, error) {
+	m.record("Create", a0)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(a0)
+	}
+	var r0 *shopify.StorefrontAccessToken
+	var r1 error
+	return r0, r1
+}
+
+func (m *StorefrontAccessTokenService) Delete(a0 int64) error {
+	m.record("Delete", a0)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(a0)
+	}
+	var r0 error
+	return r0
+}
+
+// ThemeService is a fake of synergyshopify.ThemeService.
+type ThemeService struct {
+	CallRecorder
+
+	ListFunc   func(interface{}) ([]shopify.Theme, error)
+	CreateFunc func(shopify.Theme) (*shopify.Theme, error)
+	GetFunc    func(int64, interface{}) (*shopify.Theme, error)
+	UpdateFunc func(shopify.Theme) (*shopify.Theme, error)
+	DeleteFunc func(int64) error
+}
+
+var _ shopify.ThemeService = (*ThemeService)(nil)
+
+func (m *ThemeService) List(a0 interface{}) ([]shopify.Theme, error) {
+	m.record("List", a0)
+	if m.ListFunc != nil {
+		return m.ListFunc(a0)
+	}
+	var r0 []shopify.Theme
+	var r1 error
+	return r0, r1
+}
+
+func (m *ThemeService) Create(a0 shopify.Theme) (*shopify.Theme, error) {
+	m.record("Create", a0)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(a0)
+	}
+	var r0 *shopify.Theme
+	var r1 error
+	return r0, r1
+}
+
+func (m *ThemeService) Get(a0 int64, a1 interface{}) (*shopify.Theme, error) {
+	m.record("Get", a0, a1)
+	if m.GetFunc != nil {
+		return m.GetFunc(a0, a1)
+	}
+	var r0 *shopify.Theme
+	var r1 error
+	return r0, r1
+}
+
+func (m *ThemeService) Update(a0 shopify.Theme) (*shopify.Theme, error) {
+	m.record("Update", a0)
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(a0)
+	}
+	var r0 *shopify.Theme
+	var r1 error
+	return r0, r1
+}
+
+func (m *ThemeService) Delete(a0 int64) error {
+	m.record("Delete", a0)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(a0)
+	}
+	var r0 error
+	return r0
+}
+
+// TransactionService is a fake of synergyshopify.TransactionService.
+type TransactionService struct {
+	CallRecorder
+
+	ListFunc   func(int64, interface{}) ([]shopify.Transaction, error)
+	CountFunc  func(int64, interface{}) (int, error)
+	GetFunc    func(int64, int64, interface{}) (*shopify.Transaction, error)
+	CreateFunc func(int64, shopify.Transaction) (*shopify.Transaction, error)
+}
+
+var _ shopify.TransactionService = (*TransactionService)(nil)
+
+func (m *TransactionService) List(a0 int64, a1 interface{}) ([]shopify.Transaction, error) {
+	m.record("List", a0, a1)
+	if m.ListFunc != nil {
+		return m.ListFunc(a0, a1)
+	}
+	var r0 []shopify.Transaction
+	var r1 error
+	return r0, r1
+}
+
+func (m *TransactionService) Count(a0 int64, a1 interface{}) (int, error) {
+	m.record("Count", a0, a1)
+	if m.CountFunc != nil {
+		return m.CountFunc(a0, a1)
+	}
+	var r0 int
+	var r1 error
+	return r0, r1
+}
+
+func (m *TransactionService) Get(a0 int64, a1 int64, a2 interface{}) (*shopify.Transaction, error) {
+	m.record("Get", a0, a1, a2)
+	if m.GetFunc != nil {
+		return m.GetFunc(a0, a1, a2)
+	}
+	var r0 *shopify.Transaction
+	var r1 error
+	return r0, r1
+}
+
+func (m *TransactionService) Create(a0 int64, a1 shopify.Transaction) (*shopify.Transaction, error) {
+	m.record("Create", a0, a1)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(a0, a1)
+	}
+	var r0 *shopify.Transaction
+	var r1 error
+	return r0, r1
+}
+
+// UsageChargeService is a fake of synergyshopify.UsageChargeService.
+type UsageChargeService struct {
+	CallRecorder
+
+	CreateFunc func(int64, shopify.UsageCharge) (*shopify.UsageCharge, error)
+	GetFunc    func(int64, int64, interface{}) (*shopify.UsageCharge, error)
+	ListFunc   func(int64, interface{}) ([]shopify.UsageCharge, error)
+}
+
+var _ shopify.UsageChargeService = (*UsageChargeService)(nil)
+
+func (m *UsageChargeService) Create(a0 int64, a1 shopify.UsageCharge) (*shopify.UsageCharge, error) {
+	m.record("Create", a0, a1)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(a0, a1)
+	}
+	var r0 *shopify.UsageCharge
+	var r1 error
+	return r0, r1
+}
+
+func (m *UsageChargeService) Get(a0 int64, a1 int64, a2 interface{}) (*shopify.UsageCharge, error) {
+	m.record("Get", a0, a1, a2)
+	if m.GetFunc != nil {
+		return m.GetFunc(a0, a1, a2)
+	}
+	var r0 *shopify.UsageCharge
+	var r1 error
+	return r0, r1
+}
+
+func (m *UsageChargeService) List(a0 int64, a1 interface{}) ([]shopify.UsageCharge, error) {
+	m.record("List", a0, a1)
+	if m.ListFunc != nil {
+		return m.ListFunc(a0, a1)
+	}
+	var r0 []shopify.UsageCharge
+	var r1 error
+	return r0, r1
+}
+
+// VariantService is a fake of synergyshopify.VariantService.
+type VariantService struct {
+	CallRecorder
+
+	ListFunc            func(int64, interface{}) ([]shopify.Variant, error)
+	CountFunc           func(int64, interface{}) (int, error)
+	GetFunc             func(int64, interface{}) (*shopify.Variant, error)
+	CreateFunc          func(int64, shopify.Variant) (*shopify.Variant, error)
+	UpdateFunc          func(shopify.Variant) (*shopify.Variant, error)
+	DeleteFunc          func(int64, int64) error
+	ListMetafieldsFunc  func(int64, interface{}) ([]shopify.Metafield, error)
+	CountMetafieldsFunc func(int64, interface{}) (int, error)
+	GetMetafieldFunc    func(int64, int64, interface{}) (*shopify.Metafield, error)
+	CreateMetafieldFunc func(int64, shopify.Metafield) (*shopify.Metafield, error)
+	UpdateMetafieldFunc func(int64, shopify.Metafield) (*shopify.Metafield, error)
+	DeleteMetafieldFunc func(int64, int64) error
+}
+
+var _ shopify.VariantService = (*VariantService)(nil)
+
+func (m *VariantService) List(a0 int64, a1 interface{}) ([]shopify.Variant, error) {
+	m.record("List", a0, a1)
+	if m.ListFunc != nil {
+		return m.ListFunc(a0, a1)
+	}
+	var r0 []shopify.Variant
+	var r1 error
+	return r0, r1
+}
+
+func (m *VariantService) Count(a0 int64, a1 interface{}) (int, error) {
+	m.record("Count", a0, a1)
+	if m.CountFunc != nil {
+		return m.CountFunc(a0, a1)
+	}
+	var r0 int
+	var r1 error
+	return r0, r1
+}
+
+func (m *VariantService) Get(a0 int64, a1 interface{}) (*shopify.Variant, error) {
+	m.record("Get", a0, a1)
+	if m.GetFunc != nil {
+		return m.GetFunc(a0, a1)
+	}
+	var r0 *shopify.Variant
+	var r1 error
+	return r0, r1
+}
+
+func (m *VariantService) Create(a0 int64, a1 shopify.Variant) (*shopify.Variant, error) {
+	m.record("Create", a0, a1)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(a0, a1)
+	}
+	var r0 *shopify.Variant
+	var r1 error
+	return r0, r1
+}
+
+func (m *VariantService) Update(a0 shopify.Variant) (*shopify.Variant, error) {
+	m.record("Update", a0)
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(a0)
+	}
+	var r0 *shopify.Variant
+	var r1 error
+	return r0, r1
+}
+
+func (m *VariantService) Delete(a0 int64, a1 int64) error {
+	m.record("Delete", a0, a1)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(a0, a1)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *VariantService) ListMetafields(a0 int64, a1 interface{}) ([]shopify.Metafield, error) {
+	m.record("ListMetafields", a0, a1)
+	if m.ListMetafieldsFunc != nil {
+		return m.ListMetafieldsFunc(a0, a1)
+	}
+	var r0 []shopify.Metafield
+	var r1 error
+	return r0, r1
+}
+
+func (m *VariantService) CountMetafields(a0 int64, a1 interface{}) (int, error) {
+	m.record("CountMetafields", a0, a1)
+	if m.CountMetafieldsFunc != nil {
+		return m.CountMetafieldsFunc(a0, a1)
+	}
+	var r0 int
+	var r1 error
+	return r0, r1
+}
+
+func (m *VariantService) GetMetafield(a0 int64, a1 int64, a2 interface{}) (*shopify.Metafield, error) {
+	m.record("GetMetafield", a0, a1, a2)
+	if m.GetMetafieldFunc != nil {
+		return m.GetMetafieldFunc(a0, a1, a2)
+	}
+	var r0 *shopify.Metafield
+	var r1 error
+	return r0, r1
+}
+
+func (m *VariantService) CreateMetafield(a0 int64, a1 shopify.Metafield) (*shopify.Metafield, error) {
+	m.record("CreateMetafield", a0, a1)
+	if m.CreateMetafieldFunc != nil {
+		return m.CreateMetafieldFunc(a0, a1)
+	}
+	var r0 *shopify.Metafield
+	var r1 error
+	return r0, r1
+}
+
+func (m *VariantService) UpdateMetafield(a0 int64, a1 shopify.Metafield) (*shopify.Metafield, error) {
+	m.record("UpdateMetafield", a0, a1)
+	if m.UpdateMetafieldFunc != nil {
+		return m.UpdateMetafieldFunc(a0, a1)
+	}
+	var r0 *shopify.Metafield
+	var r1 error
+	return r0, r1
+}
+
+func (m *VariantService) DeleteMetafield(a0 int64, a1 int64) error {
+	m.record("DeleteMetafield", a0, a1)
+	if m.DeleteMetafieldFunc != nil {
+		return m.DeleteMetafieldFunc(a0, a1)
+	}
+	var r0 error
+	return r0
+}
+
+// WebhookService is a fake of synergyshopify.WebhookService.
+type WebhookService struct {
+	CallRecorder
+
+	ListFunc               func(interface{}) ([]shopify.Webhook, error)
+	ListWithPaginationFunc func(interface{}) ([]shopify.Webhook, *shopify.Pagination, error)
+	CountFunc              func(interface{}) (int, error)
+	GetFunc                func(int64, interface{}) (*shopify.Webhook, error)
+	CreateFunc             func(shopify.Webhook) (*shopify.Webhook, error)
+	UpdateFunc             func(shopify.Webhook) (*shopify.Webhook, error)
+	DeleteFunc             func(int64) error
+}
+
+var _ shopify.WebhookService = (*WebhookService)(nil)
+
+func (m *WebhookService) List(a0 interface{}) ([]shopify.Webhook, error) {
+	m.record("List", a0)
+	if m.ListFunc != nil {
+		return m.ListFunc(a0)
+	}
+	var r0 []shopify.Webhook
+	var r1 error
+	return r0, r1
+}
+
+func (m *WebhookService) ListWithPagination(a0 interface{}) ([]shopify.Webhook, *shopify.Pagination, error) {
+	m.record("ListWithPagination", a0)
+	if m.ListWithPaginationFunc != nil {
+		return m.ListWithPaginationFunc(a0)
+	}
+	var r0 []shopify.Webhook
+	var r1 *shopify.Pagination
+	var r2 error
+	return r0, r1, r2
+}
+
+func (m *WebhookService) Count(a0 interface{}) (int, error) {
+	m.record("Count", a0)
+	if m.CountFunc != nil {
+		return m.CountFunc(a0)
+	}
+	var r0 int
+	var r1 error
+	return r0, r1
+}
+
+func (m *WebhookService) Get(a0 int64, a1 interface{}) (*shopify.Webhook, error) {
+	m.record("Get", a0, a1)
+	if m.GetFunc != nil {
+		return m.GetFunc(a0, a1)
+	}
+	var r0 *shopify.Webhook
+	var r1 error
+	return r0, r1
+}
+
+func (m *WebhookService) Create(a0 shopify.Webhook) (*shopify.Webhook, error) {
+	m.record("Create", a0)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(a0)
+	}
+	var r0 *shopify.Webhook
+	var r1 error
+	return r0, r1
+}
+
+func (m *WebhookService) Update(a0 shopify.Webhook) (*shopify.Webhook, error) {
+	m.record("Update", a0)
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(a0)
+	}
+	var r0 *shopify.Webhook
+	var r1 error
+	return r0, r1
+}
+
+func (m *WebhookService) Delete(a0 int64) error {
+	m.record("Delete", a0)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(a0)
+	}
+	var r0 error
+	return r0
+}
+
+// Services holds a fake of every service of a synergyshopify.Client.
+type Services struct {
+	Product                    *ProductService
+	CustomCollection           *CustomCollectionService
+	SmartCollection            *SmartCollectionService
+	Customer                   *CustomerService
+	CustomerAddress            *CustomerAddressService
+	Order                      *OrderService
+	Fulfillment                *FulfillmentService
+	DraftOrder                 *DraftOrderService
+	AbandonedCheckout          *AbandonedCheckoutService
+	Shop                       *ShopService
+	Webhook                    *WebhookService
+	Variant                    *VariantService
+	Image                      *ImageService
+	Transaction                *TransactionService
+	Theme                      *ThemeService
+	Asset                      *AssetService
+	ScriptTag                  *ScriptTagService
+	RecurringApplicationCharge *RecurringApplicationChargeService
+	UsageCharge                *UsageChargeService
+	Metafield                  *MetafieldService
+	Blog                       *BlogService
+	ApplicationCharge          *ApplicationChargeService
+	Redirect                   *RedirectService
+	Page                       *PageService
+	StorefrontAccessToken      *StorefrontAccessTokenService
+	Collect                    *CollectService
+	Collection                 *CollectionService
+	Location                   *LocationService
+	DiscountCode               *DiscountCodeService
+	PriceRule                  *PriceRuleService
+	InventoryItem              *InventoryItemService
+	ShippingZone               *ShippingZoneService
+	ProductListing             *ProductListingService
+	InventoryLevel             *InventoryLevelService
+	AccessScopes               *AccessScopesService
+	FulfillmentService         *FulfillmentServiceService
+	CarrierService             *CarrierServiceService
+	Payouts                    *PayoutsService
+	GiftCard                   *GiftCardService
+	OrderRisk                  *OrderRiskService
+	GraphQL                    *GraphQLService
+	BulkOperation              *BulkOperationService
+}
+
+// NewServices returns a fake of every service of a client.
+func NewServices() *Services {
+	return &Services{
+		Product:                    new(ProductService),
+		CustomCollection:           new(CustomCollectionService),
+		SmartCollection:            new(SmartCollectionService),
+		Customer:                   new(CustomerService),
+		CustomerAddress:            new(CustomerAddressService),
+		Order:                      new(OrderService),
+		Fulfillment:                new(FulfillmentService),
+		DraftOrder:                 new(DraftOrderService),
+		AbandonedCheckout:          new(AbandonedCheckoutService),
+		Shop:                       new(ShopService),
+		Webhook:                    new(WebhookService),
+		Variant:                    new(VariantService),
+		Image:                      new(ImageService),
+		Transaction:                new(TransactionService),
+		Theme:                      new(ThemeService),
+		Asset:                      new(AssetService),
+		ScriptTag:                  new(ScriptTagService),
+		RecurringApplicationCharge: new(RecurringApplicationChargeService),
+		UsageCharge:                new(UsageChargeService),
+		Metafield:                  new(MetafieldService),
+		Blog:                       new(BlogService),
+		ApplicationCharge:          new(ApplicationChargeService),
+		Redirect:                   new(RedirectService),
+		Page:                       new(PageService),
+		StorefrontAccessToken:      new(StorefrontAccessTokenService),
+		Collect:                    new(CollectService),
+		Collection:                 new(CollectionService),
+		Location:                   new(LocationService),
+		DiscountCode:               new(DiscountCodeService),
+		PriceRule:                  new(PriceRuleService),
+		InventoryItem:              new(InventoryItemService),
+		ShippingZone:               new(ShippingZoneService),
+		ProductListing:             new(ProductListingService),
+		InventoryLevel:             new(InventoryLevelService),
+		AccessScopes:               new(AccessScopesService),
+		FulfillmentService:         new(FulfillmentServiceService),
+		CarrierService:             new(CarrierServiceService),
+		Payouts:                    new(PayoutsService),
+		GiftCard:                   new(GiftCardService),
+		OrderRisk:                  new(OrderRiskService),
+		GraphQL:                    new(GraphQLService),
+		BulkOperation:              new(BulkOperationService),
+	}
+}
+
+// apply points the service fields of a client at the fakes.
+func (s *Services) apply(c *shopify.Client) {
+	c.Product = s.Product
+	c.CustomCollection = s.CustomCollection
+	c.SmartCollection = s.SmartCollection
+	c.Customer = s.Customer
+	c.CustomerAddress = s.CustomerAddress
+	c.Order = s.Order
+	c.Fulfillment = s.Fulfillment
+	c.DraftOrder = s.DraftOrder
+	c.AbandonedCheckout = s.AbandonedCheckout
+	c.Shop = s.Shop
+	c.Webhook = s.Webhook
+	c.Variant = s.Variant
+	c.Image = s.Image
+	c.Transaction = s.Transaction
+	c.Theme = s.Theme
+	c.Asset = s.Asset
+	c.ScriptTag = s.ScriptTag
+	c.RecurringApplicationCharge = s.RecurringApplicationCharge
+	c.UsageCharge = s.UsageCharge
+	c.Metafield = s.Metafield
+	c.Blog = s.Blog
+	c.ApplicationCharge = s.ApplicationCharge
+	c.Redirect = s.Redirect
+	c.Page = s.Page
+	c.StorefrontAccessToken = s.StorefrontAccessToken
+	c.Collect = s.Collect
+	c.Collection = s.Collection
+	c.Location = s.Location
+	c.DiscountCode = s.DiscountCode
+	c.PriceRule = s.PriceRule
+	c.InventoryItem = s.InventoryItem
+	c.ShippingZone = s.ShippingZone
+	c.ProductListing = s.ProductListing
+	c.InventoryLevel = s.InventoryLevel
+	c.AccessScopes = s.AccessScopes
+	c.FulfillmentService = s.FulfillmentService
+	c.CarrierService = s.CarrierService
+	c.Payouts = s.Payouts
+	c.GiftCard = s.GiftCard
+	c.OrderRisk = s.OrderRisk
+	c.GraphQL = s.GraphQL
+	c.BulkOperation = s.BulkOperation
+}
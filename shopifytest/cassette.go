@@ -0,0 +1,394 @@
+package shopifytest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	shopify "github.com/binodsynergytechs/synergyshopify"
+)
+
+// Mode tells whether a Recorder records or replays its cassette.
+type Mode int
+
+const (
+	// ModeReplay serves the responses of the cassette, failing the requests
+	// that weren't recorded.
+	ModeReplay Mode = iota
+
+	// ModeRecord sends the requests to Shopify and records them to the
+	// cassette, written by Save.
+	ModeRecord
+)
+
+// scrubbedValue replaces the secrets and personal data in cassettes.
+const scrubbedValue = "[SCRUBBED]"
+
+// secretFields are the JSON fields and query parameters always scrubbed.
+var secretFields = []string{"access_token", "client_secret", "password", "password_confirmation"}
+
+// scrubbedHeaders are the headers never written to cassettes.
+var scrubbedHeaders = []string{"X-Shopify-Access-Token", "Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// Cassette is the file of the requests recorded by a Recorder, written as
+// indented JSON to be reviewed and committed along with the tests.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a request of a cassette.
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is a response of a cassette.
+type RecordedResponse struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// UnmatchedRequestError is returned by a replaying Recorder for a request
+// missing from its cassette.
+type UnmatchedRequestError struct {
+	Cassette string
+	Method   string
+	URL      string
+	Body     string
+}
+
+func (e *UnmatchedRequestError) Error() string {
+	msg := fmt.Sprintf("shopifytest: no interaction of cassette %s matches %s %s", e.Cassette, e.Method, e.URL)
+	if e.Body != "" {
+		msg += " with body " + e.Body
+	}
+	return msg
+}
+
+// Recorder is an http.RoundTripper recording requests to Shopify and their
+// responses to a cassette file, then replaying them in tests:
+//
+//	recorder, err := shopifytest.NewRecorder("testdata/orders.json", shopifytest.ModeReplay)
+//	...
+//	client := synergyshopify.NewClient(app, "shopname", "token",
+//		synergyshopify.WithHTTPClient(recorder.HTTPClient()))
+//
+// Requests are matched on their method, path, query and body, the order of
+// query parameters and JSON keys not mattering. Each recorded interaction is
+// replayed once, in order for identical requests.
+//
+// Access tokens, credentials and the personal data fields of
+// synergyshopify.DefaultRedactedFields are scrubbed from recorded requests
+// and responses, JSON values keeping their type so they still decode.
+type Recorder struct {
+	// Transport sends the requests when recording, http.DefaultTransport
+	// when nil.
+	Transport http.RoundTripper
+
+	// RedactedFields are the JSON fields and query parameters scrubbed, on
+	// top of access tokens, client secrets and passwords.
+	RedactedFields []string
+
+	path string
+	mode Mode
+
+	mu       sync.Mutex
+	cassette Cassette
+	played   []bool
+}
+
+// NewRecorder returns a recorder of the cassette at path. In replay mode the
+// cassette is loaded and must exist, in record mode it is written by Save.
+func NewRecorder(path string, mode Mode) (*Recorder, error) {
+	r := &Recorder{
+		RedactedFields: shopify.DefaultRedactedFields(),
+		path:           path,
+		mode:           mode,
+	}
+
+	if mode == ModeReplay {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("shopifytest: loading cassette: %w", err)
+		}
+		if err := json.Unmarshal(data, &r.cassette); err != nil {
+			return nil, fmt.Errorf("shopifytest: decoding cassette %s: %w", path, err)
+		}
+		r.played = make([]bool, len(r.cassette.Interactions))
+	}
+
+	return r, nil
+}
+
+// HTTPClient returns an http client sending its requests through the
+// recorder, to be passed to synergyshopify.WithHTTPClient.
+func (r *Recorder) HTTPClient() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// RoundTrip records or replays a request.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+	recorded := r.scrubRequest(req, body)
+
+	if r.mode == ModeReplay {
+		return r.replay(req, recorded)
+	}
+
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	if body != nil {
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			Status: resp.StatusCode,
+			Header: scrubHeader(resp.Header),
+			Body:   r.scrubBody(respBody),
+		},
+	})
+
+	return resp, nil
+}
+
+// Save writes the recorded interactions to the cassette, creating its
+// directory if needed. It does nothing in replay mode.
+func (r *Recorder) Save() error {
+	if r.mode == ModeReplay {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(r.cassette); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, buf.Bytes(), 0o644)
+}
+
+// Unplayed returns the interactions of the cassette not replayed yet, to
+// check that a test made every recorded request.
+func (r *Recorder) Unplayed() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var unplayed []Interaction
+	for i, played := range r.played {
+		if !played {
+			unplayed = append(unplayed, r.cassette.Interactions[i])
+		}
+	}
+	return unplayed
+}
+
+func (r *Recorder) replay(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.played[i] || !matchRequest(interaction.Request, recorded) {
+			continue
+		}
+		r.played[i] = true
+
+		resp := interaction.Response
+		header := resp.Header.Clone()
+		if header == nil {
+			header = http.Header{}
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", resp.Status, http.StatusText(resp.Status)),
+			StatusCode:    resp.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(strings.NewReader(resp.Body)),
+			ContentLength: int64(len(resp.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, &UnmatchedRequestError{Cassette: r.path, Method: recorded.Method, URL: recorded.URL, Body: recorded.Body}
+}
+
+// scrubRequest returns the request as written to cassettes.
+func (r *Recorder) scrubRequest(req *http.Request, body []byte) RecordedRequest {
+	u := *req.URL
+	u.User = nil
+	query := u.Query()
+	for k := range query {
+		if r.redacted(k) {
+			for i := range query[k] {
+				query[k][i] = scrubbedValue
+			}
+		}
+	}
+	// Encode sorts the parameters
+	u.RawQuery = query.Encode()
+
+	return RecordedRequest{
+		Method: req.Method,
+		URL:    u.String(),
+		Header: scrubHeader(req.Header),
+		Body:   r.scrubBody(body),
+	}
+}
+
+// scrubBody masks the redacted fields of JSON bodies, which are re-encoded
+// with sorted keys. Other bodies are kept as is.
+func (r *Recorder) scrubBody(body []byte) string {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var v interface{}
+	if len(bytes.TrimSpace(body)) == 0 || decoder.Decode(&v) != nil {
+		return string(body)
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(r.scrubValue(v)); err != nil {
+		return string(body)
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// scrubValue masks the redacted fields of a decoded JSON value, keeping the
+// type of scalars so that the body still decodes into the same structs.
+func (r *Recorder) scrubValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, value := range v {
+			if r.redacted(k) {
+				v[k] = scrubScalar(value)
+				continue
+			}
+			v[k] = r.scrubValue(value)
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = r.scrubValue(value)
+		}
+	}
+	return v
+}
+
+func (r *Recorder) redacted(field string) bool {
+	for _, secret := range secretFields {
+		if field == secret {
+			return true
+		}
+	}
+	for _, redacted := range r.RedactedFields {
+		if strings.EqualFold(field, redacted) {
+			return true
+		}
+	}
+	return false
+}
+
+func scrubScalar(v interface{}) interface{} {
+	switch v.(type) {
+	case string:
+		return scrubbedValue
+	case json.Number:
+		return json.Number("0")
+	case map[string]interface{}, []interface{}:
+		return nil
+	}
+	return v
+}
+
+func scrubHeader(header http.Header) http.Header {
+	if len(header) == 0 {
+		return nil
+	}
+
+	// the length changes along with scrubbed bodies
+	scrubbed := header.Clone()
+	scrubbed.Del("Content-Length")
+	for _, name := range scrubbedHeaders {
+		if scrubbed.Get(name) != "" {
+			scrubbed.Set(name, scrubbedValue)
+		}
+	}
+	return scrubbed
+}
+
+// matchRequest reports whether a request matches a recorded one, the host
+// and headers being ignored.
+func matchRequest(recorded, req RecordedRequest) bool {
+	if recorded.Method != req.Method || recorded.Body != req.Body {
+		return false
+	}
+
+	u1, err1 := url.Parse(recorded.URL)
+	u2, err2 := url.Parse(req.URL)
+	if err1 != nil || err2 != nil {
+		return recorded.URL == req.URL
+	}
+	return u1.Path == u2.Path && u1.Query().Encode() == u2.Query().Encode()
+}
+
+// readBody reads and closes the body of a request.
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	defer req.Body.Close()
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("shopifytest: reading request body: %w", err)
+	}
+	return body, nil
+}
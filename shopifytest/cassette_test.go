@@ -0,0 +1,90 @@
+package shopifytest
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	shopify "github.com/binodsynergytechs/synergyshopify"
+)
+
+func TestRecorder(t *testing.T) {
+	server := NewServer()
+	path := filepath.Join(t.TempDir(), "cassettes", "customers.json")
+
+	recorder, err := NewRecorder(path, ModeRecord)
+	if err != nil {
+		t.Fatalf("NewRecorder returned error: %v", err)
+	}
+	recorder.Transport = server.HTTPClient().Transport
+
+	client := shopify.NewClient(shopify.App{}, ShopName, Token, shopify.WithVersion(APIVersion),
+		shopify.WithHTTPClient(recorder.HTTPClient()))
+	customer, err := client.Customer.Create(shopify.Customer{Email: "jane@example.com", FirstName: "Jane", Tags: "vip"})
+	if err != nil {
+		t.Fatalf("Customer.Create returned error: %v", err)
+	}
+	if customer.Email != "jane@example.com" {
+		t.Errorf("expected the recorded response to be passed along unscrubbed, got %+v", customer)
+	}
+	if _, err := client.Customer.List(shopify.ListOptions{Limit: 10, Fields: "id,email,tags"}); err != nil {
+		t.Fatalf("Customer.List returned error: %v", err)
+	}
+
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("Recorder.Save returned error: %v", err)
+	}
+	server.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{Token, "jane@example.com", "Jane"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("expected %q to be scrubbed from the cassette", secret)
+		}
+	}
+
+	replayer, err := NewRecorder(path, ModeReplay)
+	if err != nil {
+		t.Fatalf("NewRecorder returned error: %v", err)
+	}
+	client = shopify.NewClient(shopify.App{}, ShopName, "another-token", shopify.WithVersion(APIVersion),
+		shopify.WithHTTPClient(replayer.HTTPClient()))
+
+	// the order of the query parameters doesn't matter
+	customers, err := client.Customer.List(struct {
+		Fields string `url:"fields"`
+		Limit  int    `url:"limit"`
+	}{"id,email,tags", 10})
+	if err != nil {
+		t.Fatalf("Customer.List returned error on replay: %v", err)
+	}
+	if len(customers) != 1 || customers[0].Tags != "vip" || customers[0].Email != scrubbedValue {
+		t.Errorf("unexpected replayed customers %+v", customers)
+	}
+
+	if unplayed := replayer.Unplayed(); len(unplayed) != 1 || unplayed[0].Request.Method != "POST" {
+		t.Errorf("expected the creation to be left unplayed, got %+v", unplayed)
+	}
+
+	// the scrubbed body of the creation matches
+	if _, err := client.Customer.Create(shopify.Customer{Email: "john@example.com", FirstName: "John", Tags: "vip"}); err != nil {
+		t.Errorf("Customer.Create returned error on replay: %v", err)
+	}
+
+	_, err = client.Customer.Create(shopify.Customer{Tags: "other"})
+	var unmatched *UnmatchedRequestError
+	if !errors.As(err, &unmatched) || unmatched.Method != "POST" {
+		t.Errorf("expected an UnmatchedRequestError, got %v", err)
+	}
+}
+
+func TestRecorderMissingCassette(t *testing.T) {
+	if _, err := NewRecorder(filepath.Join(t.TempDir(), "missing.json"), ModeReplay); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected a missing cassette to fail, got %v", err)
+	}
+}
@@ -0,0 +1,484 @@
+package shopifytest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultLimit = 50
+	maxLimit     = 250
+)
+
+// ignoredFilters are list parameters that don't filter resources by a field.
+var ignoredFilters = map[string]bool{
+	"limit": true, "page_info": true, "fields": true, "order": true, "published_status": true,
+}
+
+// scope restricts the resources of a nested route, e.g. the variants of a
+// product, to the ones with the given field values.
+type scope map[string]interface{}
+
+func (sc scope) matches(o object) bool {
+	for k, v := range sc {
+		if fmt.Sprint(o[k]) != fmt.Sprint(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path, Query: r.URL.Query(), Body: string(body)})
+	s.requestID++
+	w.Header().Set("X-Request-Id", fmt.Sprintf("fake-%d", s.requestID))
+
+	version, segments, ok := parsePath(r.URL.Path)
+	if version != "" {
+		w.Header().Set("X-Shopify-API-Version", version)
+	}
+
+	if r.Header.Get("X-Shopify-Access-Token") != Token {
+		writeJSON(w, http.StatusUnauthorized, object{"errors": "[API] Invalid API key or access token (unrecognized login or wrong password)"})
+		return
+	}
+
+	if !s.callLimit() {
+		w.Header().Set("Retry-After", "2.0")
+		writeJSON(w, http.StatusTooManyRequests, object{"errors": "Exceeded 2 calls per second for api client. Reduce request rates to resume uninterrupted service."})
+		return
+	}
+	w.Header().Set("X-Shopify-Shop-Api-Call-Limit", callLimitHeader(s.fill))
+
+	if f := s.fault(r); f != nil {
+		s.writeFault(w, f)
+		return
+	}
+
+	if !ok {
+		writeError(w, notFound())
+		return
+	}
+
+	status, v, err := s.route(w, r, segments, body)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, status, v)
+}
+
+// parsePath splits an admin api path, e.g. /admin/api/2024-01/products/1.json,
+// into its version and segments.
+func parsePath(p string) (string, []string, bool) {
+	if !strings.HasPrefix(p, "/admin/") || !strings.HasSuffix(p, ".json") {
+		return "", nil, false
+	}
+	segments := strings.Split(strings.TrimSuffix(strings.TrimPrefix(p, "/admin/"), ".json"), "/")
+
+	version := ""
+	if segments[0] == "api" && len(segments) > 2 {
+		version, segments = segments[1], segments[2:]
+	}
+
+	return version, segments, true
+}
+
+func (s *Server) route(w http.ResponseWriter, r *http.Request, segments []string, body []byte) (int, interface{}, *apiError) {
+	if segments[0] == "inventory_levels" {
+		return s.inventoryLevels(w, r, segments[1:], body)
+	}
+
+	// collections/{id}.json and collections/{id}/products.json
+	if segments[0] == "collections" && len(segments) > 1 && idOf(segments[1]) != 0 && r.Method == http.MethodGet {
+		collectionID := idOf(segments[1])
+		collection, ok := s.store.get("collections", collectionID)
+		switch {
+		case !ok:
+			return 0, nil, notFound()
+		case len(segments) == 2:
+			return http.StatusOK, object{"collection": collection}, nil
+		case len(segments) == 3 && segments[2] == "products":
+			return s.collectionProducts(w, r, collectionID)
+		}
+	}
+
+	resource, rest := segments[0], segments[1:]
+	sc := scope{}
+	if len(rest) >= 2 && idOf(rest[0]) != 0 {
+		parent, parentID := resource, idOf(rest[0])
+		resource, rest = rest[1], rest[2:]
+
+		switch key, ok := children[parent][resource]; {
+		case ok:
+			sc[key] = parentID
+		case resource == "metafields":
+			sc["owner_resource"], sc["owner_id"] = singulars[parent], parentID
+		default:
+			return 0, nil, notFound()
+		}
+
+		if _, known := singulars[parent]; known {
+			if _, ok := s.store.get(parent, parentID); !ok {
+				return 0, nil, notFound()
+			}
+		}
+	} else if resource == "metafields" {
+		sc["owner_resource"] = "shop"
+	}
+
+	singular, ok := singulars[resource]
+	if !ok || len(rest) > 1 {
+		return 0, nil, notFound()
+	}
+
+	switch {
+	case len(rest) == 0 && r.Method == http.MethodGet:
+		return s.list(w, r, resource, sc)
+
+	case len(rest) == 0 && r.Method == http.MethodPost:
+		o, err := envelope(singular, body)
+		if err != nil {
+			return 0, nil, err
+		}
+		for k, v := range sc {
+			o[k] = v
+		}
+		created, err := s.store.create(resource, o)
+		if err != nil {
+			return 0, nil, err
+		}
+		return http.StatusCreated, object{singular: s.store.render(resource, created)}, nil
+
+	case len(rest) == 0:
+		// only lists and creations are done on a collection
+		return 0, nil, &apiError{status: http.StatusMethodNotAllowed, errors: "Method Not Allowed"}
+
+	case len(rest) > 0 && rest[0] == "count" && r.Method == http.MethodGet:
+		return http.StatusOK, object{"count": len(s.filter(resource, sc, r.URL.Query()))}, nil
+	}
+
+	id := idOf(rest[0])
+	o, found := s.store.get(resource, id)
+	if !found || !sc.matches(o) {
+		return 0, nil, notFound()
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		return http.StatusOK, object{singular: selectFields(s.store.render(resource, o), r.URL.Query().Get("fields"))}, nil
+
+	case http.MethodPut:
+		changes, err := envelope(singular, body)
+		if err != nil {
+			return 0, nil, err
+		}
+		for k := range sc {
+			delete(changes, k)
+		}
+		updated, err := s.store.update(resource, id, changes)
+		if err != nil {
+			return 0, nil, err
+		}
+		return http.StatusOK, object{singular: s.store.render(resource, updated)}, nil
+
+	case http.MethodDelete:
+		if err := s.store.delete(resource, id); err != nil {
+			return 0, nil, err
+		}
+		return http.StatusOK, object{}, nil
+	}
+
+	return 0, nil, &apiError{status: http.StatusMethodNotAllowed, errors: "Method Not Allowed"}
+}
+
+// collectionProducts lists the products of a custom collection, through its
+// collects.
+func (s *Server) collectionProducts(w http.ResponseWriter, r *http.Request, collectionID int64) (int, interface{}, *apiError) {
+	ids := []string{}
+	for _, collect := range s.store.table("collects").sorted() {
+		if idOf(collect["collection_id"]) == collectionID {
+			ids = append(ids, fmt.Sprint(collect["product_id"]))
+		}
+	}
+	if len(ids) == 0 {
+		return http.StatusOK, object{"products": []interface{}{}}, nil
+	}
+
+	query := r.URL.Query()
+	if query.Get("page_info") == "" {
+		query.Set("ids", strings.Join(ids, ","))
+		r.URL.RawQuery = query.Encode()
+	}
+	return s.list(w, r, "products", scope{})
+}
+
+func (s *Server) inventoryLevels(w http.ResponseWriter, r *http.Request, rest []string, body []byte) (int, interface{}, *apiError) {
+	query := r.URL.Query()
+
+	switch {
+	case len(rest) == 0 && r.Method == http.MethodGet:
+		if query.Get("inventory_item_ids") == "" && query.Get("location_ids") == "" && query.Get("page_info") == "" {
+			return 0, nil, &apiError{status: http.StatusUnprocessableEntity, errors: "inventory_item_ids or location_ids must be present"}
+		}
+		return s.list(w, r, "inventory_levels", scope{})
+
+	case len(rest) == 0 && r.Method == http.MethodDelete:
+		if !s.store.deleteInventoryLevel(idOf(query.Get("inventory_item_id")), idOf(query.Get("location_id"))) {
+			return 0, nil, notFound()
+		}
+		return http.StatusNoContent, nil, nil
+
+	case len(rest) == 1 && r.Method == http.MethodPost:
+		params, err := toObject(body)
+		if err != nil {
+			return 0, nil, &apiError{status: http.StatusBadRequest, errors: err.Error()}
+		}
+		itemID, locationID := idOf(params["inventory_item_id"]), idOf(params["location_id"])
+		if itemID == 0 || locationID == 0 {
+			return 0, nil, &apiError{status: http.StatusBadRequest, errors: object{"inventory_item_id": "Required parameter missing or invalid"}}
+		}
+
+		var level object
+		switch rest[0] {
+		case "connect":
+			level, _ = s.store.inventoryLevel(itemID, locationID, true)
+		case "set":
+			level, _ = s.store.inventoryLevel(itemID, locationID, true)
+			level["available"] = idOf(params["available"])
+		case "adjust":
+			var ok bool
+			if level, ok = s.store.inventoryLevel(itemID, locationID, false); !ok {
+				return 0, nil, notFound()
+			}
+			level["available"] = idOf(level["available"]) + idOf(params["available_adjustment"])
+		default:
+			return 0, nil, notFound()
+		}
+		level["updated_at"] = timestamp()
+
+		return http.StatusOK, object{"inventory_level": level}, nil
+	}
+
+	return 0, nil, notFound()
+}
+
+// cursor is the decoded page_info parameter of paginated lists.
+type cursor struct {
+	Query  string `json:"query"`
+	After  int64  `json:"after,omitempty"`
+	Before int64  `json:"before,omitempty"`
+}
+
+func (c cursor) encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(pageInfo string) (cursor, bool) {
+	var c cursor
+	data, err := base64.RawURLEncoding.DecodeString(pageInfo)
+	if err != nil || json.Unmarshal(data, &c) != nil {
+		return c, false
+	}
+	return c, true
+}
+
+// list writes a page of resources, with the Link header of the previous and
+// next pages.
+func (s *Server) list(w http.ResponseWriter, r *http.Request, resource string, sc scope) (int, interface{}, *apiError) {
+	query := r.URL.Query()
+
+	limit := defaultLimit
+	if l := query.Get("limit"); l != "" {
+		var err error
+		if limit, err = strconv.Atoi(l); err != nil || limit < 1 || limit > maxLimit {
+			return 0, nil, &apiError{status: http.StatusBadRequest, errors: object{"limit": "Invalid limit"}}
+		}
+	}
+
+	var c cursor
+	filters, _ := url.ParseQuery(r.URL.RawQuery)
+	if pageInfo := query.Get("page_info"); pageInfo != "" {
+		var ok bool
+		if c, ok = decodeCursor(pageInfo); !ok {
+			return 0, nil, &apiError{status: http.StatusBadRequest, errors: object{"page_info": "Invalid value."}}
+		}
+		filters, _ = url.ParseQuery(c.Query)
+	}
+
+	objects := s.filter(resource, sc, filters)
+	start, end := 0, len(objects)
+	for i, o := range objects {
+		id := idOf(o["id"])
+		if c.After != 0 && id <= c.After {
+			start = i + 1
+		}
+		if c.Before != 0 && id >= c.Before && i < end {
+			end = i
+		}
+	}
+	if c.Before != 0 && end-start > limit {
+		start = end - limit
+	} else if end-start > limit {
+		end = start + limit
+	}
+	page := objects[start:end]
+
+	// the filters are carried by the cursors, as with Shopify
+	for _, k := range []string{"limit", "page_info", "fields"} {
+		filters.Del(k)
+	}
+	var links []string
+	if start > 0 && len(page) > 0 {
+		links = append(links, pageLink(r, query, cursor{Query: filters.Encode(), Before: idOf(page[0]["id"])}, "previous"))
+	}
+	if end < len(objects) && len(page) > 0 {
+		links = append(links, pageLink(r, query, cursor{Query: filters.Encode(), After: idOf(page[len(page)-1]["id"])}, "next"))
+	}
+	if len(links) > 0 {
+		w.Header().Set("Link", strings.Join(links, ", "))
+	}
+
+	rendered := make([]interface{}, len(page))
+	for i, o := range page {
+		rendered[i] = selectFields(s.store.render(resource, o), query.Get("fields"))
+	}
+	return http.StatusOK, object{resource: rendered}, nil
+}
+
+func pageLink(r *http.Request, query url.Values, c cursor, rel string) string {
+	params := url.Values{"page_info": {c.encode()}}
+	for _, k := range []string{"limit", "fields"} {
+		if v := query.Get(k); v != "" {
+			params.Set(k, v)
+		}
+	}
+
+	u := url.URL{Scheme: "https", Host: r.Host, Path: r.URL.Path, RawQuery: params.Encode()}
+	return fmt.Sprintf(`<%s>; rel="%s"`, u.String(), rel)
+}
+
+// filter returns the resources in scope matching the list parameters, by
+// ascending id.
+func (s *Server) filter(resource string, sc scope, query url.Values) []object {
+	var objects []object
+	for _, o := range s.store.table(resource).sorted() {
+		if sc.matches(o) && matches(o, query) {
+			objects = append(objects, o)
+		}
+	}
+	return objects
+}
+
+// matches reports whether a resource matches the list parameters: since_id,
+// ids and other *_ids lists, created_at and updated_at bounds, and equality
+// on the other fields. A status of "any" matches every resource.
+func matches(o object, query url.Values) bool {
+	for k, values := range query {
+		v := values[0]
+		switch {
+		case ignoredFilters[k]:
+			continue
+
+		case k == "since_id":
+			if idOf(o["id"]) <= idOf(v) {
+				return false
+			}
+
+		case strings.HasSuffix(k, "_min") || strings.HasSuffix(k, "_max"):
+			field := strings.TrimSuffix(strings.TrimSuffix(k, "_min"), "_max")
+			bound, err1 := time.Parse(time.RFC3339, v)
+			at, err2 := time.Parse(time.RFC3339, fmt.Sprint(o[field]))
+			if err1 != nil || err2 != nil {
+				continue
+			}
+			if strings.HasSuffix(k, "_min") && at.Before(bound) || strings.HasSuffix(k, "_max") && at.After(bound) {
+				return false
+			}
+
+		case k == "ids" || strings.HasSuffix(k, "_ids"):
+			field := strings.TrimSuffix(k, "s")
+			if !contains(strings.Split(v, ","), fmt.Sprint(o[field])) {
+				return false
+			}
+
+		case v == "any":
+			continue
+
+		default:
+			if fmt.Sprint(o[k]) != v {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if strings.TrimSpace(value) == v {
+			return true
+		}
+	}
+	return false
+}
+
+// selectFields keeps the comma separated fields of a resource, if any.
+func selectFields(o object, fields string) object {
+	if fields == "" {
+		return o
+	}
+
+	selected := object{}
+	for _, field := range strings.Split(fields, ",") {
+		field = strings.TrimSpace(field)
+		if v, ok := o[field]; ok {
+			selected[field] = v
+		}
+	}
+	return selected
+}
+
+// envelope returns the resource wrapped in a request body, e.g. the product
+// of {"product": {...}}.
+func envelope(singular string, body []byte) (object, *apiError) {
+	missing := &apiError{status: http.StatusBadRequest, errors: object{singular: "Required parameter missing or invalid"}}
+
+	params, err := toObject(body)
+	if err != nil {
+		return nil, missing
+	}
+	o, ok := params[singular].(object)
+	if !ok {
+		return nil, missing
+	}
+	return o, nil
+}
+
+func writeError(w http.ResponseWriter, err *apiError) {
+	writeJSON(w, err.status, object{"errors": err.errors})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	if status == http.StatusNoContent {
+		w.WriteHeader(status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
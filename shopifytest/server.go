@@ -0,0 +1,264 @@
+// Package shopifytest provides an in-memory fake of the Shopify admin REST API
+// to test code using synergyshopify end to end, e.g.
+//
+//	server := shopifytest.NewServer()
+//	defer server.Close()
+//
+//	client := server.Client()
+//	product, _ := client.Product.Create(synergyshopify.Product{Title: "Shirt"})
+//	products, _ := client.Product.List(nil) // includes the shirt
+//
+// The server keeps the resources it is sent in memory, paginates lists with
+// Link headers, reports the X-Shopify-Shop-Api-Call-Limit header and can be
+// told to fail requests with InjectFault.
+package shopifytest
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	shopify "github.com/binodsynergytechs/synergyshopify"
+)
+
+const (
+	// ShopName is the name of the shop of the clients returned by Server.Client
+	ShopName = "fakeshop"
+
+	// Token is the access token expected by the server
+	Token = "shpat_fake"
+
+	// APIVersion is the version of the api used by the clients returned by
+	// Server.Client
+	APIVersion = "2024-01"
+
+	// BucketSize and LeakRate describe the call limit reported by the server
+	BucketSize = 40
+	LeakRate   = 2.0
+)
+
+// Fault makes the server answer matching requests with an error instead of
+// handling them, see Server.InjectFault.
+type Fault struct {
+	// Status of the response, e.g. 429 or 503.
+	Status int
+
+	// Method and Path restrict the requests failing, Path matching any
+	// request whose path contains it. Empty values match every request.
+	Method string
+	Path   string
+
+	// Times is the number of matching requests failing, 0 for all of them.
+	Times int
+
+	// RetryAfter is the Retry-After header of the response, "2.0" by default
+	// for 429 responses.
+	RetryAfter string
+
+	// Body of the response, a Shopify error with the status text by default.
+	Body string
+}
+
+// Request is a request received by the server, see Server.Requests.
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Body   string
+}
+
+// Server is a fake Shopify admin API backed by an httptest.Server. It is safe
+// for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	// EnforceCallLimit rejects requests with a 429 once the call limit bucket
+	// is full, instead of only reporting its fill.
+	EnforceCallLimit bool
+
+	mu        sync.Mutex
+	store     *store
+	faults    []*Fault
+	requests  []Request
+	fill      float64
+	leakedAt  time.Time
+	requestID int64
+}
+
+// NewServer starts a fake admin API. It must be closed after use.
+func NewServer() *Server {
+	s := &Server{store: newStore(), leakedAt: time.Now()}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// HTTPClient returns an http client sending every request to the server,
+// whatever the shop it is addressed to.
+func (s *Server) HTTPClient() *http.Client {
+	target, _ := url.Parse(s.URL)
+	return &http.Client{Transport: &rewriteTransport{target: target}}
+}
+
+// Client returns a client of the fake shop connected to the server, with the
+// given options applied after the ones wiring it.
+func (s *Server) Client(opts ...shopify.Option) *shopify.Client {
+	opts = append([]shopify.Option{
+		shopify.WithVersion(APIVersion),
+		shopify.WithHTTPClient(s.HTTPClient()),
+	}, opts...)
+
+	return shopify.NewClient(shopify.App{}, ShopName, Token, opts...)
+}
+
+// InjectFault makes the server fail the requests matching f.
+func (s *Server) InjectFault(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = append(s.faults, &f)
+}
+
+// ClearFaults removes the faults injected.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = nil
+}
+
+// Requests returns the requests received so far, in order.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Request(nil), s.requests...)
+}
+
+// Seed adds a resource, e.g. Seed("products", synergyshopify.Product{...}),
+// as if it was created through the api, and returns its id. The resource is
+// the name of its endpoint, e.g. "products" or "custom_collections".
+func (s *Server) Seed(resource string, v interface{}) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	object, err := toObject(v)
+	if err != nil {
+		return 0, err
+	}
+
+	created, apiErr := s.store.create(resource, object)
+	if apiErr != nil {
+		return 0, apiErr
+	}
+	return idOf(created["id"]), nil
+}
+
+// Get returns the stored resource with the given id as decoded JSON.
+func (s *Server) Get(resource string, id int64) (map[string]interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	object, ok := s.store.get(resource, id)
+	if !ok {
+		return nil, false
+	}
+	return s.store.render(resource, object), true
+}
+
+// Count returns the number of stored resources.
+func (s *Server) Count(resource string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.store.table(resource).objects)
+}
+
+// Reset removes every resource, fault and recorded request.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.store = newStore()
+	s.faults = nil
+	s.requests = nil
+	s.fill = 0
+}
+
+// callLimit leaks the bucket and takes a slot for a request, reporting
+// whether the request fits.
+func (s *Server) callLimit() bool {
+	now := time.Now()
+	s.fill = math.Max(0, s.fill-LeakRate*now.Sub(s.leakedAt).Seconds())
+	s.leakedAt = now
+
+	if s.EnforceCallLimit && s.fill+1 > BucketSize {
+		return false
+	}
+	s.fill++
+	return true
+}
+
+// fault returns the fault matching r, if any, and counts its use.
+func (s *Server) fault(r *http.Request) *Fault {
+	for i, f := range s.faults {
+		if f.Method != "" && f.Method != r.Method {
+			continue
+		}
+		if f.Path != "" && !strings.Contains(r.URL.Path, f.Path) {
+			continue
+		}
+
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+		}
+		return f
+	}
+
+	return nil
+}
+
+func (s *Server) writeFault(w http.ResponseWriter, f *Fault) {
+	retryAfter := f.RetryAfter
+	if retryAfter == "" && f.Status == http.StatusTooManyRequests {
+		retryAfter = "2.0"
+	}
+	if retryAfter != "" {
+		w.Header().Set("Retry-After", retryAfter)
+	}
+
+	body := f.Body
+	if body == "" {
+		body = fmt.Sprintf(`{"errors":%q}`, http.StatusText(f.Status))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(f.Status)
+	fmt.Fprint(w, body)
+}
+
+// rewriteTransport sends requests to the server instead of the shop.
+type rewriteTransport struct {
+	target *url.URL
+}
+
+func (t *rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	req.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// callLimitHeader formats the X-Shopify-Shop-Api-Call-Limit header.
+func callLimitHeader(fill float64) string {
+	return strconv.Itoa(int(math.Ceil(fill))) + "/" + strconv.Itoa(BucketSize)
+}
@@ -0,0 +1,345 @@
+package shopifytest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	shopify "github.com/binodsynergytechs/synergyshopify"
+)
+
+func TestProductLifecycle(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	product, err := client.Product.Create(shopify.Product{
+		Title:    "Shirt",
+		Vendor:   "Acme",
+		Variants: []shopify.Variant{{Title: "Small", Sku: "S"}, {Title: "Large", Sku: "L"}},
+	})
+	if err != nil {
+		t.Fatalf("Product.Create returned error: %v", err)
+	}
+	if product.ID == 0 || product.Handle != "shirt" || len(product.Variants) != 2 {
+		t.Fatalf("Product.Create returned %+v", product)
+	}
+	if product.Variants[0].ProductID != product.ID || product.Variants[0].InventoryItemId == 0 {
+		t.Errorf("expected the variants to belong to the product, got %+v", product.Variants[0])
+	}
+
+	products, err := client.Product.List(nil)
+	if err != nil || len(products) != 1 || products[0].Title != "Shirt" {
+		t.Errorf("Product.List returned %+v, %v", products, err)
+	}
+
+	variants, err := client.Variant.List(product.ID, nil)
+	if err != nil || len(variants) != 2 {
+		t.Errorf("Variant.List returned %+v, %v", variants, err)
+	}
+
+	updated, err := client.Product.Update(shopify.Product{ID: product.ID, Title: "T-Shirt"})
+	if err != nil || updated.Title != "T-Shirt" || updated.Vendor != "Acme" {
+		t.Errorf("Product.Update returned %+v, %v", updated, err)
+	}
+
+	if err := client.Product.Delete(product.ID); err != nil {
+		t.Fatalf("Product.Delete returned error: %v", err)
+	}
+	if _, err := client.Product.Get(product.ID, nil); !errors.Is(err, shopify.ErrNotFound) {
+		t.Errorf("Product.Get returned %v after delete, expected %v", err, shopify.ErrNotFound)
+	}
+	if count := server.Count("variants"); count != 0 {
+		t.Errorf("expected the variants to be deleted with the product, %d left", count)
+	}
+}
+
+func TestDefaultVariant(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	id, err := server.Seed("products", shopify.Product{Title: "Mug"})
+	if err != nil {
+		t.Fatalf("Seed returned error: %v", err)
+	}
+
+	product, err := server.Client().Product.Get(id, nil)
+	if err != nil || len(product.Variants) != 1 || product.Variants[0].Title != "Default Title" {
+		t.Errorf("expected a default variant, got %+v, %v", product, err)
+	}
+}
+
+func TestPagination(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	for i := 0; i < 5; i++ {
+		vendor := "Acme"
+		if i == 2 {
+			vendor = "Other"
+		}
+		if _, err := server.Seed("products", shopify.Product{Title: fmt.Sprintf("Product %d", i), Vendor: vendor}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	client := server.Client()
+	page, pagination, err := client.Product.ListWithPagination(shopify.ProductListOptions{
+		ListOptions: shopify.ListOptions{Limit: 2},
+		Vendor:      "Acme",
+	})
+	if err != nil {
+		t.Fatalf("Product.ListWithPagination returned error: %v", err)
+	}
+	if len(page) != 2 || pagination.NextPageOptions == nil || pagination.PreviousPageOptions != nil {
+		t.Fatalf("unexpected first page %+v, %+v", page, pagination)
+	}
+
+	next, pagination, err := client.Product.ListWithPagination(pagination.NextPageOptions)
+	if err != nil {
+		t.Fatalf("Product.ListWithPagination returned error: %v", err)
+	}
+	if len(next) != 2 || next[0].Title != "Product 3" || next[1].Title != "Product 4" {
+		t.Errorf("expected the filter to be kept by the cursor, got %+v", next)
+	}
+	if pagination.NextPageOptions != nil || pagination.PreviousPageOptions == nil {
+		t.Errorf("unexpected last page pagination %+v", pagination)
+	}
+
+	all, err := shopify.Iterate(client.Product.ListWithPagination, shopify.ListOptions{Limit: 2}).All()
+	if err != nil || len(all) != 5 {
+		t.Errorf("Iterate returned %d products, %v", len(all), err)
+	}
+
+	count, err := client.Product.Count(shopify.ProductListOptions{Vendor: "Other"})
+	if err != nil || count != 1 {
+		t.Errorf("Product.Count returned %d, %v", count, err)
+	}
+}
+
+func TestCollectionProducts(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	collection, err := client.CustomCollection.Create(shopify.CustomCollection{Title: "Summer"})
+	if err != nil {
+		t.Fatalf("CustomCollection.Create returned error: %v", err)
+	}
+	productID, _ := server.Seed("products", shopify.Product{Title: "Hat"})
+	server.Seed("products", shopify.Product{Title: "Scarf"})
+
+	if _, err := client.Collect.Create(shopify.Collect{CollectionID: collection.ID, ProductID: productID}); err != nil {
+		t.Fatalf("Collect.Create returned error: %v", err)
+	}
+
+	c, err := client.Collection.Get(collection.ID, nil)
+	if err != nil || c.Title != "Summer" {
+		t.Errorf("Collection.Get returned %+v, %v", c, err)
+	}
+
+	products, err := client.Collection.ListProducts(collection.ID, nil)
+	if err != nil || len(products) != 1 || products[0].Title != "Hat" {
+		t.Errorf("Collection.ListProducts returned %+v, %v", products, err)
+	}
+}
+
+func TestMetafields(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	productID, _ := server.Seed("products", shopify.Product{Title: "Hat"})
+	metafield, err := client.Product.CreateMetafield(productID, shopify.Metafield{Namespace: "custom", Key: "size", Value: "M", Type: "single_line_text_field"})
+	if err != nil {
+		t.Fatalf("Product.CreateMetafield returned error: %v", err)
+	}
+	if metafield.OwnerId != productID || metafield.OwnerResource != "product" {
+		t.Errorf("unexpected owner of %+v", metafield)
+	}
+
+	if _, err := client.Metafield.Create(shopify.Metafield{Namespace: "custom", Key: "motto", Value: "hi", Type: "single_line_text_field"}); err != nil {
+		t.Fatalf("Metafield.Create returned error: %v", err)
+	}
+
+	count, err := client.Product.CountMetafields(productID, nil)
+	if err != nil || count != 1 {
+		t.Errorf("Product.CountMetafields returned %d, %v", count, err)
+	}
+
+	shopMetafields, err := client.Metafield.List(nil)
+	if err != nil || len(shopMetafields) != 1 || shopMetafields[0].Key != "motto" {
+		t.Errorf("Metafield.List returned %+v, %v", shopMetafields, err)
+	}
+
+	if _, err := client.Product.CreateMetafield(12345, shopify.Metafield{Namespace: "custom", Key: "size"}); !errors.Is(err, shopify.ErrNotFound) {
+		t.Errorf("expected %v for a missing product, got %v", shopify.ErrNotFound, err)
+	}
+}
+
+func TestInventoryLevels(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	level, err := client.InventoryLevel.Set(shopify.InventoryLevel{InventoryItemId: 1, LocationId: 2, Available: 10})
+	if err != nil || level.Available != 10 {
+		t.Fatalf("InventoryLevel.Set returned %+v, %v", level, err)
+	}
+
+	level, err = client.InventoryLevel.Adjust(shopify.InventoryLevelAdjustOptions{InventoryItemId: 1, LocationId: 2, Adjust: -3})
+	if err != nil || level.Available != 7 {
+		t.Errorf("InventoryLevel.Adjust returned %+v, %v", level, err)
+	}
+
+	levels, err := client.InventoryLevel.List(struct {
+		InventoryItemIds string `url:"inventory_item_ids"`
+	}{"1,5"})
+	if err != nil || len(levels) != 1 || levels[0].LocationId != 2 {
+		t.Errorf("InventoryLevel.List returned %+v, %v", levels, err)
+	}
+
+	if err := client.InventoryLevel.Delete(1, 2); err != nil {
+		t.Errorf("InventoryLevel.Delete returned error: %v", err)
+	}
+	if server.Count("inventory_levels") != 0 {
+		t.Error("expected the inventory level to be deleted")
+	}
+}
+
+func TestValidation(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	_, err := server.Client().Webhook.Create(shopify.Webhook{Topic: "orders/create"})
+
+	var validationErr shopify.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected a ValidationError, got %#v", err)
+	}
+	if messages := validationErr.Fields["address"]; len(messages) != 1 || messages[0] != "can't be blank" {
+		t.Errorf("unexpected validation errors %v", validationErr.Fields)
+	}
+}
+
+func TestRejectedUpdate(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	product, err := client.Product.Create(shopify.Product{Title: "Shirt", Variants: []shopify.Variant{{Sku: "S"}}})
+	if err != nil {
+		t.Fatalf("Product.Create returned error: %v", err)
+	}
+	path := fmt.Sprintf("products/%d.json", product.ID)
+
+	err = client.Put(path, map[string]interface{}{"product": map[string]interface{}{"title": "", "vendor": "Acme"}}, nil)
+	if !errors.Is(err, shopify.ErrValidation) {
+		t.Errorf("expected a validation error, got %v", err)
+	}
+
+	variant := map[string]interface{}{"id": product.Variants[0].ID, "sku": "M"}
+	err = client.Put(path, map[string]interface{}{"product": map[string]interface{}{"title": "Hat", "variants": []interface{}{variant, "invalid"}}}, nil)
+	if !errors.Is(err, shopify.ErrValidation) {
+		t.Errorf("expected a validation error of the variants, got %v", err)
+	}
+
+	unchanged, err := client.Product.Get(product.ID, nil)
+	if err != nil || unchanged.Title != "Shirt" || unchanged.Vendor != "" || unchanged.Variants[0].Sku != "S" {
+		t.Errorf("expected the rejected updates to change nothing, got %+v, %v", unchanged, err)
+	}
+}
+
+func TestCollectionWrites(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	if err := client.Put("products.json", map[string]interface{}{"product": map[string]interface{}{"title": "Shirt"}}, nil); errorStatus(err) != http.StatusMethodNotAllowed {
+		t.Errorf("PUT on a collection returned %v, expected 405", err)
+	}
+	if err := client.Delete("products.json"); errorStatus(err) != http.StatusMethodNotAllowed {
+		t.Errorf("DELETE on a collection returned %v, expected 405", err)
+	}
+}
+
+// errorStatus returns the status of the response of a client error.
+func errorStatus(err error) int {
+	var responseErr shopify.ResponseError
+	if errors.As(err, &responseErr) {
+		return responseErr.Status
+	}
+	return 0
+}
+
+func TestUnauthorized(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	client := shopify.NewClient(shopify.App{}, ShopName, "wrong", shopify.WithHTTPClient(server.HTTPClient()))
+	if _, err := client.Product.List(nil); !errors.Is(err, shopify.ErrUnauthorized) {
+		t.Errorf("expected %v, got %v", shopify.ErrUnauthorized, err)
+	}
+}
+
+func TestFaults(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	server.InjectFault(Fault{Status: http.StatusServiceUnavailable, Path: "/products", Times: 1})
+	client := server.Client(shopify.WithRetry(2))
+
+	if _, err := client.Product.List(nil); err != nil {
+		t.Errorf("expected the request to succeed once retried, got %v", err)
+	}
+	if requests := server.Requests(); len(requests) != 2 {
+		t.Errorf("expected 2 requests, got %d", len(requests))
+	}
+
+	server.InjectFault(Fault{Status: http.StatusTooManyRequests, Method: http.MethodPost})
+	_, err := server.Client().Product.Create(shopify.Product{Title: "Hat"})
+
+	var rateLimitErr shopify.RateLimitError
+	if !errors.As(err, &rateLimitErr) || rateLimitErr.RetryAfter != 2 {
+		t.Errorf("expected a rate limit error, got %#v", err)
+	}
+
+	server.ClearFaults()
+	if _, err := server.Client().Product.Create(shopify.Product{Title: "Hat"}); err != nil {
+		t.Errorf("expected no error once the faults are cleared, got %v", err)
+	}
+}
+
+func TestCallLimit(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	server.EnforceCallLimit = true
+
+	httpClient := server.HTTPClient()
+	get := func() *http.Response {
+		req, _ := http.NewRequest(http.MethodGet, "https://fakeshop.myshopify.com/admin/api/2024-01/products.json", nil)
+		req.Header.Set("X-Shopify-Access-Token", Token)
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp
+	}
+
+	resp := get()
+	if limit := resp.Header.Get("X-Shopify-Shop-Api-Call-Limit"); limit != "1/40" {
+		t.Errorf("expected a call limit of 1/40, got %q", limit)
+	}
+	if version := resp.Header.Get("X-Shopify-API-Version"); version != APIVersion {
+		t.Errorf("expected the api version header to be %s, got %q", APIVersion, version)
+	}
+
+	for i := 0; i < BucketSize; i++ {
+		resp = get()
+	}
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("expected a 429 once the bucket is full, got %d", resp.StatusCode)
+	}
+}
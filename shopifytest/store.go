@@ -0,0 +1,396 @@
+package shopifytest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// singulars maps the resources served to the key of a single resource in
+// request and response bodies.
+var singulars = map[string]string{
+	"products":           "product",
+	"variants":           "variant",
+	"images":             "image",
+	"orders":             "order",
+	"customers":          "customer",
+	"custom_collections": "custom_collection",
+	"smart_collections":  "smart_collection",
+	"collections":        "collection",
+	"collects":           "collect",
+	"metafields":         "metafield",
+	"webhooks":           "webhook",
+	"inventory_levels":   "inventory_level",
+}
+
+// required lists the fields Shopify refuses to create a resource without.
+var required = map[string][]string{
+	"products":           {"title"},
+	"custom_collections": {"title"},
+	"smart_collections":  {"title"},
+	"collects":           {"product_id", "collection_id"},
+	"metafields":         {"namespace", "key"},
+	"webhooks":           {"topic", "address"},
+}
+
+// children lists the resources embedded in their parent, keyed by the field
+// referencing the parent.
+var children = map[string]map[string]string{
+	"products": {"variants": "product_id", "images": "product_id"},
+}
+
+// apiError is an error response of the server.
+type apiError struct {
+	status int
+	errors interface{}
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%d %s: %v", e.status, http.StatusText(e.status), e.errors)
+}
+
+func notFound() *apiError {
+	return &apiError{status: http.StatusNotFound, errors: "Not Found"}
+}
+
+type object = map[string]interface{}
+
+// table holds the resources of one kind by id.
+type table struct {
+	objects map[int64]object
+}
+
+// sorted returns the resources by ascending id.
+func (t *table) sorted() []object {
+	ids := make([]int64, 0, len(t.objects))
+	for id := range t.objects {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	objects := make([]object, len(ids))
+	for i, id := range ids {
+		objects[i] = t.objects[id]
+	}
+	return objects
+}
+
+// store holds the state of the fake shop.
+type store struct {
+	tables map[string]*table
+	lastID int64
+}
+
+func newStore() *store {
+	return &store{tables: map[string]*table{}, lastID: 1000}
+}
+
+func (s *store) table(resource string) *table {
+	t, ok := s.tables[resource]
+	if !ok {
+		t = &table{objects: map[int64]object{}}
+		s.tables[resource] = t
+	}
+	return t
+}
+
+func (s *store) nextID() int64 {
+	s.lastID++
+	return s.lastID
+}
+
+func (s *store) get(resource string, id int64) (object, bool) {
+	if resource == "collections" {
+		if o, ok := s.get("custom_collections", id); ok {
+			return o, true
+		}
+		return s.get("smart_collections", id)
+	}
+
+	o, ok := s.table(resource).objects[id]
+	return o, ok
+}
+
+// create stores a new resource, splitting off its embedded children.
+func (s *store) create(resource string, o object) (object, *apiError) {
+	if _, ok := singulars[resource]; !ok {
+		return nil, notFound()
+	}
+	if err := validate(resource, o); err != nil {
+		return nil, err
+	}
+
+	now := timestamp()
+	id := s.nextID()
+	o["id"] = id
+	o["created_at"] = now
+	o["updated_at"] = now
+	o["admin_graphql_api_id"] = fmt.Sprintf("gid://shopify/%s/%d", graphQLType(resource), id)
+
+	switch resource {
+	case "products":
+		if o["status"] == nil {
+			o["status"] = "active"
+		}
+		if o["handle"] == nil {
+			o["handle"] = handleize(fmt.Sprint(o["title"]))
+		}
+		if variants, _ := o["variants"].([]interface{}); len(variants) == 0 {
+			o["variants"] = []interface{}{object{"title": "Default Title", "option1": "Default Title", "price": "0.00"}}
+		}
+	case "variants":
+		if o["inventory_item_id"] == nil {
+			o["inventory_item_id"] = s.nextID()
+		}
+	case "orders":
+		if o["financial_status"] == nil {
+			o["financial_status"] = "pending"
+		}
+		o["name"] = fmt.Sprintf("#%d", len(s.table("orders").objects)+1001)
+	}
+
+	embedded := s.split(resource, o)
+	s.table(resource).objects[id] = o
+	if err := s.saveChildren(resource, id, embedded); err != nil {
+		delete(s.table(resource).objects, id)
+		return nil, err
+	}
+
+	return o, nil
+}
+
+// update merges the fields of changes into the stored resource. A rejected
+// update leaves the resource and its children as they were.
+func (s *store) update(resource string, id int64, changes object) (object, *apiError) {
+	o, ok := s.get(resource, id)
+	if !ok {
+		return nil, notFound()
+	}
+
+	delete(changes, "id")
+	embedded := s.split(resource, changes)
+	updated := make(object, len(o)+len(changes))
+	for k, v := range o {
+		updated[k] = v
+	}
+	for k, v := range changes {
+		updated[k] = v
+	}
+	if err := validate(resource, updated); err != nil {
+		return nil, err
+	}
+	updated["updated_at"] = timestamp()
+
+	saved := s.snapshot()
+	if err := s.saveChildren(resource, id, embedded); err != nil {
+		s.tables = saved
+		return nil, err
+	}
+
+	// written back into the stored object, which may be in the table of a
+	// kind of collections
+	for k := range o {
+		delete(o, k)
+	}
+	for k, v := range updated {
+		o[k] = v
+	}
+	return o, nil
+}
+
+// snapshot returns a copy of the tables, to restore them after a failed
+// write.
+func (s *store) snapshot() map[string]*table {
+	tables := make(map[string]*table, len(s.tables))
+	for name, t := range s.tables {
+		objects := make(map[int64]object, len(t.objects))
+		for id, o := range t.objects {
+			copied := make(object, len(o))
+			for k, v := range o {
+				copied[k] = v
+			}
+			objects[id] = copied
+		}
+		tables[name] = &table{objects: objects}
+	}
+	return tables
+}
+
+// delete removes a resource along with its children.
+func (s *store) delete(resource string, id int64) *apiError {
+	if _, ok := s.table(resource).objects[id]; !ok {
+		return notFound()
+	}
+	delete(s.table(resource).objects, id)
+
+	for child, key := range children[resource] {
+		for childID, o := range s.table(child).objects {
+			if idOf(o[key]) == id {
+				delete(s.table(child).objects, childID)
+			}
+		}
+	}
+
+	return nil
+}
+
+// split removes the embedded children of a resource from its fields.
+func (s *store) split(resource string, o object) map[string][]interface{} {
+	embedded := map[string][]interface{}{}
+	for child := range children[resource] {
+		if values, ok := o[child].([]interface{}); ok {
+			embedded[child] = values
+		}
+		delete(o, child)
+	}
+	return embedded
+}
+
+// saveChildren creates or updates the embedded children of a resource.
+func (s *store) saveChildren(resource string, id int64, embedded map[string][]interface{}) *apiError {
+	for child, values := range embedded {
+		key := children[resource][child]
+		for _, value := range values {
+			o, ok := value.(object)
+			if !ok {
+				return &apiError{status: http.StatusUnprocessableEntity, errors: object{child: []string{"is invalid"}}}
+			}
+
+			o[key] = id
+			if childID := idOf(o["id"]); childID != 0 {
+				if _, err := s.update(child, childID, o); err != nil {
+					return err
+				}
+				continue
+			}
+			if _, err := s.create(child, o); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// render returns a copy of a resource with its children embedded.
+func (s *store) render(resource string, o object) object {
+	rendered := make(object, len(o))
+	for k, v := range o {
+		rendered[k] = v
+	}
+
+	for child, key := range children[resource] {
+		values := []interface{}{}
+		for _, c := range s.table(child).sorted() {
+			if idOf(c[key]) == idOf(o["id"]) {
+				values = append(values, s.render(child, c))
+			}
+		}
+		rendered[child] = values
+	}
+
+	return rendered
+}
+
+// inventoryLevel returns the level of an item at a location, creating it when
+// create is set.
+func (s *store) inventoryLevel(itemID, locationID int64, create bool) (object, bool) {
+	for _, o := range s.table("inventory_levels").objects {
+		if idOf(o["inventory_item_id"]) == itemID && idOf(o["location_id"]) == locationID {
+			return o, true
+		}
+	}
+	if !create {
+		return nil, false
+	}
+
+	id := s.nextID()
+	o := object{"id": id, "inventory_item_id": itemID, "location_id": locationID, "available": int64(0), "updated_at": timestamp()}
+	s.table("inventory_levels").objects[id] = o
+	return o, true
+}
+
+func (s *store) deleteInventoryLevel(itemID, locationID int64) bool {
+	for id, o := range s.table("inventory_levels").objects {
+		if idOf(o["inventory_item_id"]) == itemID && idOf(o["location_id"]) == locationID {
+			delete(s.table("inventory_levels").objects, id)
+			return true
+		}
+	}
+	return false
+}
+
+func validate(resource string, o object) *apiError {
+	fields := object{}
+	for _, field := range required[resource] {
+		if v, ok := o[field]; !ok || v == nil || v == "" {
+			fields[field] = []string{"can't be blank"}
+		}
+	}
+	if len(fields) > 0 {
+		return &apiError{status: http.StatusUnprocessableEntity, errors: fields}
+	}
+	return nil
+}
+
+// toObject returns the JSON object of v, keeping numbers as json.Number.
+func toObject(v interface{}) (object, error) {
+	data, ok := v.([]byte)
+	if !ok {
+		var err error
+		if data, err = json.Marshal(v); err != nil {
+			return nil, err
+		}
+	}
+
+	var o object
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&o); err != nil {
+		return nil, err
+	}
+	if o == nil {
+		o = object{}
+	}
+	return o, nil
+}
+
+// idOf returns the id held by a field, 0 when it is not an id.
+func idOf(v interface{}) int64 {
+	id, _ := strconv.ParseInt(fmt.Sprint(v), 10, 64)
+	return id
+}
+
+func timestamp() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
+
+func handleize(title string) string {
+	return strings.Join(strings.Fields(strings.ToLower(title)), "-")
+}
+
+// graphQLType returns the type of a resource in its GraphQL id, e.g.
+// ProductVariant for variants.
+func graphQLType(resource string) string {
+	switch resource {
+	case "variants":
+		return "ProductVariant"
+	case "images":
+		return "ProductImage"
+	case "custom_collections", "smart_collections":
+		return "Collection"
+	case "webhooks":
+		return "WebhookSubscription"
+	}
+
+	singular := singulars[resource]
+	parts := strings.Split(singular, "_")
+	for i, part := range parts {
+		parts[i] = strings.ToUpper(part[:1]) + part[1:]
+	}
+	return strings.Join(parts, "")
+}
@@ -0,0 +1,156 @@
+package synergyshopify
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrStopStream can be returned by the callback of Stream and StreamAll to
+// stop decoding without an error.
+var ErrStopStream = errors.New("stop stream")
+
+// responseDecoder is implemented by resources that decode the response body
+// themselves instead of having it decoded into them at once.
+type responseDecoder interface {
+	decodeResponse(r io.Reader) error
+}
+
+// streamDecoder decodes the elements of the array of a list response envelope,
+// e.g. the "orders" of an OrdersResource, one at a time.
+type streamDecoder[T any] struct {
+	fn func(T) error
+
+	// error returned by fn, passed on to the caller unwrapped
+	err error
+}
+
+// Stream performs a GET request for the given list path and calls fn with
+// every element of the array of the response envelope as soon as it is
+// decoded, e.g.
+//
+//	pagination, err := Stream(client, "orders.json", options, func(order Order) error {
+//		...
+//	})
+//
+// Unlike List, the page is never held in memory at once. Returning an error
+// from fn stops the decoding and that error is returned, except ErrStopStream
+// which stops it silently.
+func Stream[T any](c *Client, path string, options interface{}, fn func(T) error) (*Pagination, error) {
+	decoder := &streamDecoder[T]{fn: fn}
+	pagination, err := c.ListWithPagination(path, decoder, options)
+	if decoder.err != nil {
+		err = decoder.err
+	}
+	if errors.Is(err, ErrStopStream) {
+		return nil, nil
+	}
+	return pagination, err
+}
+
+// StreamAll streams every page of the given list path, see Stream.
+func StreamAll[T any](c *Client, path string, options interface{}, fn func(T) error) error {
+	stopped := false
+	stop := func(item T) error {
+		err := fn(item)
+		if errors.Is(err, ErrStopStream) {
+			stopped = true
+		}
+		return err
+	}
+
+	original := options
+	for {
+		pagination, err := Stream(c, path, options, stop)
+		if err != nil || stopped {
+			return err
+		}
+
+		if pagination == nil || pagination.NextPageOptions == nil {
+			return nil
+		}
+		options = nextPageOptions(original, pagination.NextPageOptions)
+	}
+}
+
+func (d *streamDecoder[T]) decodeResponse(r io.Reader) error {
+	dec := json.NewDecoder(r)
+
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		// the key of the envelope, e.g. "orders"
+		if _, err := dec.Token(); err != nil {
+			return err
+		}
+
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		switch tok {
+		case json.Delim('['):
+			for dec.More() {
+				var item T
+				if err := dec.Decode(&item); err != nil {
+					return err
+				}
+				if err := d.fn(item); err != nil {
+					d.err = err
+					return err
+				}
+			}
+			// closing ]
+			if _, err := dec.Token(); err != nil {
+				return err
+			}
+		case json.Delim('{'):
+			if err := skipValue(dec); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// expectDelim reads the next token of dec and checks that it is delim.
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	if tok != delim {
+		return ResponseDecodingError{
+			Message: fmt.Sprintf("expected %s but found %v", delim, tok),
+		}
+	}
+
+	return nil
+}
+
+// skipValue reads the tokens of dec up to the end of the object or array whose
+// opening delimiter was just read.
+func skipValue(dec *json.Decoder) error {
+	depth := 1
+	for depth > 0 {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		switch tok {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+	}
+
+	return nil
+}
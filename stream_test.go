@@ -0,0 +1,128 @@
+package synergyshopify
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestStream(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"meta": {"nested": [1, {"a": []}]}, "orders": [{"id":1},{"id":2},{"id":3}], "count": 3}`).
+			HeaderSet(http.Header{
+				"Link": {`<https://fooshop.myshopify.com/admin/api/2024-01/orders.json?page_info=abc&limit=3>; rel="next"`},
+			}))
+
+	var ids []int64
+	pagination, err := Stream(client, "orders.json", nil, func(order Order) error {
+		ids = append(ids, order.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Stream returned error: %v", err)
+	}
+
+	expected := []int64{1, 2, 3}
+	if !reflect.DeepEqual(ids, expected) {
+		t.Errorf("Stream returned ids %v, expected %v", ids, expected)
+	}
+
+	if pagination == nil || pagination.NextPageOptions == nil || pagination.NextPageOptions.PageInfo != "abc" {
+		t.Errorf("Stream returned pagination %+v, expected next page abc", pagination)
+	}
+}
+
+func TestStreamStop(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"orders": [{"id":1},{"id":2},{"id":3}]}`))
+
+	var ids []int64
+	_, err := Stream(client, "orders.json", nil, func(order Order) error {
+		ids = append(ids, order.ID)
+		return ErrStopStream
+	})
+	if err != nil {
+		t.Errorf("Stream returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(ids, []int64{1}) {
+		t.Errorf("Stream returned ids %v, expected [1]", ids)
+	}
+
+	expectedErr := errors.New("oh no")
+	_, err = Stream(client, "orders.json", nil, func(order Order) error {
+		return expectedErr
+	})
+	if err != expectedErr {
+		t.Errorf("Stream returned error %v, expected %v", err, expectedErr)
+	}
+}
+
+func TestStreamInvalidJSON(t *testing.T) {
+	setup()
+	defer teardown()
+
+	cases := []string{
+		`[{"id":1}]`,
+		`{"orders": [{"id":1}, {"id":`,
+		`{"orders": [{"id":"foo"}]}`,
+	}
+
+	for _, body := range cases {
+		httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders.json", client.pathPrefix),
+			httpmock.NewStringResponder(200, body))
+
+		_, err := Stream(client, "orders.json", nil, func(order Order) error { return nil })
+		if err == nil {
+			t.Errorf("Stream(%s) expected an error", body)
+		}
+	}
+}
+
+func TestStreamAll(t *testing.T) {
+	setup()
+	defer teardown()
+
+	registerPagedResponders("customers.json", "customers", "id")
+
+	var ids []int64
+	options := ListOptions{Fields: "id", Limit: 2, Vendor: "foo"}
+	err := StreamAll(client, "customers.json", options, func(customer Customer) error {
+		ids = append(ids, customer.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamAll returned error: %v", err)
+	}
+
+	expected := []int64{1, 2, 3}
+	if !reflect.DeepEqual(ids, expected) {
+		t.Errorf("StreamAll returned ids %v, expected %v", ids, expected)
+	}
+
+	ids = nil
+	err = StreamAll(client, "customers.json", options, func(customer Customer) error {
+		ids = append(ids, customer.ID)
+		if len(ids) == 2 {
+			return ErrStopStream
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamAll returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(ids, []int64{1, 2}) {
+		t.Errorf("StreamAll returned ids %v, expected [1 2]", ids)
+	}
+}
@@ -12,6 +12,7 @@ const webhooksBasePath = "webhooks"
 // See: https://help.shopify.com/api/reference/webhook
 type WebhookService interface {
 	List(interface{}) ([]Webhook, error)
+	ListWithPagination(interface{}) ([]Webhook, *Pagination, error)
 	Count(interface{}) (int, error)
 	Get(int64, interface{}) (*Webhook, error)
 	Create(Webhook) (*Webhook, error)
@@ -57,10 +58,24 @@ type WebhooksResource struct {
 
 // List webhooks
 func (s *WebhookServiceOp) List(options interface{}) ([]Webhook, error) {
+	webhooks, _, err := s.ListWithPagination(options)
+	if err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+// ListWithPagination lists webhooks and return pagination to retrieve next/previous results.
+func (s *WebhookServiceOp) ListWithPagination(options interface{}) ([]Webhook, *Pagination, error) {
 	path := fmt.Sprintf("%s.json", webhooksBasePath)
 	resource := new(WebhooksResource)
-	err := s.client.Get(path, resource, options, true)
-	return resource.Webhooks, err
+
+	pagination, err := s.client.ListWithPagination(path, resource, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resource.Webhooks, pagination, nil
 }
 
 // Count webhooks
@@ -0,0 +1,250 @@
+package synergyshopify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Headers of the webhook requests sent by Shopify.
+const (
+	webhookTopicHeader       = "X-Shopify-Topic"
+	webhookShopDomainHeader  = "X-Shopify-Shop-Domain"
+	webhookIDHeader          = "X-Shopify-Webhook-Id"
+	webhookEventIDHeader     = "X-Shopify-Event-Id"
+	webhookAPIVersionHeader  = "X-Shopify-API-Version"
+	webhookTriggeredAtHeader = "X-Shopify-Triggered-At"
+)
+
+// default max size of the webhook payloads, see WebhookRouter.MaxBodySize
+const defaultWebhookMaxBodySize = 10 << 20
+
+// webhookPayloads returns the payload of the topics by resource, i.e. by the
+// part of the topic before the slash.
+var webhookPayloads = map[string]func() interface{}{
+	"app":                func() interface{} { return new(Shop) },
+	"checkouts":          func() interface{} { return new(AbandonedCheckout) },
+	"collections":        func() interface{} { return new(Collection) },
+	"customers":          func() interface{} { return new(Customer) },
+	"draft_orders":       func() interface{} { return new(DraftOrder) },
+	"fulfillments":       func() interface{} { return new(Fulfillment) },
+	"inventory_items":    func() interface{} { return new(InventoryItem) },
+	"inventory_levels":   func() interface{} { return new(InventoryLevel) },
+	"locations":          func() interface{} { return new(Location) },
+	"order_transactions": func() interface{} { return new(Transaction) },
+	"orders":             func() interface{} { return new(Order) },
+	"products":           func() interface{} { return new(Product) },
+	"refunds":            func() interface{} { return new(Refund) },
+	"shop":               func() interface{} { return new(Shop) },
+	"themes":             func() interface{} { return new(Theme) },
+}
+
+// webhookGenericTopics are the topics whose payload isn't the resource of
+// their prefix, e.g. the mandatory privacy webhooks.
+var webhookGenericTopics = map[string]bool{
+	"customers/data_request": true,
+	"customers/redact":       true,
+	"shop/redact":            true,
+}
+
+// NewWebhookPayload returns a pointer to a new payload of the given topic,
+// e.g. a *Order for orders/create, or a *map[string]interface{} for the
+// topics without a matching type.
+func NewWebhookPayload(topic string) interface{} {
+	resource, _, _ := strings.Cut(topic, "/")
+	if payload, ok := webhookPayloads[resource]; ok && !webhookGenericTopics[topic] {
+		return payload()
+	}
+	return new(map[string]interface{})
+}
+
+// WebhookEvent is a webhook received from Shopify.
+type WebhookEvent struct {
+	Topic      string
+	ShopDomain string
+	WebhookID  string
+	// EventID identifies the event the webhook was sent for, which is shared
+	// by the webhooks of the different subscriptions to it.
+	EventID     string
+	APIVersion  string
+	TriggeredAt time.Time
+
+	// Body is the raw payload and Payload the decoded one, see
+	// NewWebhookPayload. Payload is nil when the body doesn't decode into
+	// the type of the topic, e.g. after a change of the type of a field, the
+	// error being PayloadError.
+	Body         []byte
+	Payload      interface{}
+	PayloadError error
+}
+
+// WebhookHandlerFunc handles a webhook. An error makes the router answer 500
+// Internal Server Error, which Shopify retries.
+type WebhookHandlerFunc func(ctx context.Context, event *WebhookEvent) error
+
+// WebhookPayload returns a WebhookHandlerFunc passing the payload to fn as a
+// *T, e.g.
+//
+//	router.Handle("orders/create", WebhookPayload(func(ctx context.Context, event *WebhookEvent, order *Order) error {
+//		...
+//	}))
+//
+// The body is decoded into a new T when the payload of the topic is of
+// another type. A payload that doesn't decode into T fails the handler
+// without calling fn.
+func WebhookPayload[T any](fn func(ctx context.Context, event *WebhookEvent, payload *T) error) WebhookHandlerFunc {
+	return func(ctx context.Context, event *WebhookEvent) error {
+		payload, ok := event.Payload.(*T)
+		if !ok {
+			payload = new(T)
+			if err := json.Unmarshal(event.Body, payload); err != nil {
+				return fmt.Errorf("invalid %s webhook payload: %w", event.Topic, err)
+			}
+		}
+		return fn(ctx, event, payload)
+	}
+}
+
+// WebhookRouter is an http.Handler receiving the webhooks sent by Shopify. It
+// verifies their signature with the ApiSecret of the app, decodes their
+// payload and dispatches them to the handler of their topic:
+//
+//	router := NewWebhookRouter(app)
+//	router.Handle("orders/create", WebhookPayload(func(ctx context.Context, event *WebhookEvent, order *Order) error {
+//		...
+//	}))
+//	http.Handle("/webhooks", router)
+//
+// It answers 405 Method Not Allowed to requests other than POST, 413 Request
+// Entity Too Large to payloads over MaxBodySize, 401 Unauthorized to requests
+// with an invalid signature, 400 Bad Request to requests without a topic and
+// 404 Not Found to topics without a handler. Payloads that don't decode into
+// the type of their topic are still passed on to the handler, see
+// WebhookEvent.PayloadError.
+type WebhookRouter struct {
+	// MaxBodySize is the max size of a payload in bytes, 10 MB by default.
+	MaxBodySize int64
+
+	// Logger logs the rejected webhooks and the errors of the handlers, if
+	// set.
+	Logger LeveledLoggerInterface
+
+	app App
+
+	mu       sync.RWMutex
+	handlers map[string]WebhookHandlerFunc
+}
+
+// NewWebhookRouter returns a router verifying the webhooks with the ApiSecret
+// of the app.
+func NewWebhookRouter(app App) *WebhookRouter {
+	return &WebhookRouter{app: app, handlers: map[string]WebhookHandlerFunc{}}
+}
+
+// Handle registers the handler of a topic, e.g. "orders/create". A topic
+// ending with "/*" handles the topics of a resource without a handler of
+// their own, e.g. "orders/*", and "*" handles every topic without a handler.
+func (r *WebhookRouter) Handle(topic string, fn WebhookHandlerFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers[topic] = fn
+}
+
+// handler returns the handler of a topic.
+func (r *WebhookRouter) handler(topic string) WebhookHandlerFunc {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	resource, _, _ := strings.Cut(topic, "/")
+	for _, pattern := range []string{topic, resource + "/*", "*"} {
+		if fn, ok := r.handlers[pattern]; ok {
+			return fn
+		}
+	}
+	return nil
+}
+
+func (r *WebhookRouter) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		r.reject(w, http.StatusMethodNotAllowed, "webhook request with method %s", req.Method)
+		return
+	}
+
+	if r.app.ApiSecret == "" {
+		r.reject(w, http.StatusInternalServerError, "webhook received without an ApiSecret to verify it")
+		return
+	}
+
+	maxBodySize := r.MaxBodySize
+	if maxBodySize <= 0 {
+		maxBodySize = defaultWebhookMaxBodySize
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, req.Body, maxBodySize))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			r.reject(w, http.StatusRequestEntityTooLarge, "webhook payload over %d bytes", maxBodySize)
+			return
+		}
+		r.reject(w, http.StatusBadRequest, "reading webhook payload: %s", err)
+		return
+	}
+
+	if !verifyWebhookHMAC(r.app.ApiSecret, body, req.Header.Get(shopifyChecksumHeader)) {
+		r.reject(w, http.StatusUnauthorized, "webhook with an invalid signature from %s", req.Header.Get(webhookShopDomainHeader))
+		return
+	}
+
+	event := &WebhookEvent{
+		Topic:      req.Header.Get(webhookTopicHeader),
+		ShopDomain: req.Header.Get(webhookShopDomainHeader),
+		WebhookID:  req.Header.Get(webhookIDHeader),
+		EventID:    req.Header.Get(webhookEventIDHeader),
+		APIVersion: req.Header.Get(webhookAPIVersionHeader),
+		Body:       body,
+	}
+	event.TriggeredAt, _ = time.Parse(time.RFC3339Nano, req.Header.Get(webhookTriggeredAtHeader))
+	if event.Topic == "" {
+		r.reject(w, http.StatusBadRequest, "webhook without %s header", webhookTopicHeader)
+		return
+	}
+
+	fn := r.handler(event.Topic)
+	if fn == nil {
+		r.reject(w, http.StatusNotFound, "no handler of webhook topic %s", event.Topic)
+		return
+	}
+
+	event.Payload = NewWebhookPayload(event.Topic)
+	if err := json.Unmarshal(body, event.Payload); err != nil {
+		if r.Logger != nil {
+			r.Logger.Warnf("invalid %s webhook payload %s from %s: %s", event.Topic, event.WebhookID, event.ShopDomain, err)
+		}
+		event.Payload, event.PayloadError = nil, err
+	}
+
+	if err := fn(req.Context(), event); err != nil {
+		if r.Logger != nil {
+			r.Logger.Errorf("%s webhook %s from %s failed: %s", event.Topic, event.WebhookID, event.ShopDomain, err)
+		}
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// reject answers a webhook request with an error status.
+func (r *WebhookRouter) reject(w http.ResponseWriter, status int, format string, v ...interface{}) {
+	if r.Logger != nil {
+		r.Logger.Warnf(format, v...)
+	}
+	http.Error(w, http.StatusText(status), status)
+}
@@ -0,0 +1,194 @@
+package synergyshopify
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const webhookSecret = "hush"
+
+// webhookRequest returns a webhook request of the topic signed with secret.
+func webhookRequest(secret, topic, body string) *http.Request {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+
+	req := httptest.NewRequest("POST", "/webhooks", strings.NewReader(body))
+	req.Header.Set(shopifyChecksumHeader, base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+	req.Header.Set(webhookTopicHeader, topic)
+	req.Header.Set(webhookShopDomainHeader, "fooshop.myshopify.com")
+	req.Header.Set(webhookIDHeader, "b54557e4-bdd9-4b37-8a5f-bf7d70bcd043")
+	req.Header.Set(webhookEventIDHeader, "98880550-7158-44d4-b7cd-2c97c8a091b5")
+	req.Header.Set(webhookAPIVersionHeader, "2024-01")
+	req.Header.Set(webhookTriggeredAtHeader, "2024-01-02T10:00:00.123456789Z")
+	return req
+}
+
+func TestWebhookRouter(t *testing.T) {
+	router := NewWebhookRouter(App{ApiSecret: webhookSecret})
+
+	var received *WebhookEvent
+	var order *Order
+	router.Handle("orders/create", WebhookPayload(func(ctx context.Context, event *WebhookEvent, o *Order) error {
+		received, order = event, o
+		return nil
+	}))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, webhookRequest(webhookSecret, "orders/create", `{"id": 820982911946154508, "email": "jon@example.com"}`))
+	if w.Code != http.StatusOK {
+		t.Fatalf("WebhookRouter answered %d, expected 200", w.Code)
+	}
+	if order == nil || order.ID != 820982911946154508 || order.Email != "jon@example.com" {
+		t.Errorf("unexpected order %+v", order)
+	}
+
+	triggeredAt := time.Date(2024, 1, 2, 10, 0, 0, 123456789, time.UTC)
+	if received.Topic != "orders/create" || received.ShopDomain != "fooshop.myshopify.com" || received.APIVersion != "2024-01" ||
+		received.WebhookID != "b54557e4-bdd9-4b37-8a5f-bf7d70bcd043" || received.EventID != "98880550-7158-44d4-b7cd-2c97c8a091b5" ||
+		!received.TriggeredAt.Equal(triggeredAt) {
+		t.Errorf("unexpected event %+v", received)
+	}
+}
+
+func TestWebhookRouterDispatch(t *testing.T) {
+	router := NewWebhookRouter(App{ApiSecret: webhookSecret})
+
+	var handled []string
+	handle := func(name string) WebhookHandlerFunc {
+		return func(ctx context.Context, event *WebhookEvent) error {
+			handled = append(handled, name+" "+event.Topic)
+			return nil
+		}
+	}
+	router.Handle("products/update", handle("update"))
+	router.Handle("products/*", handle("products"))
+
+	cases := map[string]int{
+		"products/update": http.StatusOK,
+		"products/create": http.StatusOK,
+		"orders/create":   http.StatusNotFound,
+	}
+	for topic, expected := range cases {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, webhookRequest(webhookSecret, topic, `{"id": 1}`))
+		if w.Code != expected {
+			t.Errorf("WebhookRouter answered %d to %s, expected %d", w.Code, topic, expected)
+		}
+	}
+
+	router.Handle("*", handle("any"))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, webhookRequest(webhookSecret, "orders/create", `{"id": 1}`))
+	if w.Code != http.StatusOK {
+		t.Errorf("WebhookRouter answered %d, expected the catch-all handler", w.Code)
+	}
+
+	expected := map[string]bool{"update products/update": true, "products products/create": true, "any orders/create": true}
+	if len(handled) != len(expected) {
+		t.Errorf("unexpected handlers %q", handled)
+	}
+	for _, h := range handled {
+		if !expected[h] {
+			t.Errorf("unexpected handler %q", h)
+		}
+	}
+}
+
+func TestWebhookRouterErrors(t *testing.T) {
+	router := NewWebhookRouter(App{ApiSecret: webhookSecret})
+	router.MaxBodySize = 64
+	router.Handle("*", func(ctx context.Context, event *WebhookEvent) error {
+		if event.Topic == "orders/paid" {
+			return errors.New("database is down")
+		}
+		return nil
+	})
+
+	get := webhookRequest(webhookSecret, "orders/create", `{}`)
+	get.Method = "GET"
+	noTopic := webhookRequest(webhookSecret, "", `{}`)
+	unsigned := webhookRequest(webhookSecret, "orders/create", `{}`)
+	unsigned.Header.Del(shopifyChecksumHeader)
+
+	cases := []struct {
+		name     string
+		req      *http.Request
+		expected int
+	}{
+		{"method", get, http.StatusMethodNotAllowed},
+		{"unsigned", unsigned, http.StatusUnauthorized},
+		{"signature", webhookRequest("wrong", "orders/create", `{}`), http.StatusUnauthorized},
+		{"too large", webhookRequest(webhookSecret, "orders/create", `{"note": "`+strings.Repeat("a", 64)+`"}`), http.StatusRequestEntityTooLarge},
+		{"topic", noTopic, http.StatusBadRequest},
+		{"handler", webhookRequest(webhookSecret, "orders/paid", `{}`), http.StatusInternalServerError},
+	}
+	for _, c := range cases {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, c.req)
+		if w.Code != c.expected {
+			t.Errorf("%s: WebhookRouter answered %d, expected %d", c.name, w.Code, c.expected)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	NewWebhookRouter(App{}).ServeHTTP(w, webhookRequest("", "orders/create", `{}`))
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("WebhookRouter without secret answered %d, expected 500", w.Code)
+	}
+}
+
+func TestWebhookRouterInvalidPayload(t *testing.T) {
+	router := NewWebhookRouter(App{ApiSecret: webhookSecret})
+
+	var received *WebhookEvent
+	router.Handle("orders/create", func(ctx context.Context, event *WebhookEvent) error {
+		received = event
+		return nil
+	})
+	called := false
+	router.Handle("orders/updated", WebhookPayload(func(ctx context.Context, event *WebhookEvent, order *Order) error {
+		called = true
+		return nil
+	}))
+
+	body := `{"id": "one", "email": "jon@example.com"}`
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, webhookRequest(webhookSecret, "orders/create", body))
+	if w.Code != http.StatusOK {
+		t.Errorf("WebhookRouter answered %d, expected 200", w.Code)
+	}
+	if received == nil || received.Payload != nil || received.PayloadError == nil || string(received.Body) != body {
+		t.Errorf("expected the event with the raw payload and its error, got %+v", received)
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, webhookRequest(webhookSecret, "orders/updated", body))
+	if w.Code != http.StatusInternalServerError || called {
+		t.Errorf("WebhookRouter answered %d, expected the typed handler to fail without being called", w.Code)
+	}
+}
+
+func TestNewWebhookPayload(t *testing.T) {
+	cases := map[string]interface{}{
+		"orders/updated":           &Order{},
+		"inventory_levels/update":  &InventoryLevel{},
+		"fulfillments/create":      &Fulfillment{},
+		"app/uninstalled":          &Shop{},
+		"customers/redact":         &map[string]interface{}{},
+		"subscription_billing/foo": &map[string]interface{}{},
+	}
+	for topic, expected := range cases {
+		if actual := NewWebhookPayload(topic); fmt.Sprintf("%T", actual) != fmt.Sprintf("%T", expected) {
+			t.Errorf("NewWebhookPayload(%q) returned %T, expected %T", topic, actual, expected)
+		}
+	}
+}
@@ -0,0 +1,301 @@
+package synergyshopify
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// DefaultWebhookWindow is the window of DeduplicateWebhooks by default,
+// longer than Shopify keeps retrying a failed webhook.
+const DefaultWebhookWindow = 48 * time.Hour
+
+// interval between the removals of the expired keys of a MemoryWebhookStore
+const webhookStoreSweepInterval = time.Minute
+
+// WebhookStore records the webhooks already handled, see DeduplicateWebhooks.
+type WebhookStore interface {
+	// Claim records key for ttl, returning false if it's already recorded.
+	Claim(ctx context.Context, key string, ttl time.Duration) (bool, error)
+	// Release forgets key, so that it can be claimed again.
+	Release(ctx context.Context, key string) error
+}
+
+// DeduplicateWebhooks wraps webhook handlers so that they're called once per
+// webhook within window, DefaultWebhookWindow if 0, e.g.
+//
+//	dedupe := DeduplicateWebhooks(NewMemoryWebhookStore(), 0)
+//	router.Handle("orders/create", dedupe(handleOrder))
+//
+// Webhooks are identified by their X-Shopify-Event-Id and topic, which are
+// shared by the retries of a webhook and by the webhooks of duplicate
+// subscriptions, or by their X-Shopify-Webhook-Id if they have no event id.
+// Webhooks with neither are always handled.
+//
+// The duplicates are answered 200 OK without calling the handler. A webhook
+// is released when its handler fails, so that its retry is handled again.
+func DeduplicateWebhooks(store WebhookStore, window time.Duration) func(WebhookHandlerFunc) WebhookHandlerFunc {
+	if window <= 0 {
+		window = DefaultWebhookWindow
+	}
+
+	return func(next WebhookHandlerFunc) WebhookHandlerFunc {
+		return func(ctx context.Context, event *WebhookEvent) error {
+			key := webhookKey(event)
+			if key == "" {
+				return next(ctx, event)
+			}
+
+			claimed, err := store.Claim(ctx, key, window)
+			if err != nil {
+				return fmt.Errorf("claiming webhook %s: %w", key, err)
+			}
+			if !claimed {
+				return nil
+			}
+
+			if err := next(ctx, event); err != nil {
+				if releaseErr := store.Release(ctx, key); releaseErr != nil {
+					return fmt.Errorf("%w (releasing webhook %s: %s)", err, key, releaseErr)
+				}
+				return err
+			}
+			return nil
+		}
+	}
+}
+
+// webhookKey returns the key of a webhook in a WebhookStore.
+func webhookKey(event *WebhookEvent) string {
+	switch {
+	case event.EventID != "":
+		return event.ShopDomain + " " + event.Topic + " event " + event.EventID
+	case event.WebhookID != "":
+		return event.ShopDomain + " webhook " + event.WebhookID
+	}
+	return ""
+}
+
+// MemoryWebhookStore is a WebhookStore keeping the keys in memory, which
+// deduplicates the webhooks received by a single process.
+type MemoryWebhookStore struct {
+	mu        sync.Mutex
+	keys      map[string]time.Time
+	nextSweep time.Time
+	now       func() time.Time
+}
+
+// NewMemoryWebhookStore returns an empty MemoryWebhookStore.
+func NewMemoryWebhookStore() *MemoryWebhookStore {
+	return &MemoryWebhookStore{keys: map[string]time.Time{}, now: time.Now}
+}
+
+// Claim records key until ttl from now.
+func (s *MemoryWebhookStore) Claim(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, claimed := s.claim(key, ttl)
+	return claimed, nil
+}
+
+// Release forgets key.
+func (s *MemoryWebhookStore) Release(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.keys, key)
+	return nil
+}
+
+// claim records key, returning its expiry time and whether it wasn't
+// recorded yet. The expired keys are removed once per sweep interval.
+func (s *MemoryWebhookStore) claim(key string, ttl time.Duration) (time.Time, bool) {
+	now := s.now()
+	if !now.Before(s.nextSweep) {
+		for k, expires := range s.keys {
+			if !now.Before(expires) {
+				delete(s.keys, k)
+			}
+		}
+		s.nextSweep = now.Add(webhookStoreSweepInterval)
+	}
+
+	if expires, ok := s.keys[key]; ok && now.Before(expires) {
+		return expires, false
+	}
+	expires := now.Add(ttl)
+	s.keys[key] = expires
+	return expires, true
+}
+
+// webhookStoreRecord is a line of the file of a FileWebhookStore, a release
+// having no expiry time.
+type webhookStoreRecord struct {
+	Key     string     `json:"key"`
+	Expires *time.Time `json:"expires,omitempty"`
+}
+
+// FileWebhookStore is a WebhookStore keeping the keys in memory and appending
+// them to a file, so that they survive restarts. The file is compacted when
+// it's opened and when most of its records are outdated. It must not be
+// shared by several processes.
+type FileWebhookStore struct {
+	memory *MemoryWebhookStore
+
+	mu      sync.Mutex
+	path    string
+	file    *os.File
+	records int
+}
+
+// OpenFileWebhookStore opens the FileWebhookStore of the file at path,
+// creating it if needed.
+func OpenFileWebhookStore(path string) (*FileWebhookStore, error) {
+	s := &FileWebhookStore{memory: NewMemoryWebhookStore(), path: path}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	if err := s.compact(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// load replays the records of the file.
+func (s *FileWebhookStore) load() error {
+	f, err := os.Open(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	now := s.memory.now()
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1<<20)
+	for line := 1; scanner.Scan(); line++ {
+		var record webhookStoreRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			// a partial last line is a write interrupted by a crash
+			if !scanner.Scan() {
+				break
+			}
+			return fmt.Errorf("invalid webhook store %s: line %d: %w", s.path, line, err)
+		}
+
+		switch {
+		case record.Expires == nil:
+			delete(s.memory.keys, record.Key)
+		case now.Before(*record.Expires):
+			s.memory.keys[record.Key] = *record.Expires
+		}
+	}
+	return scanner.Err()
+}
+
+// compact replaces the file with the records of the current keys and opens
+// it for appending.
+func (s *FileWebhookStore) compact() error {
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	w := bufio.NewWriter(tmp)
+	enc := json.NewEncoder(w)
+	for key, expires := range s.memory.keys {
+		expires := expires
+		if err := enc.Encode(webhookStoreRecord{Key: key, Expires: &expires}); err != nil {
+			tmp.Close()
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return err
+	}
+
+	if s.file != nil {
+		s.file.Close()
+	}
+	s.file, err = os.OpenFile(s.path, os.O_WRONLY|os.O_APPEND, 0o600)
+	s.records = len(s.memory.keys)
+	return err
+}
+
+// Claim records key until ttl from now.
+func (s *FileWebhookStore) Claim(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expires, claimed := s.memory.claim(key, ttl)
+	if !claimed {
+		return false, nil
+	}
+	if err := s.append(webhookStoreRecord{Key: key, Expires: &expires}); err != nil {
+		delete(s.memory.keys, key)
+		return false, err
+	}
+	return true, nil
+}
+
+// Release forgets key.
+func (s *FileWebhookStore) Release(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.memory.keys[key]; !ok {
+		return nil
+	}
+	delete(s.memory.keys, key)
+	return s.append(webhookStoreRecord{Key: key})
+}
+
+// append writes a record to the file, compacting it when it holds more than
+// twice as many records as keys.
+func (s *FileWebhookStore) append(record webhookStoreRecord) error {
+	if s.file == nil {
+		return os.ErrClosed
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	if _, err := s.file.Write(append(data, '\n')); err != nil {
+		return err
+	}
+
+	s.records++
+	if s.records > 1000 && s.records > 2*len(s.memory.keys) {
+		return s.compact()
+	}
+	return nil
+}
+
+// Close closes the file of the store.
+func (s *FileWebhookStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
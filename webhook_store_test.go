@@ -0,0 +1,133 @@
+package synergyshopify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDeduplicateWebhooks(t *testing.T) {
+	router := NewWebhookRouter(App{ApiSecret: webhookSecret})
+	dedupe := DeduplicateWebhooks(NewMemoryWebhookStore(), time.Hour)
+
+	calls := 0
+	fail := true
+	router.Handle("orders/create", dedupe(func(ctx context.Context, event *WebhookEvent) error {
+		calls++
+		if fail {
+			return errors.New("database is down")
+		}
+		return nil
+	}))
+
+	send := func(req *http.Request) int {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	if code := send(webhookRequest(webhookSecret, "orders/create", `{}`)); code != http.StatusInternalServerError || calls != 1 {
+		t.Fatalf("failing handler answered %d after %d calls", code, calls)
+	}
+
+	fail = false
+	for i := 0; i < 3; i++ {
+		if code := send(webhookRequest(webhookSecret, "orders/create", `{}`)); code != http.StatusOK {
+			t.Errorf("retry %d answered %d, expected 200", i, code)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("expected the retry of the failed webhook to be handled once, got %d calls", calls)
+	}
+
+	anonymous := webhookRequest(webhookSecret, "orders/create", `{}`)
+	anonymous.Header.Del(webhookIDHeader)
+	anonymous.Header.Del(webhookEventIDHeader)
+	send(anonymous)
+	if calls != 3 {
+		t.Errorf("expected a webhook without ids to be handled, got %d calls", calls)
+	}
+}
+
+func TestWebhookKey(t *testing.T) {
+	event := &WebhookEvent{ShopDomain: "fooshop.myshopify.com", Topic: "orders/create", WebhookID: "w1", EventID: "e1"}
+	if key := webhookKey(event); key != "fooshop.myshopify.com orders/create event e1" {
+		t.Errorf("unexpected key %q", key)
+	}
+	event.EventID = ""
+	if key := webhookKey(event); key != "fooshop.myshopify.com webhook w1" {
+		t.Errorf("unexpected key %q", key)
+	}
+}
+
+func TestMemoryWebhookStore(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+	store := NewMemoryWebhookStore()
+	store.now = func() time.Time { return now }
+
+	if ok, _ := store.Claim(ctx, "a", time.Hour); !ok {
+		t.Error("expected a new key to be claimed")
+	}
+	if ok, _ := store.Claim(ctx, "a", time.Hour); ok {
+		t.Error("expected a claimed key not to be claimed again")
+	}
+
+	store.Release(ctx, "a")
+	if ok, _ := store.Claim(ctx, "a", time.Hour); !ok {
+		t.Error("expected a released key to be claimed again")
+	}
+
+	now = now.Add(time.Hour)
+	if ok, _ := store.Claim(ctx, "a", time.Hour); !ok {
+		t.Error("expected an expired key to be claimed again")
+	}
+
+	store.Claim(ctx, "b", time.Minute)
+	now = now.Add(2 * time.Minute)
+	store.Claim(ctx, "c", time.Hour)
+	if _, ok := store.keys["b"]; ok {
+		t.Error("expected the expired keys to be removed")
+	}
+}
+
+func TestFileWebhookStore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "webhooks.jsonl")
+
+	store, err := OpenFileWebhookStore(path)
+	if err != nil {
+		t.Fatalf("OpenFileWebhookStore returned error: %v", err)
+	}
+	for _, key := range []string{"a", "b", "c"} {
+		if ok, err := store.Claim(ctx, key, time.Hour); !ok || err != nil {
+			t.Errorf("Claim(%q) returned %v, %v", key, ok, err)
+		}
+	}
+	store.Claim(ctx, "expired", time.Nanosecond)
+	store.Release(ctx, "b")
+	store.Close()
+
+	// a crash while appending leaves a partial line
+	f, _ := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+	f.WriteString(`{"key":"d","exp`)
+	f.Close()
+
+	store, err = OpenFileWebhookStore(path)
+	if err != nil {
+		t.Fatalf("OpenFileWebhookStore returned error: %v", err)
+	}
+	defer store.Close()
+
+	expected := map[string]bool{"a": false, "b": true, "c": false, "d": true, "expired": true}
+	for key, claimable := range expected {
+		if ok, err := store.Claim(ctx, key, time.Hour); ok != claimable || err != nil {
+			t.Errorf("Claim(%q) after reopening returned %v, %v, expected %v", key, ok, err, claimable)
+		}
+	}
+}